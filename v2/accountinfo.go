@@ -0,0 +1,764 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// AccountIndex index of the account in the account table. These are assigned sequentially
+// in the order of creation of accounts. The first account has index 0.
+type AccountIndex struct {
+	Value uint64
+}
+
+func (AccountIndex) isAccountIdentifier() {}
+
+// CredentialRegistrationId a registration ID of a credential, derived from the secret PRF key and a nonce.
+// This is always 48 bytes long.
+type CredentialRegistrationId struct {
+	Value []byte
+}
+
+func (CredentialRegistrationId) isAccountIdentifier() {}
+
+func (AccountAddress) isAccountIdentifier() {}
+
+// isAccountIdentifier identifies an account either by its address, the registration ID of one of its
+// credentials or its index. Implemented by AccountAddress, CredentialRegistrationId and AccountIndex and pointers to them.
+type isAccountIdentifier interface {
+	isAccountIdentifier()
+}
+
+// convertAccountIdentifier converts an account identifier to *pb.AccountIdentifierInput. It returns an error
+// for nil pointers and identifiers of unknown types, which would otherwise be sent as an empty identifier.
+func convertAccountIdentifier(accId isAccountIdentifier) (*pb.AccountIdentifierInput, error) {
+	switch v := accId.(type) {
+	case AccountAddress:
+		return convertAccountAddressIdentifier(v), nil
+	case *AccountAddress:
+		if v != nil {
+			return convertAccountAddressIdentifier(*v), nil
+		}
+	case CredentialRegistrationId:
+		return convertCredentialRegistrationIdIdentifier(v), nil
+	case *CredentialRegistrationId:
+		if v != nil {
+			return convertCredentialRegistrationIdIdentifier(*v), nil
+		}
+	case AccountIndex:
+		return convertAccountIndexIdentifier(v), nil
+	case *AccountIndex:
+		if v != nil {
+			return convertAccountIndexIdentifier(*v), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported account identifier %T", accId)
+}
+
+func convertAccountAddressIdentifier(address AccountAddress) *pb.AccountIdentifierInput {
+	return &pb.AccountIdentifierInput{
+		AccountIdentifierInput: &pb.AccountIdentifierInput_Address{
+			Address: &pb.AccountAddress{Value: address.Value[:]},
+		}}
+}
+
+func convertCredentialRegistrationIdIdentifier(credId CredentialRegistrationId) *pb.AccountIdentifierInput {
+	return &pb.AccountIdentifierInput{
+		AccountIdentifierInput: &pb.AccountIdentifierInput_CredId{
+			CredId: &pb.CredentialRegistrationId{Value: credId.Value},
+		}}
+}
+
+func convertAccountIndexIdentifier(index AccountIndex) *pb.AccountIdentifierInput {
+	return &pb.AccountIdentifierInput{
+		AccountIdentifierInput: &pb.AccountIdentifierInput_AccountIndex{
+			AccountIndex: &pb.AccountIndex{Value: index.Value},
+		}}
+}
+
+// AccountInfo information about the account at a particular point in time.
+type AccountInfo struct {
+	// Next sequence number to be used for transactions signed from this account.
+	SequenceNumber SequenceNumber
+	// Current (unencrypted) balance of the account.
+	Amount Amount
+	// Release schedule for any locked up amount. This could be an empty release schedule.
+	Schedule ReleaseSchedule
+	// Map of all currently active credentials on the account. This includes public keys that can sign
+	// for the given credentials, as well as any revealed attributes. This map always contains a credential with index 0.
+	Creds map[CredentialIndex]AccountCredential
+	// Lower bound on how many credentials must sign any given transaction from this account.
+	Threshold AccountThreshold
+	// The encrypted balance of the account.
+	EncryptedBalance EncryptedBalance
+	// The public key for sending encrypted balances to the account.
+	EncryptionKey EncryptionKey
+	// Internal index of the account. The account index serves the role of the baker id, if the account is a baker.
+	Index AccountIndex
+	// Present if the account is a baker or delegator. In that case it is the information about the baker or delegator.
+	Stake *AccountStakingInfo
+	// Canonical address of the account. This is derived from the first credential that created the account.
+	Address AccountAddress
+	// The stake on the account that is in cooldown. There can be multiple amounts in cooldown that expire at
+	// different times. This was introduced in protocol version 7, and so is empty in earlier protocol versions.
+	Cooldowns []Cooldown
+	// The available (unencrypted) balance of the account (i.e. that can be transferred or used to pay for transactions).
+	// This was introduced in node version 7.0, and so is nil when querying earlier node versions.
+	AvailableBalance *Amount
+}
+
+// Parses *pb.AccountInfo to AccountInfo.
+func parseAccountInfo(a *pb.AccountInfo) (AccountInfo, error) {
+	address, err := AccountAddressFromBytes(a.Address.GetValue())
+	if err != nil {
+		return AccountInfo{}, errors.New("Error parsing AccountInfo: " + err.Error())
+	}
+
+	creds := make(map[CredentialIndex]AccountCredential, len(a.Creds))
+	for i, c := range a.Creds {
+		cred, err := parseAccountCredential(c)
+		if err != nil {
+			return AccountInfo{}, errors.New("Error parsing AccountInfo: " + err.Error())
+		}
+		creds[CredentialIndex(i)] = cred
+	}
+
+	var stake *AccountStakingInfo
+	if a.Stake != nil {
+		s, err := parseAccountStakingInfo(a.Stake)
+		if err != nil {
+			return AccountInfo{}, errors.New("Error parsing AccountInfo: " + err.Error())
+		}
+		stake = &s
+	}
+
+	cooldowns := make([]Cooldown, 0, len(a.Cooldowns))
+	for _, c := range a.Cooldowns {
+		cooldowns = append(cooldowns, parseCooldown(c))
+	}
+
+	var availableBalance *Amount
+	if a.AvailableBalance != nil {
+		amount := parseAmount(a.AvailableBalance)
+		availableBalance = &amount
+	}
+
+	return AccountInfo{
+		SequenceNumber:   SequenceNumber{Value: a.SequenceNumber.GetValue()},
+		Amount:           Amount{Value: a.Amount.GetValue()},
+		Schedule:         parseReleaseSchedule(a.Schedule),
+		Creds:            creds,
+		Threshold:        AccountThreshold{Value: uint8(a.Threshold.GetValue())},
+		EncryptedBalance: parseEncryptedBalance(a.EncryptedBalance),
+		EncryptionKey:    EncryptionKey{Value: a.EncryptionKey.GetValue()},
+		Index:            AccountIndex{Value: a.Index.GetValue()},
+		Stake:            stake,
+		Address:          address,
+		Cooldowns:        cooldowns,
+		AvailableBalance: availableBalance,
+	}, nil
+}
+
+// ReleaseSchedule state of the account's release schedule. This is the balance of the account that
+// is owned by the account, but cannot be used until the release point.
+type ReleaseSchedule struct {
+	// Total amount locked in the release schedule.
+	Total Amount
+	// A list of releases, ordered by increasing timestamp.
+	Schedules []Release
+}
+
+// Parses *pb.ReleaseSchedule to ReleaseSchedule.
+func parseReleaseSchedule(r *pb.ReleaseSchedule) ReleaseSchedule {
+	schedules := make([]Release, 0, len(r.GetSchedules()))
+	for _, s := range r.GetSchedules() {
+		schedules = append(schedules, parseRelease(s))
+	}
+
+	return ReleaseSchedule{
+		Total:     Amount{Value: r.GetTotal().GetValue()},
+		Schedules: schedules,
+	}
+}
+
+// Release an individual release of a locked balance.
+type Release struct {
+	// Effective time of the release in milliseconds since unix epoch.
+	Timestamp Timestamp
+	// Amount to be released.
+	Amount Amount
+	// List of transaction hashes that contribute a balance to this release.
+	Transactions []TransactionHash
+}
+
+// Parses *pb.Release to Release.
+func parseRelease(r *pb.Release) Release {
+	transactions := make([]TransactionHash, 0, len(r.Transactions))
+	for _, t := range r.Transactions {
+		var hash TransactionHash
+		copy(hash.Value[:], t.Value)
+		transactions = append(transactions, hash)
+	}
+
+	return Release{
+		Timestamp:    Timestamp{Value: r.Timestamp.GetValue()},
+		Amount:       Amount{Value: r.Amount.GetValue()},
+		Transactions: transactions,
+	}
+}
+
+// EncryptionKey elgamal public key used for receiving encrypted amounts.
+type EncryptionKey struct {
+	Value []byte
+}
+
+// EncryptedAmount an encrypted amount, in two chunks in "little endian limbs". That is, the first
+// chunk represents the low 32 bits of an amount, and the second chunk represents the high 32 bits.
+type EncryptedAmount struct {
+	Value []byte
+}
+
+// EncryptedBalance the encrypted balance of an account.
+type EncryptedBalance struct {
+	// Encrypted amount that is a result of this account's actions.
+	SelfAmount EncryptedAmount
+	// Starting index for incoming encrypted amounts. If an aggregated amount is present then this index is
+	// associated with such an amount and the list of incoming encrypted amounts starts at the index `StartIndex + 1`.
+	StartIndex uint64
+	// If present, the amount that has resulted from aggregating other amounts.
+	// If this field is present so is NumAggregated.
+	AggregatedAmount *EncryptedAmount
+	// The number of aggregated amounts (must be at least 2 if present).
+	NumAggregated *uint32
+	// Amounts starting at StartIndex (or at `StartIndex + 1` if there is an aggregated amount present).
+	IncomingAmounts []EncryptedAmount
+}
+
+// Parses *pb.EncryptedBalance to EncryptedBalance.
+func parseEncryptedBalance(e *pb.EncryptedBalance) EncryptedBalance {
+	var aggregatedAmount *EncryptedAmount
+	if e.GetAggregatedAmount() != nil {
+		aggregatedAmount = &EncryptedAmount{Value: e.AggregatedAmount.Value}
+	}
+
+	incomingAmounts := make([]EncryptedAmount, 0, len(e.GetIncomingAmounts()))
+	for _, a := range e.GetIncomingAmounts() {
+		incomingAmounts = append(incomingAmounts, EncryptedAmount{Value: a.Value})
+	}
+
+	return EncryptedBalance{
+		SelfAmount:       EncryptedAmount{Value: e.GetSelfAmount().GetValue()},
+		StartIndex:       e.GetStartIndex(),
+		AggregatedAmount: aggregatedAmount,
+		NumAggregated:    e.NumAggregated,
+		IncomingAmounts:  incomingAmounts,
+	}
+}
+
+// CooldownStatus the status of a cooldown. When stake is removed from a baker or delegator
+// (from protocol version 7) it first enters the pre-pre-cooldown state. The next time the stake
+// snapshot is taken (at the epoch transition before a payday) it enters the pre-cooldown state.
+// At the subsequent payday, it enters the cooldown state. At the payday after the end of the
+// cooldown period, the stake is finally released.
+type CooldownStatus uint8
+
+const (
+	// CooldownStatusCooldown the amount is in cooldown and will expire at the specified time,
+	// becoming available at the subsequent pay day.
+	CooldownStatusCooldown CooldownStatus = 0
+	// CooldownStatusPreCooldown the amount will enter cooldown at the next pay day.
+	CooldownStatusPreCooldown CooldownStatus = 1
+	// CooldownStatusPrePreCooldown the amount will enter pre-cooldown at the next snapshot epoch.
+	CooldownStatusPrePreCooldown CooldownStatus = 2
+)
+
+// Cooldown an amount of stake that is in cooldown.
+type Cooldown struct {
+	// The time in milliseconds since the Unix epoch when the cooldown period ends.
+	EndTime Timestamp
+	// The amount that is in cooldown and set to be released at the end of the cooldown period.
+	Amount Amount
+	// The status of the cooldown.
+	Status CooldownStatus
+}
+
+// Parses *pb.Cooldown to Cooldown.
+func parseCooldown(c *pb.Cooldown) Cooldown {
+	return Cooldown{
+		EndTime: Timestamp{Value: c.EndTime.GetValue()},
+		Amount:  Amount{Value: c.Amount.GetValue()},
+		Status:  CooldownStatus(c.Status),
+	}
+}
+
+// AccountPending an account with a pending scheduled release or cooldown, together with the time of the first
+// pending release or cooldown expiry of the account.
+type AccountPending struct {
+	AccountIndex AccountIndex
+	// The time in milliseconds since the Unix epoch of the first pending release or cooldown expiry.
+	FirstTimestamp Timestamp
+}
+
+// Parses *pb.AccountPending to AccountPending.
+func parseAccountPending(a *pb.AccountPending) AccountPending {
+	return AccountPending{
+		AccountIndex:   AccountIndex{Value: a.GetAccountIndex().GetValue()},
+		FirstTimestamp: Timestamp{Value: a.GetFirstTimestamp().GetValue()},
+	}
+}
+
+// Return type of GetScheduledReleaseAccounts and GetCooldownAccounts. Parses the returned *pb.AccountPending
+// to AccountPending when Recv() is called.
+type AccountPendingStream struct {
+	stream pb.Queries_GetCooldownAccountsClient
+}
+
+// Recv retrieves the next AccountPending.
+func (s *AccountPendingStream) Recv() (AccountPending, error) {
+	pending, err := s.stream.Recv()
+	if err != nil {
+		return AccountPending{}, err
+	}
+	return parseAccountPending(pending), nil
+}
+
+// Return type of GetPreCooldownAccounts and GetPrePreCooldownAccounts. Parses the returned *pb.AccountIndex
+// to AccountIndex when Recv() is called.
+type AccountIndexStream struct {
+	stream pb.Queries_GetPreCooldownAccountsClient
+}
+
+// Recv retrieves the next AccountIndex.
+func (s *AccountIndexStream) Recv() (AccountIndex, error) {
+	index, err := s.stream.Recv()
+	if err != nil {
+		return AccountIndex{}, err
+	}
+	return AccountIndex{Value: index.GetValue()}, nil
+}
+
+// AccountStakingInfo information about the baker or delegator of an account.
+// StakingInfo is either AccountStakingInfoBaker or AccountStakingInfoDelegator.
+type AccountStakingInfo struct {
+	StakingInfo isAccountStakingInfo
+}
+
+type isAccountStakingInfo interface {
+	isAccountStakingInfo()
+}
+
+// AccountStakingInfoBaker the account is a baker.
+type AccountStakingInfoBaker struct {
+	// Amount staked at present.
+	StakedAmount Amount
+	// A flag indicating whether rewards paid to the baker are automatically restaked or not.
+	RestakeEarnings bool
+	// Information about the baker that is staking.
+	BakerInfo BakerInfo
+	// If present, any pending change to the staked amount.
+	PendingChange *StakePendingChange
+	// Present if the account is currently a baker, i.e., it is in the baking committee of the current epoch.
+	PoolInfo *BakerPoolInfo
+	// A flag indicating whether the account is currently suspended or not. For protocol version < 8 the flag
+	// will always be set to false.
+	IsSuspended bool
+}
+
+// AccountStakingInfoDelegator the account is delegating stake to a baker or passive delegation.
+type AccountStakingInfoDelegator struct {
+	// The amount that the account delegates.
+	StakedAmount Amount
+	// Whether the earnings are automatically added to the staked amount.
+	RestakeEarnings bool
+	// The entity to which the account delegates.
+	Target DelegationTarget
+	// If present, any pending change to the delegated stake.
+	PendingChange *StakePendingChange
+}
+
+func (AccountStakingInfoBaker) isAccountStakingInfo()     {}
+func (AccountStakingInfoDelegator) isAccountStakingInfo() {}
+
+// StakedAmount returns the amount staked by the baker or delegated by the delegator.
+func (a *AccountStakingInfo) StakedAmount() Amount {
+	switch s := a.StakingInfo.(type) {
+	case AccountStakingInfoBaker:
+		return s.StakedAmount
+	case AccountStakingInfoDelegator:
+		return s.StakedAmount
+	}
+	return Amount{}
+}
+
+// Parses *pb.AccountStakingInfo to AccountStakingInfo.
+func parseAccountStakingInfo(s *pb.AccountStakingInfo) (AccountStakingInfo, error) {
+	switch v := s.StakingInfo.(type) {
+	case *pb.AccountStakingInfo_Baker_:
+		var pendingChange *StakePendingChange
+		if v.Baker.PendingChange != nil {
+			change := parseStakePendingChange(v.Baker.PendingChange)
+			pendingChange = &change
+		}
+
+		var poolInfo *BakerPoolInfo
+		if v.Baker.PoolInfo != nil {
+			info, err := parseBakerPoolInfo(v.Baker.PoolInfo)
+			if err != nil {
+				return AccountStakingInfo{}, err
+			}
+			poolInfo = &info
+		}
+
+		return AccountStakingInfo{StakingInfo: AccountStakingInfoBaker{
+			StakedAmount:    Amount{Value: v.Baker.StakedAmount.GetValue()},
+			RestakeEarnings: v.Baker.RestakeEarnings,
+			BakerInfo:       parseBakerInfo(v.Baker.BakerInfo),
+			PendingChange:   pendingChange,
+			PoolInfo:        poolInfo,
+			IsSuspended:     v.Baker.IsSuspended,
+		}}, nil
+	case *pb.AccountStakingInfo_Delegator_:
+		var pendingChange *StakePendingChange
+		if v.Delegator.PendingChange != nil {
+			change := parseStakePendingChange(v.Delegator.PendingChange)
+			pendingChange = &change
+		}
+
+		return AccountStakingInfo{StakingInfo: AccountStakingInfoDelegator{
+			StakedAmount:    Amount{Value: v.Delegator.StakedAmount.GetValue()},
+			RestakeEarnings: v.Delegator.RestakeEarnings,
+			Target:          parseDelegationTarget(v.Delegator.Target),
+			PendingChange:   pendingChange,
+		}}, nil
+	}
+
+	return AccountStakingInfo{}, errors.New("unknown account staking info")
+}
+
+// StakePendingChange a pending change to the stake of a baker or delegator.
+// Change is either StakePendingChangeReduce or StakePendingChangeRemove.
+type StakePendingChange struct {
+	Change isStakePendingChange
+}
+
+type isStakePendingChange interface {
+	isStakePendingChange()
+}
+
+// StakePendingChangeReduce the stake is being reduced. The new stake will take affect in the given epoch.
+type StakePendingChangeReduce struct {
+	NewStake Amount
+	// Unix timestamp in milliseconds when the change takes effect.
+	EffectiveTime Timestamp
+}
+
+// StakePendingChangeRemove the baker or delegator will be removed at the end of the given epoch.
+type StakePendingChangeRemove struct {
+	// Unix timestamp in milliseconds when the change takes effect.
+	EffectiveTime Timestamp
+}
+
+func (StakePendingChangeReduce) isStakePendingChange() {}
+func (StakePendingChangeRemove) isStakePendingChange() {}
+
+// Parses *pb.StakePendingChange to StakePendingChange.
+func parseStakePendingChange(s *pb.StakePendingChange) StakePendingChange {
+	switch v := s.Change.(type) {
+	case *pb.StakePendingChange_Reduce_:
+		return StakePendingChange{Change: StakePendingChangeReduce{
+			NewStake:      Amount{Value: v.Reduce.NewStake.GetValue()},
+			EffectiveTime: Timestamp{Value: v.Reduce.EffectiveTime.GetValue()},
+		}}
+	case *pb.StakePendingChange_Remove:
+		return StakePendingChange{Change: StakePendingChangeRemove{
+			EffectiveTime: Timestamp{Value: v.Remove.GetValue()},
+		}}
+	}
+
+	return StakePendingChange{}
+}
+
+// DelegationTarget the target of delegation. Target is either DelegationTargetPassive or DelegationTargetBaker.
+type DelegationTarget struct {
+	Target isDelegationTarget
+}
+
+type isDelegationTarget interface {
+	isDelegationTarget()
+}
+
+// DelegationTargetPassive delegate passively, i.e., to no specific baker.
+type DelegationTargetPassive struct{}
+
+// DelegationTargetBaker delegate to a specific baker.
+type DelegationTargetBaker struct {
+	BakerId BakerId
+}
+
+func (DelegationTargetPassive) isDelegationTarget() {}
+func (DelegationTargetBaker) isDelegationTarget()   {}
+
+// Parses *pb.DelegationTarget to DelegationTarget.
+func parseDelegationTarget(t *pb.DelegationTarget) DelegationTarget {
+	switch v := t.GetTarget().(type) {
+	case *pb.DelegationTarget_Passive:
+		return DelegationTarget{Target: DelegationTargetPassive{}}
+	case *pb.DelegationTarget_Baker:
+		return DelegationTarget{Target: DelegationTargetBaker{BakerId: parseBakerId(v.Baker)}}
+	}
+
+	return DelegationTarget{}
+}
+
+// OpenStatus the status of whether a baking pool allows delegators to join.
+type OpenStatus uint8
+
+const (
+	// OpenStatusOpenForAll new delegators may join the pool.
+	OpenStatusOpenForAll OpenStatus = 0
+	// OpenStatusClosedForNew new delegators may not join, but existing delegators are kept.
+	OpenStatusClosedForNew OpenStatus = 1
+	// OpenStatusClosedForAll no delegators are allowed.
+	OpenStatusClosedForAll OpenStatus = 2
+)
+
+// BakerPoolInfo additional information about a baking pool.
+type BakerPoolInfo struct {
+	// Whether the pool allows delegators.
+	OpenStatus OpenStatus
+	// The URL that links to the metadata about the pool.
+	Url string
+	// The commission rates charged by the pool owner.
+	CommissionRates CommissionRates
+}
+
+// Parses *pb.BakerPoolInfo to BakerPoolInfo.
+func parseBakerPoolInfo(b *pb.BakerPoolInfo) (BakerPoolInfo, error) {
+	commissionRates, err := parseCommissionRates(b.CommissionRates)
+	if err != nil {
+		return BakerPoolInfo{}, errors.New("Error parsing BakerPoolInfo: " + err.Error())
+	}
+
+	return BakerPoolInfo{
+		OpenStatus:      OpenStatus(b.OpenStatus),
+		Url:             b.Url,
+		CommissionRates: commissionRates,
+	}, nil
+}
+
+// AccountCredential a credential deployed on an account.
+// CredentialValues is either InitialCredentialValues or NormalCredentialValues.
+type AccountCredential struct {
+	CredentialValues isAccountCredentialValues
+}
+
+type isAccountCredentialValues interface {
+	isAccountCredentialValues()
+}
+
+// PublicKeys returns the public keys of the credential.
+func (a *AccountCredential) PublicKeys() CredentialPublicKeys {
+	switch c := a.CredentialValues.(type) {
+	case InitialCredentialValues:
+		return c.Keys
+	case NormalCredentialValues:
+		return c.Keys
+	}
+	return CredentialPublicKeys{}
+}
+
+// CredId returns the registration ID of the credential.
+func (a *AccountCredential) CredId() CredentialRegistrationId {
+	switch c := a.CredentialValues.(type) {
+	case InitialCredentialValues:
+		return c.CredId
+	case NormalCredentialValues:
+		return c.CredId
+	}
+	return CredentialRegistrationId{}
+}
+
+// InitialCredentialValues values in initial credential deployment.
+type InitialCredentialValues struct {
+	// Public keys of the credential.
+	Keys CredentialPublicKeys
+	// Its registration ID.
+	CredId CredentialRegistrationId
+	// The identity provider who signed the identity object from which this credential is derived.
+	IpId IdentityProviderIdentity
+	// Policy of this credential.
+	Policy Policy
+}
+
+// NormalCredentialValues values in normal credential deployment.
+type NormalCredentialValues struct {
+	// Public keys of the credential.
+	Keys CredentialPublicKeys
+	// Its registration ID.
+	CredId CredentialRegistrationId
+	// The identity provider who signed the identity object from which this credential is derived.
+	IpId IdentityProviderIdentity
+	// Policy of this credential.
+	Policy Policy
+	// The number of anonymity revokers that must work together to revoke the anonymity of the credential holder.
+	ArThreshold ArThreshold
+	// Mapping from anonymity revoker identities to revocation data for the given anonymity revoker.
+	ArData map[ArIdentity]ChainArData
+	// Commitments to attributes which have not been revealed.
+	Commitments CredentialCommitments
+}
+
+func (InitialCredentialValues) isAccountCredentialValues() {}
+func (NormalCredentialValues) isAccountCredentialValues()  {}
+
+// Parses *pb.AccountCredential to AccountCredential.
+func parseAccountCredential(c *pb.AccountCredential) (AccountCredential, error) {
+	switch v := c.CredentialValues.(type) {
+	case *pb.AccountCredential_Initial:
+		return AccountCredential{CredentialValues: InitialCredentialValues{
+			Keys:   parseCredentialPublicKeys(v.Initial.Keys),
+			CredId: CredentialRegistrationId{Value: v.Initial.CredId.GetValue()},
+			IpId:   IdentityProviderIdentity{Value: v.Initial.IpId.GetValue()},
+			Policy: parsePolicy(v.Initial.Policy),
+		}}, nil
+	case *pb.AccountCredential_Normal:
+		arData := make(map[ArIdentity]ChainArData, len(v.Normal.ArData))
+		for i, d := range v.Normal.ArData {
+			arData[ArIdentity{Value: i}] = ChainArData{EncIdCredPubShare: d.EncIdCredPubShare}
+		}
+
+		return AccountCredential{CredentialValues: NormalCredentialValues{
+			Keys:        parseCredentialPublicKeys(v.Normal.Keys),
+			CredId:      CredentialRegistrationId{Value: v.Normal.CredId.GetValue()},
+			IpId:        IdentityProviderIdentity{Value: v.Normal.IpId.GetValue()},
+			Policy:      parsePolicy(v.Normal.Policy),
+			ArThreshold: ArThreshold{Value: uint8(v.Normal.ArThreshold.GetValue())},
+			ArData:      arData,
+			Commitments: parseCredentialCommitments(v.Normal.Commitments),
+		}}, nil
+	}
+
+	return AccountCredential{}, errors.New("unknown account credential")
+}
+
+// AccountVerifyKey a public key used to verify transaction signatures from an account.
+// Currently only ed25519 keys are supported.
+type AccountVerifyKey struct {
+	Ed25519Key []byte
+}
+
+// CredentialPublicKeys public keys of a single credential.
+type CredentialPublicKeys struct {
+	Keys      map[KeyIndex]AccountVerifyKey
+	Threshold SignatureThreshold
+}
+
+// Parses *pb.CredentialPublicKeys to CredentialPublicKeys.
+func parseCredentialPublicKeys(c *pb.CredentialPublicKeys) CredentialPublicKeys {
+	keys := make(map[KeyIndex]AccountVerifyKey, len(c.GetKeys()))
+	for i, k := range c.GetKeys() {
+		keys[KeyIndex(i)] = AccountVerifyKey{Ed25519Key: k.GetEd25519Key()}
+	}
+
+	return CredentialPublicKeys{
+		Keys:      keys,
+		Threshold: SignatureThreshold{Value: uint8(c.GetThreshold().GetValue())},
+	}
+}
+
+// IdentityProviderIdentity a unique identifier of an identity provider.
+type IdentityProviderIdentity struct {
+	Value uint32
+}
+
+// ArIdentity a unique identifier of an anonymity revoker.
+type ArIdentity struct {
+	Value uint32
+}
+
+// ArThreshold the number of anonymity revokers that must work together to revoke the anonymity of a credential holder.
+type ArThreshold struct {
+	Value uint8
+}
+
+// ChainArData data relating to a single anonymity revoker sent by the account holder to the chain.
+type ChainArData struct {
+	// Share of the encryption of IdCredPub.
+	EncIdCredPubShare []byte
+}
+
+// AttributeTag a tag identifying an identity attribute.
+type AttributeTag uint8
+
+// YearMonth representation of the pair of a year and month.
+type YearMonth struct {
+	Year  uint32
+	Month uint32
+}
+
+// Policy on a credential.
+type Policy struct {
+	// The year and month when the identity object from which the credential is derived was created.
+	CreatedAt YearMonth
+	// The last year and month when the credential is still valid.
+	ValidTo YearMonth
+	// Mapping from attribute tags to attribute values.
+	Attributes map[AttributeTag][]byte
+}
+
+// Parses *pb.Policy to Policy.
+func parsePolicy(p *pb.Policy) Policy {
+	attributes := make(map[AttributeTag][]byte, len(p.GetAttributes()))
+	for t, a := range p.GetAttributes() {
+		attributes[AttributeTag(t)] = a
+	}
+
+	return Policy{
+		CreatedAt:  YearMonth{Year: p.GetCreatedAt().GetYear(), Month: p.GetCreatedAt().GetMonth()},
+		ValidTo:    YearMonth{Year: p.GetValidTo().GetYear(), Month: p.GetValidTo().GetMonth()},
+		Attributes: attributes,
+	}
+}
+
+// Commitment a Pedersen commitment.
+type Commitment struct {
+	Value []byte
+}
+
+// CredentialCommitments the commitments sent by the account holder to the chain in order to deploy credentials.
+type CredentialCommitments struct {
+	// Commitment to the PRF key.
+	Prf Commitment
+	// Commitment to the counter used to generate the credential registration id.
+	CredCounter Commitment
+	// Commitment to the `max_accounts` value, which determines the maximum number of credentials
+	// that may be created from the identity object.
+	MaxAccounts Commitment
+	// Commitments to the attributes which have not been revealed in the policy.
+	Attributes map[AttributeTag]Commitment
+	// List of commitments to the coefficients of the sharing polynomial.
+	IdCredSecSharingCoeff []Commitment
+}
+
+// Parses *pb.CredentialCommitments to CredentialCommitments.
+func parseCredentialCommitments(c *pb.CredentialCommitments) CredentialCommitments {
+	attributes := make(map[AttributeTag]Commitment, len(c.GetAttributes()))
+	for t, a := range c.GetAttributes() {
+		attributes[AttributeTag(t)] = Commitment{Value: a.Value}
+	}
+
+	coefficients := make([]Commitment, 0, len(c.GetIdCredSecSharingCoeff()))
+	for _, a := range c.GetIdCredSecSharingCoeff() {
+		coefficients = append(coefficients, Commitment{Value: a.Value})
+	}
+
+	return CredentialCommitments{
+		Prf:                   Commitment{Value: c.GetPrf().GetValue()},
+		CredCounter:           Commitment{Value: c.GetCredCounter().GetValue()},
+		MaxAccounts:           Commitment{Value: c.GetMaxAccounts().GetValue()},
+		Attributes:            attributes,
+		IdCredSecSharingCoeff: coefficients,
+	}
+}
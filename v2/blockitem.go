@@ -0,0 +1,600 @@
+package v2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// BlockItemVersion is the version of the binary serialization of block items produced by MarshalBinary.
+const BlockItemVersion = 0
+
+// Tags of the kinds of block items in the binary serialization.
+const (
+	blockItemTagAccountTransaction   byte = 0
+	blockItemTagCredentialDeployment byte = 1
+	blockItemTagUpdateInstruction    byte = 2
+)
+
+// MarshalBinary serializes the AccountTransaction as a versioned block item, in the format defined by the protocol.
+// The payload size in the header is computed from the payload.
+func (accountTransaction *AccountTransaction) MarshalBinary() ([]byte, error) {
+	return marshalVersionedBlockItem(accountTransaction)
+}
+
+// UnmarshalBinary parses a versioned block item serialized by MarshalBinary. The payload is kept as a RawPayload,
+// which can be decoded with RawPayload.Decode.
+func (accountTransaction *AccountTransaction) UnmarshalBinary(data []byte) error {
+	item, err := unmarshalVersionedBlockItem(data)
+	if err != nil {
+		return err
+	}
+	tx, ok := item.(*AccountTransaction)
+	if !ok {
+		return errors.New("block item is not an account transaction")
+	}
+	*accountTransaction = *tx
+	return nil
+}
+
+// Hash computes the transaction hash of the AccountTransaction, which is the hash SendBlockItem returns for it.
+func (accountTransaction *AccountTransaction) Hash() (*TransactionHash, error) {
+	return blockItemHash(accountTransaction)
+}
+
+// MarshalBinary serializes the CredentialDeployment as a versioned block item, in the format defined by the protocol.
+func (credentialDeployment *CredentialDeployment) MarshalBinary() ([]byte, error) {
+	return marshalVersionedBlockItem(credentialDeployment)
+}
+
+// UnmarshalBinary parses a versioned block item serialized by MarshalBinary.
+func (credentialDeployment *CredentialDeployment) UnmarshalBinary(data []byte) error {
+	item, err := unmarshalVersionedBlockItem(data)
+	if err != nil {
+		return err
+	}
+	deployment, ok := item.(*CredentialDeployment)
+	if !ok {
+		return errors.New("block item is not a credential deployment")
+	}
+	*credentialDeployment = *deployment
+	return nil
+}
+
+// Hash computes the transaction hash of the CredentialDeployment, which is the hash SendBlockItem returns for it.
+func (credentialDeployment *CredentialDeployment) Hash() (*TransactionHash, error) {
+	return blockItemHash(credentialDeployment)
+}
+
+// MarshalBinary serializes the UpdateInstruction as a versioned block item, in the format defined by the protocol.
+func (updateInstruction *UpdateInstruction) MarshalBinary() ([]byte, error) {
+	return marshalVersionedBlockItem(updateInstruction)
+}
+
+// UnmarshalBinary parses a versioned block item serialized by MarshalBinary.
+func (updateInstruction *UpdateInstruction) UnmarshalBinary(data []byte) error {
+	item, err := unmarshalVersionedBlockItem(data)
+	if err != nil {
+		return err
+	}
+	instruction, ok := item.(*UpdateInstruction)
+	if !ok {
+		return errors.New("block item is not an update instruction")
+	}
+	*updateInstruction = *instruction
+	return nil
+}
+
+// Hash computes the transaction hash of the UpdateInstruction, which is the hash SendBlockItem returns for it.
+func (updateInstruction *UpdateInstruction) Hash() (*TransactionHash, error) {
+	return blockItemHash(updateInstruction)
+}
+
+// MarshalBinary serializes the block item as a versioned block item, in the format defined by the protocol.
+func (blockItem *BlockItem) MarshalBinary() ([]byte, error) {
+	return marshalVersionedBlockItem(blockItem.BlockItem)
+}
+
+// UnmarshalBinary parses a versioned block item of any kind and computes its hash.
+func (blockItem *BlockItem) UnmarshalBinary(data []byte) error {
+	item, err := unmarshalVersionedBlockItem(data)
+	if err != nil {
+		return err
+	}
+	hash, err := blockItemHash(item)
+	if err != nil {
+		return err
+	}
+	blockItem.Hash = hash
+	blockItem.BlockItem = item
+	return nil
+}
+
+// ComputeHash computes the transaction hash of the block item locally. It does not use the Hash field, which is
+// set by the node for block items returned by queries.
+func (blockItem *BlockItem) ComputeHash() (*TransactionHash, error) {
+	return blockItemHash(blockItem.BlockItem)
+}
+
+// SendBlockItemRequest converts the block item to a request for SendBlockItem.
+func (blockItem *BlockItem) SendBlockItemRequest() (*pb.SendBlockItemRequest, error) {
+	switch item := blockItem.BlockItem.(type) {
+	case *AccountTransaction:
+		return item.sendBlockItemRequest()
+	case *CredentialDeployment:
+		return item.sendBlockItemRequest()
+	case *UpdateInstruction:
+		return item.sendBlockItemRequest()
+	}
+	return nil, fmt.Errorf("unsupported block item %T", blockItem.BlockItem)
+}
+
+// SendBlockItemBinary sends a versioned block item serialized by MarshalBinary, e.g. a transaction that was
+// signed elsewhere. The node API has no request for raw block items, so the block item is parsed and sent as
+// the matching kind of block item.
+func (c *Client) SendBlockItemBinary(ctx context.Context, data []byte) (_ *TransactionHash, err error) {
+	var blockItem BlockItem
+	if err = blockItem.UnmarshalBinary(data); err != nil {
+		return &TransactionHash{}, err
+	}
+	req, err := blockItem.SendBlockItemRequest()
+	if err != nil {
+		return &TransactionHash{}, err
+	}
+	return c.SendBlockItem(ctx, req)
+}
+
+// ComputeBlockItemHash computes the hash of the block item in a SendBlockItemRequest, which is the hash
+// SendBlockItem returns. Account transactions must have a raw payload, such as those sent by
+// AccountTransaction.Send.
+func ComputeBlockItemHash(req *pb.SendBlockItemRequest) (*TransactionHash, error) {
+	var item isBlockItem
+	switch k := req.GetBlockItem().(type) {
+	case *pb.SendBlockItemRequest_AccountTransaction:
+		tx := k.AccountTransaction
+		payload, ok := tx.GetPayload().GetPayload().(*pb.AccountTransactionPayload_RawPayload)
+		if !ok {
+			return &TransactionHash{}, errors.New("hash can only be computed for account transactions with a raw payload")
+		}
+		sender, err := AccountAddressFromBytes(tx.GetHeader().GetSender().GetValue())
+		if err != nil {
+			return &TransactionHash{}, errors.New("invalid sender address")
+		}
+		signatures := make(map[uint8]*AccountSignatureMap, len(tx.GetSignature().GetSignatures()))
+		for credential, signatureMap := range tx.GetSignature().GetSignatures() {
+			keys := make(map[uint8]*Signature, len(signatureMap.GetSignatures()))
+			for key, signature := range signatureMap.GetSignatures() {
+				keys[uint8(key)] = &Signature{Value: signature.GetValue()}
+			}
+			signatures[uint8(credential)] = &AccountSignatureMap{Signatures: keys}
+		}
+		item = &AccountTransaction{
+			Signature: &AccountTransactionSignature{Signatures: signatures},
+			Header: &AccountTransactionHeader{
+				Sender:         &sender,
+				SequenceNumber: &SequenceNumber{Value: tx.GetHeader().GetSequenceNumber().GetValue()},
+				EnergyAmount:   &Energy{Value: tx.GetHeader().GetEnergyAmount().GetValue()},
+				Expiry:         &TransactionTime{Value: tx.GetHeader().GetExpiry().GetValue()},
+			},
+			Payload: &AccountTransactionPayload{Payload: &RawPayload{Value: payload.RawPayload}},
+		}
+	case *pb.SendBlockItemRequest_CredentialDeployment:
+		item = &CredentialDeployment{
+			MessageExpiry: &TransactionTime{Value: k.CredentialDeployment.GetMessageExpiry().GetValue()},
+			Payload:       &RawPayload{Value: k.CredentialDeployment.GetRawPayload()},
+		}
+	case *pb.SendBlockItemRequest_UpdateInstruction:
+		instruction := k.UpdateInstruction
+		signatures := make(map[uint32]*Signature, len(instruction.GetSignatures().GetSignatures()))
+		for key, signature := range instruction.GetSignatures().GetSignatures() {
+			signatures[key] = &Signature{Value: signature.GetValue()}
+		}
+		item = &UpdateInstruction{
+			Signatures: &SignatureMap{Signatures: signatures},
+			Header: &UpdateInstructionHeader{
+				SequenceNumber: &UpdateSequenceNumber{Value: instruction.GetHeader().GetSequenceNumber().GetValue()},
+				EffectiveTime:  &TransactionTime{Value: instruction.GetHeader().GetEffectiveTime().GetValue()},
+				Timeout:        &TransactionTime{Value: instruction.GetHeader().GetTimeout().GetValue()},
+			},
+			Payload: &UpdateInstructionPayload{Payload: &RawPayload{Value: instruction.GetPayload().GetRawPayload()}},
+		}
+	default:
+		return &TransactionHash{}, errors.New("missing block item")
+	}
+	return blockItemHash(item)
+}
+
+// sendBlockItemRequest converts the AccountTransaction to a request for SendBlockItem.
+func (accountTransaction *AccountTransaction) sendBlockItemRequest() (*pb.SendBlockItemRequest, error) {
+	if accountTransaction.Signature == nil || accountTransaction.Header == nil || accountTransaction.Payload == nil ||
+		accountTransaction.Payload.Payload == nil {
+		return nil, errors.New("account transaction is missing its signature, header or payload")
+	}
+	header := accountTransaction.Header
+	if header.Sender == nil || header.SequenceNumber == nil || header.EnergyAmount == nil || header.Expiry == nil {
+		return nil, errors.New("account transaction header is incomplete")
+	}
+	signaturesMap := make(map[uint32]*pb.AccountSignatureMap, len(accountTransaction.Signature.Signatures))
+	for extKey, signatureMap := range accountTransaction.Signature.Signatures {
+		signatures := make(map[uint32]*pb.Signature, len(signatureMap.Signatures))
+		for innKey, signature := range signatureMap.Signatures {
+			signatures[uint32(innKey)] = &pb.Signature{Value: signature.Value}
+		}
+		signaturesMap[uint32(extKey)] = &pb.AccountSignatureMap{Signatures: signatures}
+	}
+
+	return &pb.SendBlockItemRequest{
+		BlockItem: &pb.SendBlockItemRequest_AccountTransaction{AccountTransaction: &pb.AccountTransaction{
+			Signature: &pb.AccountTransactionSignature{Signatures: signaturesMap},
+			Header: &pb.AccountTransactionHeader{
+				Sender:         &pb.AccountAddress{Value: header.Sender.Value[:]},
+				SequenceNumber: &pb.SequenceNumber{Value: header.SequenceNumber.Value},
+				EnergyAmount:   &pb.Energy{Value: header.EnergyAmount.Value},
+				Expiry:         &pb.TransactionTime{Value: header.Expiry.Value},
+			},
+			Payload: &pb.AccountTransactionPayload{Payload: &pb.AccountTransactionPayload_RawPayload{
+				RawPayload: accountTransaction.Payload.Payload.Encode().Value,
+			}},
+		}},
+	}, nil
+}
+
+// sendBlockItemRequest converts the CredentialDeployment to a request for SendBlockItem.
+func (credentialDeployment *CredentialDeployment) sendBlockItemRequest() (*pb.SendBlockItemRequest, error) {
+	payload, err := credentialDeploymentPayload(credentialDeployment)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SendBlockItemRequest{
+		BlockItem: &pb.SendBlockItemRequest_CredentialDeployment{CredentialDeployment: &pb.CredentialDeployment{
+			MessageExpiry: &pb.TransactionTime{Value: credentialDeployment.MessageExpiry.Value},
+			Payload:       &pb.CredentialDeployment_RawPayload{RawPayload: payload},
+		}},
+	}, nil
+}
+
+// sendBlockItemRequest converts the UpdateInstruction to a request for SendBlockItem.
+func (updateInstruction *UpdateInstruction) sendBlockItemRequest() (*pb.SendBlockItemRequest, error) {
+	header := updateInstruction.Header
+	if header == nil || header.SequenceNumber == nil || header.EffectiveTime == nil || header.Timeout == nil {
+		return nil, errors.New("update instruction header is incomplete")
+	}
+	payload, err := updateInstructionPayload(updateInstruction)
+	if err != nil {
+		return nil, err
+	}
+	signatures := make(map[uint32]*pb.Signature)
+	if updateInstruction.Signatures != nil {
+		for index, signature := range updateInstruction.Signatures.Signatures {
+			signatures[index] = &pb.Signature{Value: signature.Value}
+		}
+	}
+	return &pb.SendBlockItemRequest{
+		BlockItem: &pb.SendBlockItemRequest_UpdateInstruction{UpdateInstruction: &pb.UpdateInstruction{
+			Signatures: &pb.SignatureMap{Signatures: signatures},
+			Header: &pb.UpdateInstructionHeader{
+				SequenceNumber: &pb.UpdateSequenceNumber{Value: header.SequenceNumber.Value},
+				EffectiveTime:  &pb.TransactionTime{Value: header.EffectiveTime.Value},
+				Timeout:        &pb.TransactionTime{Value: header.Timeout.Value},
+			},
+			Payload: &pb.UpdateInstructionPayload{Payload: &pb.UpdateInstructionPayload_RawPayload{RawPayload: payload}},
+		}},
+	}, nil
+}
+
+// blockItemHash computes the hash of a block item, which is the SHA256 hash of its unversioned serialization.
+func blockItemHash(item isBlockItem) (*TransactionHash, error) {
+	buf, err := serializeBlockItem(item)
+	if err != nil {
+		return &TransactionHash{}, err
+	}
+	return &TransactionHash{Value: sha256.Sum256(buf)}, nil
+}
+
+// marshalVersionedBlockItem serializes a block item prefixed with BlockItemVersion.
+func marshalVersionedBlockItem(item isBlockItem) ([]byte, error) {
+	buf, err := serializeBlockItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{BlockItemVersion}, buf...), nil
+}
+
+// unmarshalVersionedBlockItem parses a block item prefixed with BlockItemVersion.
+func unmarshalVersionedBlockItem(data []byte) (isBlockItem, error) {
+	if len(data) == 0 {
+		return nil, errors.New("could not deserialize block item: invalid length")
+	}
+	if data[0] != BlockItemVersion {
+		return nil, fmt.Errorf("could not deserialize block item: unsupported version %d", data[0])
+	}
+	return deserializeBlockItem(data[1:])
+}
+
+// serializeBlockItem serializes a block item without a version.
+func serializeBlockItem(item isBlockItem) ([]byte, error) {
+	switch item := item.(type) {
+	case *AccountTransaction:
+		return serializeAccountTransaction(item)
+	case *CredentialDeployment:
+		payload, err := credentialDeploymentPayload(item)
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, 0, 9+len(payload))
+		buf = append(buf, blockItemTagCredentialDeployment)
+		buf = binary.BigEndian.AppendUint64(buf, item.MessageExpiry.Value)
+		return append(buf, payload...), nil
+	case *UpdateInstruction:
+		return serializeUpdateInstruction(item)
+	}
+	return nil, fmt.Errorf("unsupported block item %T", item)
+}
+
+func serializeAccountTransaction(tx *AccountTransaction) ([]byte, error) {
+	if tx.Signature == nil || tx.Header == nil || tx.Payload == nil || tx.Payload.Payload == nil {
+		return nil, errors.New("account transaction is missing its signature, header or payload")
+	}
+	header := tx.Header
+	if header.Sender == nil || header.SequenceNumber == nil || header.EnergyAmount == nil || header.Expiry == nil {
+		return nil, errors.New("account transaction header is incomplete")
+	}
+	payload := tx.Payload.Payload.Encode()
+
+	buf := []byte{blockItemTagAccountTransaction}
+	if len(tx.Signature.Signatures) > 255 {
+		return nil, errors.New("too many credential signatures")
+	}
+	credentials := make([]int, 0, len(tx.Signature.Signatures))
+	for index := range tx.Signature.Signatures {
+		credentials = append(credentials, int(index))
+	}
+	sort.Ints(credentials)
+	buf = append(buf, byte(len(credentials)))
+	for _, credential := range credentials {
+		var signatures map[uint8]*Signature
+		if signatureMap := tx.Signature.Signatures[uint8(credential)]; signatureMap != nil {
+			signatures = signatureMap.Signatures
+		}
+		if len(signatures) > 255 {
+			return nil, errors.New("too many key signatures")
+		}
+		keys := make([]int, 0, len(signatures))
+		for index := range signatures {
+			keys = append(keys, int(index))
+		}
+		sort.Ints(keys)
+		buf = append(buf, byte(credential), byte(len(keys)))
+		for _, key := range keys {
+			var err error
+			buf = append(buf, byte(key))
+			if buf, err = appendSignature(buf, signatures[uint8(key)]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	buf = append(buf, (&AccountTransactionHeader{
+		Sender:         header.Sender,
+		SequenceNumber: header.SequenceNumber,
+		EnergyAmount:   header.EnergyAmount,
+		PayloadSize:    &PayloadSize{Value: uint32(len(payload.Value))},
+		Expiry:         header.Expiry,
+	}).Serialize()...)
+	return append(buf, payload.Value...), nil
+}
+
+func serializeUpdateInstruction(instruction *UpdateInstruction) ([]byte, error) {
+	header := instruction.Header
+	if header == nil || header.SequenceNumber == nil || header.EffectiveTime == nil || header.Timeout == nil {
+		return nil, errors.New("update instruction header is incomplete")
+	}
+	payload, err := updateInstructionPayload(instruction)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := []byte{blockItemTagUpdateInstruction}
+	buf = binary.BigEndian.AppendUint64(buf, header.SequenceNumber.Value)
+	buf = binary.BigEndian.AppendUint64(buf, header.EffectiveTime.Value)
+	buf = binary.BigEndian.AppendUint64(buf, header.Timeout.Value)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(payload)))
+	buf = append(buf, payload...)
+
+	var signatures map[uint32]*Signature
+	if instruction.Signatures != nil {
+		signatures = instruction.Signatures.Signatures
+	}
+	if len(signatures) > 65535 {
+		return nil, errors.New("too many update signatures")
+	}
+	keys := make([]int, 0, len(signatures))
+	for index := range signatures {
+		if index > 65535 {
+			return nil, fmt.Errorf("invalid update key index %d", index)
+		}
+		keys = append(keys, int(index))
+	}
+	sort.Ints(keys)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(keys)))
+	for _, key := range keys {
+		buf = binary.BigEndian.AppendUint16(buf, uint16(key))
+		if buf, err = appendSignature(buf, signatures[uint32(key)]); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// appendSignature appends a signature prefixed with its length.
+func appendSignature(buf []byte, signature *Signature) ([]byte, error) {
+	if signature == nil {
+		return nil, errors.New("missing signature")
+	}
+	if len(signature.Value) > 65535 {
+		return nil, errors.New("signature is too long")
+	}
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(signature.Value)))
+	return append(buf, signature.Value...), nil
+}
+
+// credentialDeploymentPayload returns the serialized credential of a CredentialDeployment.
+func credentialDeploymentPayload(deployment *CredentialDeployment) ([]byte, error) {
+	if deployment.MessageExpiry == nil {
+		return nil, errors.New("credential deployment is missing its expiry")
+	}
+	switch payload := deployment.Payload.(type) {
+	case *RawPayload:
+		return payload.Value, nil
+	case RawPayload:
+		return payload.Value, nil
+	}
+	return nil, errors.New("credential deployment must have a raw payload")
+}
+
+// updateInstructionPayload returns the serialized payload of an UpdateInstruction.
+func updateInstructionPayload(instruction *UpdateInstruction) ([]byte, error) {
+	if instruction.Payload != nil {
+		switch payload := instruction.Payload.Payload.(type) {
+		case *RawPayload:
+			return payload.Value, nil
+		case RawPayload:
+			return payload.Value, nil
+		}
+	}
+	return nil, errors.New("update instruction must have a raw payload")
+}
+
+// blockItemReader reads the fields of a serialized block item.
+type blockItemReader struct {
+	data []byte
+	err  error
+}
+
+func (r *blockItemReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.data) < n {
+		r.err = errors.New("could not deserialize block item: invalid length")
+		return nil
+	}
+	res := r.data[:n]
+	r.data = r.data[n:]
+	return res
+}
+
+func (r *blockItemReader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *blockItemReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *blockItemReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *blockItemReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// signature reads a signature prefixed with its length.
+func (r *blockItemReader) signature() *Signature {
+	value := r.bytes(int(r.uint16()))
+	return &Signature{Value: append([]byte(nil), value...)}
+}
+
+// deserializeBlockItem parses a block item without a version. All data must be used.
+func deserializeBlockItem(data []byte) (isBlockItem, error) {
+	r := &blockItemReader{data: data}
+	var item isBlockItem
+	switch tag := r.uint8(); tag {
+	case blockItemTagAccountTransaction:
+		signatures := make(map[uint8]*AccountSignatureMap)
+		for i, n := 0, int(r.uint8()); i < n && r.err == nil; i++ {
+			credential := r.uint8()
+			signatureMap := &AccountSignatureMap{Signatures: make(map[uint8]*Signature)}
+			for j, m := 0, int(r.uint8()); j < m && r.err == nil; j++ {
+				key := r.uint8()
+				signatureMap.Signatures[key] = r.signature()
+			}
+			signatures[credential] = signatureMap
+		}
+		headerBytes := r.bytes(int(TransactionHeaderSize))
+		if r.err != nil {
+			return nil, r.err
+		}
+		sender, err := AccountAddressFromBytes(headerBytes[:32])
+		if err != nil {
+			return nil, err
+		}
+		header := &AccountTransactionHeader{
+			Sender:         &sender,
+			SequenceNumber: &SequenceNumber{Value: binary.BigEndian.Uint64(headerBytes[32:40])},
+			EnergyAmount:   &Energy{Value: binary.BigEndian.Uint64(headerBytes[40:48])},
+			PayloadSize:    &PayloadSize{Value: binary.BigEndian.Uint32(headerBytes[48:52])},
+			Expiry:         &TransactionTime{Value: binary.BigEndian.Uint64(headerBytes[52:TransactionHeaderSize])},
+		}
+		payload := r.bytes(int(header.PayloadSize.Value))
+		item = &AccountTransaction{
+			Signature: &AccountTransactionSignature{Signatures: signatures},
+			Header:    header,
+			Payload:   &AccountTransactionPayload{Payload: &RawPayload{Value: append([]byte(nil), payload...)}},
+		}
+	case blockItemTagCredentialDeployment:
+		expiry := r.uint64()
+		// the credential is the remainder of the block item.
+		payload := r.bytes(len(r.data))
+		item = &CredentialDeployment{
+			MessageExpiry: &TransactionTime{Value: expiry},
+			Payload:       &RawPayload{Value: append([]byte(nil), payload...)},
+		}
+	case blockItemTagUpdateInstruction:
+		header := &UpdateInstructionHeader{
+			SequenceNumber: &UpdateSequenceNumber{Value: r.uint64()},
+			EffectiveTime:  &TransactionTime{Value: r.uint64()},
+			Timeout:        &TransactionTime{Value: r.uint64()},
+		}
+		payload := r.bytes(int(r.uint32()))
+		signatures := make(map[uint32]*Signature)
+		for i, n := 0, int(r.uint16()); i < n && r.err == nil; i++ {
+			key := r.uint16()
+			signatures[uint32(key)] = r.signature()
+		}
+		item = &UpdateInstruction{
+			Signatures: &SignatureMap{Signatures: signatures},
+			Header:     header,
+			Payload:    &UpdateInstructionPayload{Payload: &RawPayload{Value: append([]byte(nil), payload...)}},
+		}
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("could not deserialize block item: unknown tag %d", tag)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.data) > 0 {
+		return nil, errors.New("could not deserialize block item: trailing bytes")
+	}
+	return item, nil
+}
@@ -0,0 +1,1166 @@
+package v2
+
+import (
+	"errors"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// ErrUnknownVariant indicates that the node returned a variant of a type that is not known to the SDK.
+// This can happen when the node is newer than the SDK.
+var ErrUnknownVariant = errors.New("unknown variant")
+
+// BlockItemStatus the status of a block item that is known to the node.
+// Status is either BlockItemStatusReceived, BlockItemStatusCommitted or BlockItemStatusFinalized.
+type BlockItemStatus struct {
+	Status isBlockItemStatus
+}
+
+type isBlockItemStatus interface {
+	isBlockItemStatus()
+}
+
+// BlockItemStatusReceived the block item is received, but not yet in any blocks.
+type BlockItemStatusReceived struct{}
+
+// BlockItemStatusCommitted the block item is committed to one or more blocks. The outcomes are listed
+// for each block. Note that in the vast majority of cases the outcome of a transaction should not be
+// dependent on the block it is in, but this can in principle happen.
+type BlockItemStatusCommitted struct {
+	Outcomes []BlockItemSummaryInBlock
+}
+
+// BlockItemStatusFinalized the block item is finalized in the given block, with the given summary.
+type BlockItemStatusFinalized struct {
+	Outcome BlockItemSummaryInBlock
+}
+
+func (BlockItemStatusReceived) isBlockItemStatus()  {}
+func (BlockItemStatusCommitted) isBlockItemStatus() {}
+func (BlockItemStatusFinalized) isBlockItemStatus() {}
+
+// Parses *pb.BlockItemStatus to BlockItemStatus.
+func parseBlockItemStatus(s *pb.BlockItemStatus) (BlockItemStatus, error) {
+	switch v := s.GetStatus().(type) {
+	case *pb.BlockItemStatus_Received:
+		return BlockItemStatus{Status: BlockItemStatusReceived{}}, nil
+	case *pb.BlockItemStatus_Committed_:
+		outcomes := make([]BlockItemSummaryInBlock, 0, len(v.Committed.GetOutcomes()))
+		for _, o := range v.Committed.GetOutcomes() {
+			outcome, err := parseBlockItemSummaryInBlock(o)
+			if err != nil {
+				return BlockItemStatus{}, errors.New("Error parsing BlockItemStatus: " + err.Error())
+			}
+			outcomes = append(outcomes, outcome)
+		}
+		return BlockItemStatus{Status: BlockItemStatusCommitted{Outcomes: outcomes}}, nil
+	case *pb.BlockItemStatus_Finalized_:
+		outcome, err := parseBlockItemSummaryInBlock(v.Finalized.GetOutcome())
+		if err != nil {
+			return BlockItemStatus{}, errors.New("Error parsing BlockItemStatus: " + err.Error())
+		}
+		return BlockItemStatus{Status: BlockItemStatusFinalized{Outcome: outcome}}, nil
+	}
+
+	return BlockItemStatus{}, errors.New("Error parsing BlockItemStatus: " + ErrUnknownVariant.Error())
+}
+
+// BlockItemSummaryInBlock a block item summary together with the hash of the block it is in.
+type BlockItemSummaryInBlock struct {
+	// The block hash.
+	BlockHash BlockHash
+	// The outcome of the block item.
+	Outcome BlockItemSummary
+}
+
+// Parses *pb.BlockItemSummaryInBlock to BlockItemSummaryInBlock.
+func parseBlockItemSummaryInBlock(b *pb.BlockItemSummaryInBlock) (BlockItemSummaryInBlock, error) {
+	outcome, err := parseBlockItemSummary(b.GetOutcome())
+	if err != nil {
+		return BlockItemSummaryInBlock{}, err
+	}
+
+	var blockHash BlockHash
+	copy(blockHash.Value[:], b.GetBlockHash().GetValue())
+
+	return BlockItemSummaryInBlock{BlockHash: blockHash, Outcome: outcome}, nil
+}
+
+// Return type of GetBlockTransactionEvents. Parses the returned *pb.BlockItemSummary to BlockItemSummary when Recv() is called.
+type BlockItemSummaryStream struct {
+	stream pb.Queries_GetBlockTransactionEventsClient
+}
+
+// Recv retrieves the next BlockItemSummary.
+func (s *BlockItemSummaryStream) Recv() (BlockItemSummary, error) {
+	summary, err := s.stream.Recv()
+	if err != nil {
+		return BlockItemSummary{}, err
+	}
+	return parseBlockItemSummary(summary)
+}
+
+// TransactionIndex index of the transaction in a block.
+type TransactionIndex struct {
+	Value uint64
+}
+
+// BlockItemSummary summary of the outcome of a block item in structured form. The summary determines
+// which transaction type it was. Details is either AccountTransactionDetails, AccountCreationDetails or UpdateDetails.
+type BlockItemSummary struct {
+	// Index of the transaction in the block where it is included.
+	Index TransactionIndex
+	// The amount of NRG the transaction cost.
+	EnergyCost Energy
+	// Hash of the transaction.
+	Hash TransactionHash
+	// Details that are specific to different transaction types.
+	Details isBlockItemSummaryDetails
+}
+
+type isBlockItemSummaryDetails interface {
+	isBlockItemSummaryDetails()
+}
+
+// AccountTransactionDetails details about an account transaction.
+type AccountTransactionDetails struct {
+	// The cost of the transaction. Paid by the sender.
+	Cost Amount
+	// The sender of the transaction.
+	Sender AccountAddress
+	// The effects of the transaction.
+	Effects AccountTransactionEffects
+}
+
+// AccountCreationDetails details of an account creation. These transactions are free, and we only
+// ever get a response for them if the account is created, hence no failure cases.
+type AccountCreationDetails struct {
+	// Whether this is an initial or normal account.
+	CredentialType CredentialType
+	// Address of the newly created account.
+	Address AccountAddress
+	// Credential registration ID of the first credential.
+	RegId CredentialRegistrationId
+}
+
+// UpdateDetails details of an update instruction. These are free, and we only ever get a response
+// for them if the update is successfully enqueued, hence no failure cases.
+type UpdateDetails struct {
+	// The time at which the update will be effective.
+	EffectiveTime TransactionTime
+	// The payload for the update.
+	Payload *pb.UpdatePayload
+}
+
+func (AccountTransactionDetails) isBlockItemSummaryDetails() {}
+func (AccountCreationDetails) isBlockItemSummaryDetails()    {}
+func (UpdateDetails) isBlockItemSummaryDetails()             {}
+
+// IsSuccess returns whether the block item was successful. Only account transactions can be rejected,
+// account creations and update instructions are only included in blocks if they succeed.
+func (b *BlockItemSummary) IsSuccess() bool {
+	return b.RejectReason() == nil
+}
+
+// RejectReason returns the reason the transaction was rejected, or nil if it was not rejected.
+func (b *BlockItemSummary) RejectReason() RejectReason {
+	if d, ok := b.Details.(AccountTransactionDetails); ok {
+		if r, ok := d.Effects.(TransactionRejected); ok {
+			return r.Reason
+		}
+	}
+	return nil
+}
+
+// SenderAccount returns the sender of the block item if it is an account transaction, or nil otherwise.
+func (b *BlockItemSummary) SenderAccount() *AccountAddress {
+	if d, ok := b.Details.(AccountTransactionDetails); ok {
+		return &d.Sender
+	}
+	return nil
+}
+
+// AffectedAccounts returns the list of accounts affected by the block item, without duplicates.
+// For account transactions this is the sender followed by any accounts that received CCD.
+// For account creations this is the created account. Update instructions affect no accounts.
+func (b *BlockItemSummary) AffectedAccounts() []AccountAddress {
+	var res []AccountAddress
+	seen := make(map[AccountAddress]bool)
+	add := func(a AccountAddress) {
+		if !seen[a] {
+			seen[a] = true
+			res = append(res, a)
+		}
+	}
+
+	switch d := b.Details.(type) {
+	case AccountTransactionDetails:
+		add(d.Sender)
+		switch e := d.Effects.(type) {
+		case AccountTransfer:
+			add(e.Receiver)
+		case TransferredWithSchedule:
+			add(e.Receiver)
+		case EncryptedAmountTransferred:
+			add(e.Added.Receiver)
+		case ContractUpdateIssued:
+			for _, element := range e.Effects {
+				if t, ok := element.Element.(ContractTraceElementTransferred); ok {
+					add(t.Receiver)
+				}
+			}
+		}
+	case AccountCreationDetails:
+		add(d.Address)
+	}
+
+	return res
+}
+
+// AffectedContracts returns the list of contract instances affected by the block item, without duplicates,
+// in the order they were first affected. Only account transactions initializing or updating contracts affect contracts.
+func (b *BlockItemSummary) AffectedContracts() []ContractAddress {
+	var res []ContractAddress
+	seen := make(map[ContractAddress]bool)
+	add := func(c ContractAddress) {
+		if !seen[c] {
+			seen[c] = true
+			res = append(res, c)
+		}
+	}
+
+	d, ok := b.Details.(AccountTransactionDetails)
+	if !ok {
+		return nil
+	}
+
+	switch e := d.Effects.(type) {
+	case ContractInitialized:
+		add(e.Address)
+	case ContractUpdateIssued:
+		for _, element := range e.Effects {
+			switch t := element.Element.(type) {
+			case ContractTraceElementUpdated:
+				add(t.Address)
+			case ContractTraceElementTransferred:
+				add(t.Sender)
+			case ContractTraceElementInterrupted:
+				add(t.Address)
+			case ContractTraceElementResumed:
+				add(t.Address)
+			case ContractTraceElementUpgraded:
+				add(t.Address)
+			}
+		}
+	}
+
+	return res
+}
+
+// Parses *pb.BlockItemSummary to BlockItemSummary.
+func parseBlockItemSummary(b *pb.BlockItemSummary) (BlockItemSummary, error) {
+	var details isBlockItemSummaryDetails
+	switch v := b.GetDetails().(type) {
+	case *pb.BlockItemSummary_AccountTransaction:
+		d, err := parseAccountTransactionDetails(v.AccountTransaction)
+		if err != nil {
+			return BlockItemSummary{}, errors.New("Error parsing BlockItemSummary: " + err.Error())
+		}
+		details = d
+	case *pb.BlockItemSummary_AccountCreation:
+		credentialType := CredentialType{Type: CredentialTypeNormal{}}
+		if v.AccountCreation.GetCredentialType() == pb.CredentialType_CREDENTIAL_TYPE_INITIAL {
+			credentialType = CredentialType{Type: CredentialTypeInitial{}}
+		}
+		details = AccountCreationDetails{
+			CredentialType: credentialType,
+			Address:        parseAccountAddress(v.AccountCreation.GetAddress()),
+			RegId:          CredentialRegistrationId{Value: v.AccountCreation.GetRegId().GetValue()},
+		}
+	case *pb.BlockItemSummary_Update:
+		details = UpdateDetails{
+			EffectiveTime: TransactionTime{Value: v.Update.GetEffectiveTime().GetValue()},
+			Payload:       v.Update.GetPayload(),
+		}
+	default:
+		return BlockItemSummary{}, errors.New("Error parsing BlockItemSummary: " + ErrUnknownVariant.Error())
+	}
+
+	return BlockItemSummary{
+		Index:      TransactionIndex{Value: b.GetIndex().GetValue()},
+		EnergyCost: Energy{Value: b.GetEnergyCost().GetValue()},
+		Hash:       parseTransactionHash(b.GetHash()),
+		Details:    details,
+	}, nil
+}
+
+// Parses *pb.AccountTransactionDetails to AccountTransactionDetails.
+func parseAccountTransactionDetails(a *pb.AccountTransactionDetails) (AccountTransactionDetails, error) {
+	effects, err := parseAccountTransactionEffects(a.GetEffects())
+	if err != nil {
+		return AccountTransactionDetails{}, errors.New("Error parsing AccountTransactionDetails: " + err.Error())
+	}
+
+	return AccountTransactionDetails{
+		Cost:    Amount{Value: a.GetCost().GetValue()},
+		Sender:  parseAccountAddress(a.GetSender()),
+		Effects: effects,
+	}, nil
+}
+
+// TransactionType the type of an account transaction.
+type TransactionType int32
+
+const (
+	TransactionTypeDeployModule                    TransactionType = 0
+	TransactionTypeInitContract                    TransactionType = 1
+	TransactionTypeUpdate                          TransactionType = 2
+	TransactionTypeTransfer                        TransactionType = 3
+	TransactionTypeAddBaker                        TransactionType = 4
+	TransactionTypeRemoveBaker                     TransactionType = 5
+	TransactionTypeUpdateBakerStake                TransactionType = 6
+	TransactionTypeUpdateBakerRestakeEarnings      TransactionType = 7
+	TransactionTypeUpdateBakerKeys                 TransactionType = 8
+	TransactionTypeUpdateCredentialKeys            TransactionType = 9
+	TransactionTypeEncryptedAmountTransfer         TransactionType = 10
+	TransactionTypeTransferToEncrypted             TransactionType = 11
+	TransactionTypeTransferToPublic                TransactionType = 12
+	TransactionTypeTransferWithSchedule            TransactionType = 13
+	TransactionTypeUpdateCredentials               TransactionType = 14
+	TransactionTypeRegisterData                    TransactionType = 15
+	TransactionTypeTransferWithMemo                TransactionType = 16
+	TransactionTypeEncryptedAmountTransferWithMemo TransactionType = 17
+	TransactionTypeTransferWithScheduleAndMemo     TransactionType = 18
+	TransactionTypeConfigureBaker                  TransactionType = 19
+	TransactionTypeConfigureDelegation             TransactionType = 20
+)
+
+// AccountTransactionEffects effects of an account transaction. All variants except TransactionRejected
+// correspond to a unique transaction that was successful. Implemented by TransactionRejected, ModuleDeployed,
+// ContractInitialized, ContractUpdateIssued, AccountTransfer, BakerAdded, BakerRemoved, BakerStakeUpdated,
+// BakerRestakeEarningsUpdated, BakerKeysUpdated, EncryptedAmountTransferred, TransferredToEncrypted,
+// TransferredToPublic, TransferredWithSchedule, CredentialKeysUpdated, CredentialsUpdated, DataRegistered,
+// BakerConfigured and DelegationConfigured.
+type AccountTransactionEffects interface {
+	isAccountTransactionEffects()
+}
+
+// TransactionRejected the transaction was rejected. The sender is still charged for it.
+type TransactionRejected struct {
+	// Transaction type of a failed transaction, if known. In case of serialization failure this will be nil.
+	TransactionType *TransactionType
+	// Reason for rejection of the transaction.
+	Reason RejectReason
+}
+
+// ModuleDeployed a module was deployed. This corresponds to DeployModule transaction type.
+type ModuleDeployed struct {
+	ModuleRef ModuleRef
+}
+
+// ContractInitialized a contract was initialized. This corresponds to InitContract transaction type.
+type ContractInitialized struct {
+	// Contract version.
+	ContractVersion ContractVersion
+	// Module with the source code of the contract.
+	OriginRef ModuleRef
+	// The newly assigned address of the contract.
+	Address ContractAddress
+	// The amount the instance was initialized with.
+	Amount Amount
+	// The name of the contract.
+	InitName InitName
+	// Any contract events that might have been generated by the contract initialization.
+	Events []ContractEvent
+	// The parameter passed to the initializer.
+	Parameter Parameter
+}
+
+// ContractUpdateIssued a contract update transaction was issued and produced the given trace.
+// This is the result of Update transaction.
+type ContractUpdateIssued struct {
+	Effects []ContractTraceElement
+}
+
+// AccountTransfer a simple account to account transfer occurred. This is the result of a successful
+// Transfer or TransferWithMemo transaction.
+type AccountTransfer struct {
+	// Amount that was transferred.
+	Amount Amount
+	// Receiver account.
+	Receiver AccountAddress
+	// Memo, if the transfer had one.
+	Memo *Memo
+}
+
+// BakerAdded an account was registered as a baker. This is the result of a successful AddBaker transaction.
+type BakerAdded struct {
+	// The keys with which the baker registered.
+	KeysEvent BakerKeysEvent
+	// The amount the account staked to become a baker. This amount is locked.
+	Stake Amount
+	// Whether the baker will automatically add earnings to their stake or not.
+	RestakeEarnings bool
+}
+
+// BakerRemoved an account was deregistered as a baker. This is the result of a successful
+// UpdateBakerStake transaction that removed a baker.
+type BakerRemoved struct {
+	BakerId BakerId
+}
+
+// BakerStakeUpdated the stake of a baker was updated. This is the result of a successful
+// UpdateBakerStake transaction. Update is nil if the stake did not change.
+type BakerStakeUpdated struct {
+	Update *BakerStakeUpdatedData
+}
+
+// BakerRestakeEarningsUpdated an account changed its preference for restaking earnings. This is the result
+// of a successful UpdateBakerRestakeEarnings transaction.
+type BakerRestakeEarningsUpdated struct {
+	// Baker's id.
+	BakerId BakerId
+	// The new value of the flag.
+	RestakeEarnings bool
+}
+
+// BakerKeysUpdated the baker's keys were updated. This is the result of a successful UpdateBakerKeys transaction.
+type BakerKeysUpdated struct {
+	KeysEvent BakerKeysEvent
+}
+
+// EncryptedAmountTransferred an encrypted amount was transferred. This is the result of a successful
+// EncryptedAmountTransfer or EncryptedAmountTransferWithMemo transaction.
+type EncryptedAmountTransferred struct {
+	Removed EncryptedAmountRemovedEvent
+	Added   NewEncryptedAmountEvent
+	Memo    *Memo
+}
+
+// TransferredToEncrypted an account transferred part of its public balance to its encrypted balance.
+// This is the result of a successful TransferToEncrypted transaction.
+type TransferredToEncrypted struct {
+	Added EncryptedSelfAmountAddedEvent
+}
+
+// TransferredToPublic an account transferred part of its encrypted balance to its public balance.
+// This is the result of a successful TransferToPublic transaction.
+type TransferredToPublic struct {
+	Removed EncryptedAmountRemovedEvent
+	Amount  Amount
+}
+
+// TransferredWithSchedule a transfer with schedule was performed. This is the result of a successful
+// TransferWithSchedule or TransferWithScheduleAndMemo transaction.
+type TransferredWithSchedule struct {
+	// Receiver account.
+	Receiver AccountAddress
+	// The list of releases. Ordered by increasing timestamp.
+	Amount []NewRelease
+	// Memo, if the transfer had one.
+	Memo *Memo
+}
+
+// CredentialKeysUpdated keys of a specific credential were updated. This is the result of a successful
+// UpdateCredentialKeys transaction.
+type CredentialKeysUpdated struct {
+	CredId CredentialRegistrationId
+}
+
+// CredentialsUpdated account credentials were updated. This is the result of a successful UpdateCredentials transaction.
+type CredentialsUpdated struct {
+	// The credential ids that were added.
+	NewCredIds []CredentialRegistrationId
+	// The credentials that were removed.
+	RemovedCredIds []CredentialRegistrationId
+	// The (possibly) updated account threshold.
+	NewThreshold AccountThreshold
+}
+
+// DataRegistered some data was registered on the chain. This is the result of a successful RegisterData transaction.
+type DataRegistered struct {
+	Data RegisteredData
+}
+
+// BakerConfigured a baker was configured. The details of what happened are contained in the list of BakerEvents.
+type BakerConfigured struct {
+	Events []BakerEvent
+}
+
+// DelegationConfigured an account configured delegation. The details of what happened are contained in the
+// list of DelegationEvents.
+type DelegationConfigured struct {
+	Events []DelegationEvent
+}
+
+func (TransactionRejected) isAccountTransactionEffects()         {}
+func (ModuleDeployed) isAccountTransactionEffects()              {}
+func (ContractInitialized) isAccountTransactionEffects()         {}
+func (ContractUpdateIssued) isAccountTransactionEffects()        {}
+func (AccountTransfer) isAccountTransactionEffects()             {}
+func (BakerAdded) isAccountTransactionEffects()                  {}
+func (BakerRemoved) isAccountTransactionEffects()                {}
+func (BakerStakeUpdated) isAccountTransactionEffects()           {}
+func (BakerRestakeEarningsUpdated) isAccountTransactionEffects() {}
+func (BakerKeysUpdated) isAccountTransactionEffects()            {}
+func (EncryptedAmountTransferred) isAccountTransactionEffects()  {}
+func (TransferredToEncrypted) isAccountTransactionEffects()      {}
+func (TransferredToPublic) isAccountTransactionEffects()         {}
+func (TransferredWithSchedule) isAccountTransactionEffects()     {}
+func (CredentialKeysUpdated) isAccountTransactionEffects()       {}
+func (CredentialsUpdated) isAccountTransactionEffects()          {}
+func (DataRegistered) isAccountTransactionEffects()              {}
+func (BakerConfigured) isAccountTransactionEffects()             {}
+func (DelegationConfigured) isAccountTransactionEffects()        {}
+
+// Parses *pb.AccountTransactionEffects to AccountTransactionEffects.
+func parseAccountTransactionEffects(e *pb.AccountTransactionEffects) (AccountTransactionEffects, error) {
+	switch v := e.GetEffect().(type) {
+	case *pb.AccountTransactionEffects_None_:
+		reason, err := parseRejectReason(v.None.GetRejectReason())
+		if err != nil {
+			return nil, errors.New("Error parsing AccountTransactionEffects: " + err.Error())
+		}
+		var transactionType *TransactionType
+		if v.None.TransactionType != nil {
+			t := TransactionType(*v.None.TransactionType)
+			transactionType = &t
+		}
+		return TransactionRejected{TransactionType: transactionType, Reason: reason}, nil
+	case *pb.AccountTransactionEffects_ModuleDeployed:
+		return ModuleDeployed{ModuleRef: parseModuleRef(v.ModuleDeployed)}, nil
+	case *pb.AccountTransactionEffects_ContractInitialized:
+		c := v.ContractInitialized
+		return ContractInitialized{
+			ContractVersion: ContractVersion(c.GetContractVersion()),
+			OriginRef:       parseModuleRef(c.GetOriginRef()),
+			Address:         parseContractAddress(c.GetAddress()),
+			Amount:          Amount{Value: c.GetAmount().GetValue()},
+			InitName:        InitName{Value: c.GetInitName().GetValue()},
+			Events:          parseContractEvents(c.GetEvents()),
+			Parameter:       Parameter{Value: c.GetParameter().GetValue()},
+		}, nil
+	case *pb.AccountTransactionEffects_ContractUpdateIssued_:
+		effects := make([]ContractTraceElement, 0, len(v.ContractUpdateIssued.GetEffects()))
+		for _, t := range v.ContractUpdateIssued.GetEffects() {
+			element, err := parseContractTraceElement(t)
+			if err != nil {
+				return nil, errors.New("Error parsing AccountTransactionEffects: " + err.Error())
+			}
+			effects = append(effects, element)
+		}
+		return ContractUpdateIssued{Effects: effects}, nil
+	case *pb.AccountTransactionEffects_AccountTransfer_:
+		return AccountTransfer{
+			Amount:   Amount{Value: v.AccountTransfer.GetAmount().GetValue()},
+			Receiver: parseAccountAddress(v.AccountTransfer.GetReceiver()),
+			Memo:     parseMemo(v.AccountTransfer.GetMemo()),
+		}, nil
+	case *pb.AccountTransactionEffects_BakerAdded:
+		return BakerAdded{
+			KeysEvent:       parseBakerKeysEvent(v.BakerAdded.GetKeysEvent()),
+			Stake:           Amount{Value: v.BakerAdded.GetStake().GetValue()},
+			RestakeEarnings: v.BakerAdded.GetRestakeEarnings(),
+		}, nil
+	case *pb.AccountTransactionEffects_BakerRemoved:
+		return BakerRemoved{BakerId: BakerId{Value: v.BakerRemoved.GetValue()}}, nil
+	case *pb.AccountTransactionEffects_BakerStakeUpdated_:
+		var update *BakerStakeUpdatedData
+		if u := v.BakerStakeUpdated.GetUpdate(); u != nil {
+			update = &BakerStakeUpdatedData{
+				BakerId:   BakerId{Value: u.GetBakerId().GetValue()},
+				NewStake:  Amount{Value: u.GetNewStake().GetValue()},
+				Increased: u.GetIncreased(),
+			}
+		}
+		return BakerStakeUpdated{Update: update}, nil
+	case *pb.AccountTransactionEffects_BakerRestakeEarningsUpdated:
+		return BakerRestakeEarningsUpdated{
+			BakerId:         BakerId{Value: v.BakerRestakeEarningsUpdated.GetBakerId().GetValue()},
+			RestakeEarnings: v.BakerRestakeEarningsUpdated.GetRestakeEarnings(),
+		}, nil
+	case *pb.AccountTransactionEffects_BakerKeysUpdated:
+		return BakerKeysUpdated{KeysEvent: parseBakerKeysEvent(v.BakerKeysUpdated)}, nil
+	case *pb.AccountTransactionEffects_EncryptedAmountTransferred_:
+		added := v.EncryptedAmountTransferred.GetAdded()
+		return EncryptedAmountTransferred{
+			Removed: parseEncryptedAmountRemovedEvent(v.EncryptedAmountTransferred.GetRemoved()),
+			Added: NewEncryptedAmountEvent{
+				Receiver:        parseAccountAddress(added.GetReceiver()),
+				NewIndex:        added.GetNewIndex(),
+				EncryptedAmount: EncryptedAmount{Value: added.GetEncryptedAmount().GetValue()},
+			},
+			Memo: parseMemo(v.EncryptedAmountTransferred.GetMemo()),
+		}, nil
+	case *pb.AccountTransactionEffects_TransferredToEncrypted:
+		return TransferredToEncrypted{Added: EncryptedSelfAmountAddedEvent{
+			Account:   parseAccountAddress(v.TransferredToEncrypted.GetAccount()),
+			NewAmount: EncryptedAmount{Value: v.TransferredToEncrypted.GetNewAmount().GetValue()},
+			Amount:    Amount{Value: v.TransferredToEncrypted.GetAmount().GetValue()},
+		}}, nil
+	case *pb.AccountTransactionEffects_TransferredToPublic_:
+		return TransferredToPublic{
+			Removed: parseEncryptedAmountRemovedEvent(v.TransferredToPublic.GetRemoved()),
+			Amount:  Amount{Value: v.TransferredToPublic.GetAmount().GetValue()},
+		}, nil
+	case *pb.AccountTransactionEffects_TransferredWithSchedule_:
+		releases := make([]NewRelease, 0, len(v.TransferredWithSchedule.GetAmount()))
+		for _, r := range v.TransferredWithSchedule.GetAmount() {
+			releases = append(releases, NewRelease{
+				Timestamp: Timestamp{Value: r.GetTimestamp().GetValue()},
+				Amount:    Amount{Value: r.GetAmount().GetValue()},
+			})
+		}
+		return TransferredWithSchedule{
+			Receiver: parseAccountAddress(v.TransferredWithSchedule.GetReceiver()),
+			Amount:   releases,
+			Memo:     parseMemo(v.TransferredWithSchedule.GetMemo()),
+		}, nil
+	case *pb.AccountTransactionEffects_CredentialKeysUpdated:
+		return CredentialKeysUpdated{CredId: CredentialRegistrationId{Value: v.CredentialKeysUpdated.GetValue()}}, nil
+	case *pb.AccountTransactionEffects_CredentialsUpdated_:
+		return CredentialsUpdated{
+			NewCredIds:     parseCredentialRegistrationIds(v.CredentialsUpdated.GetNewCredIds()),
+			RemovedCredIds: parseCredentialRegistrationIds(v.CredentialsUpdated.GetRemovedCredIds()),
+			NewThreshold:   AccountThreshold{Value: uint8(v.CredentialsUpdated.GetNewThreshold().GetValue())},
+		}, nil
+	case *pb.AccountTransactionEffects_DataRegistered:
+		return DataRegistered{Data: RegisteredData{Value: v.DataRegistered.GetValue()}}, nil
+	case *pb.AccountTransactionEffects_BakerConfigured_:
+		events := make([]BakerEvent, 0, len(v.BakerConfigured.GetEvents()))
+		for _, e := range v.BakerConfigured.GetEvents() {
+			event, err := parseBakerEvent(e)
+			if err != nil {
+				return nil, errors.New("Error parsing AccountTransactionEffects: " + err.Error())
+			}
+			events = append(events, event)
+		}
+		return BakerConfigured{Events: events}, nil
+	case *pb.AccountTransactionEffects_DelegationConfigured_:
+		events := make([]DelegationEvent, 0, len(v.DelegationConfigured.GetEvents()))
+		for _, e := range v.DelegationConfigured.GetEvents() {
+			event, err := parseDelegationEvent(e)
+			if err != nil {
+				return nil, errors.New("Error parsing AccountTransactionEffects: " + err.Error())
+			}
+			events = append(events, event)
+		}
+		return DelegationConfigured{Events: events}, nil
+	}
+
+	return nil, errors.New("Error parsing AccountTransactionEffects: " + ErrUnknownVariant.Error())
+}
+
+// Parses *pb.Memo to *Memo. Returns nil if no memo is present.
+func parseMemo(m *pb.Memo) *Memo {
+	if m == nil {
+		return nil
+	}
+	return &Memo{Value: m.Value}
+}
+
+// ContractVersion the version of a smart contract module.
+type ContractVersion uint8
+
+const (
+	ContractVersionV0 ContractVersion = 0
+	ContractVersionV1 ContractVersion = 1
+)
+
+// ContractEvent an event logged by a smart contract instance.
+type ContractEvent struct {
+	Value []byte
+}
+
+// Parses []*pb.ContractEvent to []ContractEvent.
+func parseContractEvents(events []*pb.ContractEvent) []ContractEvent {
+	res := make([]ContractEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, ContractEvent{Value: e.GetValue()})
+	}
+	return res
+}
+
+// ContractTraceElement effects produced by successful smart contract invocations. A single invocation will
+// produce a sequence of these effects. Element is either ContractTraceElementUpdated, ContractTraceElementTransferred,
+// ContractTraceElementInterrupted, ContractTraceElementResumed or ContractTraceElementUpgraded.
+type ContractTraceElement struct {
+	Element isContractTraceElement
+}
+
+type isContractTraceElement interface {
+	isContractTraceElement()
+}
+
+// ContractTraceElementUpdated a contract instance was updated.
+type ContractTraceElementUpdated struct {
+	// Contract version.
+	ContractVersion ContractVersion
+	// Address of the affected instance.
+	Address ContractAddress
+	// The origin of the message to the smart contract. This is either *AccountAddress or *ContractAddress.
+	Instigator isAddress
+	// The amount the method was invoked with.
+	Amount Amount
+	// The parameter passed to the method.
+	Parameter Parameter
+	// The name of the method that was executed.
+	ReceiveName ReceiveName
+	// Any contract events that might have been generated by the contract execution.
+	Events []ContractEvent
+}
+
+// ContractTraceElementTransferred a contract transferred an amount to an account.
+type ContractTraceElementTransferred struct {
+	// Sender contract.
+	Sender ContractAddress
+	// Amount transferred.
+	Amount Amount
+	// Receiver account.
+	Receiver AccountAddress
+}
+
+// ContractTraceElementInterrupted a contract was interrupted. This occurs when a contract invokes another
+// contract or makes a transfer to an account.
+type ContractTraceElementInterrupted struct {
+	// The contract interrupted.
+	Address ContractAddress
+	// The events generated up until the interruption.
+	Events []ContractEvent
+}
+
+// ContractTraceElementResumed a previously interrupted contract was resumed.
+type ContractTraceElementResumed struct {
+	// The contract resumed.
+	Address ContractAddress
+	// Whether the action that caused the interruption (invoke contract or make transfer) was successful or not.
+	Success bool
+}
+
+// ContractTraceElementUpgraded a contract was upgraded.
+type ContractTraceElementUpgraded struct {
+	// The contract that was upgraded.
+	Address ContractAddress
+	// The module from which the contract was upgraded.
+	From ModuleRef
+	// The module to which it was upgraded.
+	To ModuleRef
+}
+
+func (ContractTraceElementUpdated) isContractTraceElement()     {}
+func (ContractTraceElementTransferred) isContractTraceElement() {}
+func (ContractTraceElementInterrupted) isContractTraceElement() {}
+func (ContractTraceElementResumed) isContractTraceElement()     {}
+func (ContractTraceElementUpgraded) isContractTraceElement()    {}
+
+// Parses *pb.ContractTraceElement to ContractTraceElement.
+func parseContractTraceElement(c *pb.ContractTraceElement) (ContractTraceElement, error) {
+	switch v := c.GetElement().(type) {
+	case *pb.ContractTraceElement_Updated:
+		return ContractTraceElement{Element: ContractTraceElementUpdated{
+			ContractVersion: ContractVersion(v.Updated.GetContractVersion()),
+			Address:         parseContractAddress(v.Updated.GetAddress()),
+			Instigator:      parseAddress(v.Updated.GetInstigator()),
+			Amount:          Amount{Value: v.Updated.GetAmount().GetValue()},
+			Parameter:       Parameter{Value: v.Updated.GetParameter().GetValue()},
+			ReceiveName:     ReceiveName{Value: v.Updated.GetReceiveName().GetValue()},
+			Events:          parseContractEvents(v.Updated.GetEvents()),
+		}}, nil
+	case *pb.ContractTraceElement_Transferred_:
+		return ContractTraceElement{Element: ContractTraceElementTransferred{
+			Sender:   parseContractAddress(v.Transferred.GetSender()),
+			Amount:   Amount{Value: v.Transferred.GetAmount().GetValue()},
+			Receiver: parseAccountAddress(v.Transferred.GetReceiver()),
+		}}, nil
+	case *pb.ContractTraceElement_Interrupted_:
+		return ContractTraceElement{Element: ContractTraceElementInterrupted{
+			Address: parseContractAddress(v.Interrupted.GetAddress()),
+			Events:  parseContractEvents(v.Interrupted.GetEvents()),
+		}}, nil
+	case *pb.ContractTraceElement_Resumed_:
+		return ContractTraceElement{Element: ContractTraceElementResumed{
+			Address: parseContractAddress(v.Resumed.GetAddress()),
+			Success: v.Resumed.GetSuccess(),
+		}}, nil
+	case *pb.ContractTraceElement_Upgraded_:
+		return ContractTraceElement{Element: ContractTraceElementUpgraded{
+			Address: parseContractAddress(v.Upgraded.GetAddress()),
+			From:    parseModuleRef(v.Upgraded.GetFrom()),
+			To:      parseModuleRef(v.Upgraded.GetTo()),
+		}}, nil
+	}
+
+	return ContractTraceElement{}, errors.New("Error parsing ContractTraceElement: " + ErrUnknownVariant.Error())
+}
+
+// BakerKeysEvent the keys of a baker were set.
+type BakerKeysEvent struct {
+	// ID of the baker whose keys were changed.
+	BakerId BakerId
+	// Account address of the baker.
+	Account AccountAddress
+	// The new public key for verifying block signatures.
+	SignKey BakerSignatureVerifyKey
+	// The new public key for verifying whether the baker won the block lottery.
+	ElectionKey BakerElectionVerifyKey
+	// The new public key for verifying finalization records.
+	AggregationKey BakerAggregationVerifyKey
+}
+
+// Parses *pb.BakerKeysEvent to BakerKeysEvent.
+func parseBakerKeysEvent(b *pb.BakerKeysEvent) BakerKeysEvent {
+	return BakerKeysEvent{
+		BakerId:        BakerId{Value: b.GetBakerId().GetValue()},
+		Account:        parseAccountAddress(b.GetAccount()),
+		SignKey:        BakerSignatureVerifyKey{Value: b.GetSignKey().GetValue()},
+		ElectionKey:    BakerElectionVerifyKey{Value: b.GetElectionKey().GetValue()},
+		AggregationKey: BakerAggregationVerifyKey{Value: b.GetAggregationKey().GetValue()},
+	}
+}
+
+// BakerStakeUpdatedData the stake of a baker was updated.
+type BakerStakeUpdatedData struct {
+	// Affected baker.
+	BakerId BakerId
+	// New stake.
+	NewStake Amount
+	// A boolean which indicates whether it increased (true) or decreased (false).
+	Increased bool
+}
+
+// EncryptedAmountRemovedEvent event generated when one or more encrypted amounts are consumed from the account.
+type EncryptedAmountRemovedEvent struct {
+	// The affected account.
+	Account AccountAddress
+	// The new self encrypted amount on the affected account.
+	NewAmount EncryptedAmount
+	// The input encrypted amount that was removed.
+	InputAmount EncryptedAmount
+	// The index indicating which amounts were used.
+	UpToIndex uint64
+}
+
+// Parses *pb.EncryptedAmountRemovedEvent to EncryptedAmountRemovedEvent.
+func parseEncryptedAmountRemovedEvent(e *pb.EncryptedAmountRemovedEvent) EncryptedAmountRemovedEvent {
+	return EncryptedAmountRemovedEvent{
+		Account:     parseAccountAddress(e.GetAccount()),
+		NewAmount:   EncryptedAmount{Value: e.GetNewAmount().GetValue()},
+		InputAmount: EncryptedAmount{Value: e.GetInputAmount().GetValue()},
+		UpToIndex:   e.GetUpToIndex(),
+	}
+}
+
+// NewEncryptedAmountEvent a new encrypted amount was added to the account.
+type NewEncryptedAmountEvent struct {
+	// The account onto which the amount was added.
+	Receiver AccountAddress
+	// The index the amount was assigned.
+	NewIndex uint64
+	// The encrypted amount that was added.
+	EncryptedAmount EncryptedAmount
+}
+
+// EncryptedSelfAmountAddedEvent an amount was transferred from public to encrypted balance of the account.
+type EncryptedSelfAmountAddedEvent struct {
+	// The affected account.
+	Account AccountAddress
+	// The new self encrypted amount of the account.
+	NewAmount EncryptedAmount
+	// The amount that was transferred from public to encrypted balance.
+	Amount Amount
+}
+
+// NewRelease a single scheduled release of an amount.
+type NewRelease struct {
+	// Effective time of the release in milliseconds since unix epoch.
+	Timestamp Timestamp
+	// Amount to be released.
+	Amount Amount
+}
+
+// DelegatorId the ID of a delegator, which is the index of its account.
+type DelegatorId struct {
+	Id AccountIndex
+}
+
+// BakerEvent an event that occurred when configuring a baker. Event is one of the BakerEvent* types.
+type BakerEvent struct {
+	Event isBakerEvent
+}
+
+type isBakerEvent interface {
+	isBakerEvent()
+}
+
+// BakerEventAdded a baker was added.
+type BakerEventAdded struct {
+	// The keys with which the baker registered.
+	KeysEvent BakerKeysEvent
+	// The amount the account staked to become a baker. This amount is locked.
+	Stake Amount
+	// Whether the baker will automatically add earnings to their stake or not.
+	RestakeEarnings bool
+}
+
+// BakerEventRemoved a baker was removed.
+type BakerEventRemoved struct {
+	BakerId BakerId
+}
+
+// BakerEventStakeIncreased the baker's stake was increased.
+type BakerEventStakeIncreased struct {
+	BakerId  BakerId
+	NewStake Amount
+}
+
+// BakerEventStakeDecreased the baker's stake was decreased.
+type BakerEventStakeDecreased struct {
+	BakerId  BakerId
+	NewStake Amount
+}
+
+// BakerEventRestakeEarningsUpdated the baker's setting for restaking earnings was updated.
+type BakerEventRestakeEarningsUpdated struct {
+	BakerId         BakerId
+	RestakeEarnings bool
+}
+
+// BakerEventKeysUpdated baker keys were updated.
+type BakerEventKeysUpdated struct {
+	KeysEvent BakerKeysEvent
+}
+
+// BakerEventSetOpenStatus the baker's open status was updated.
+type BakerEventSetOpenStatus struct {
+	BakerId    BakerId
+	OpenStatus OpenStatus
+}
+
+// BakerEventSetMetadataUrl the baker's metadata URL was updated.
+type BakerEventSetMetadataUrl struct {
+	BakerId BakerId
+	Url     string
+}
+
+// BakerEventSetTransactionFeeCommission the baker's transaction fee commission was updated.
+type BakerEventSetTransactionFeeCommission struct {
+	BakerId                  BakerId
+	TransactionFeeCommission AmountFraction
+}
+
+// BakerEventSetBakingRewardCommission the baker's baking reward commission was updated.
+type BakerEventSetBakingRewardCommission struct {
+	BakerId                BakerId
+	BakingRewardCommission AmountFraction
+}
+
+// BakerEventSetFinalizationRewardCommission the baker's finalization reward commission was updated.
+type BakerEventSetFinalizationRewardCommission struct {
+	BakerId                      BakerId
+	FinalizationRewardCommission AmountFraction
+}
+
+// BakerEventDelegationRemoved an existing delegator was removed when the account became a baker.
+type BakerEventDelegationRemoved struct {
+	DelegatorId DelegatorId
+}
+
+// BakerEventSuspended the baker was suspended.
+type BakerEventSuspended struct {
+	BakerId BakerId
+}
+
+// BakerEventResumed the baker was resumed.
+type BakerEventResumed struct {
+	BakerId BakerId
+}
+
+func (BakerEventAdded) isBakerEvent()                           {}
+func (BakerEventRemoved) isBakerEvent()                         {}
+func (BakerEventStakeIncreased) isBakerEvent()                  {}
+func (BakerEventStakeDecreased) isBakerEvent()                  {}
+func (BakerEventRestakeEarningsUpdated) isBakerEvent()          {}
+func (BakerEventKeysUpdated) isBakerEvent()                     {}
+func (BakerEventSetOpenStatus) isBakerEvent()                   {}
+func (BakerEventSetMetadataUrl) isBakerEvent()                  {}
+func (BakerEventSetTransactionFeeCommission) isBakerEvent()     {}
+func (BakerEventSetBakingRewardCommission) isBakerEvent()       {}
+func (BakerEventSetFinalizationRewardCommission) isBakerEvent() {}
+func (BakerEventDelegationRemoved) isBakerEvent()               {}
+func (BakerEventSuspended) isBakerEvent()                       {}
+func (BakerEventResumed) isBakerEvent()                         {}
+
+// Parses *pb.BakerEvent to BakerEvent.
+func parseBakerEvent(b *pb.BakerEvent) (BakerEvent, error) {
+	switch v := b.GetEvent().(type) {
+	case *pb.BakerEvent_BakerAdded_:
+		return BakerEvent{Event: BakerEventAdded{
+			KeysEvent:       parseBakerKeysEvent(v.BakerAdded.GetKeysEvent()),
+			Stake:           Amount{Value: v.BakerAdded.GetStake().GetValue()},
+			RestakeEarnings: v.BakerAdded.GetRestakeEarnings(),
+		}}, nil
+	case *pb.BakerEvent_BakerRemoved:
+		return BakerEvent{Event: BakerEventRemoved{BakerId: BakerId{Value: v.BakerRemoved.GetValue()}}}, nil
+	case *pb.BakerEvent_BakerStakeIncreased_:
+		return BakerEvent{Event: BakerEventStakeIncreased{
+			BakerId:  BakerId{Value: v.BakerStakeIncreased.GetBakerId().GetValue()},
+			NewStake: Amount{Value: v.BakerStakeIncreased.GetNewStake().GetValue()},
+		}}, nil
+	case *pb.BakerEvent_BakerStakeDecreased_:
+		return BakerEvent{Event: BakerEventStakeDecreased{
+			BakerId:  BakerId{Value: v.BakerStakeDecreased.GetBakerId().GetValue()},
+			NewStake: Amount{Value: v.BakerStakeDecreased.GetNewStake().GetValue()},
+		}}, nil
+	case *pb.BakerEvent_BakerRestakeEarningsUpdated_:
+		return BakerEvent{Event: BakerEventRestakeEarningsUpdated{
+			BakerId:         BakerId{Value: v.BakerRestakeEarningsUpdated.GetBakerId().GetValue()},
+			RestakeEarnings: v.BakerRestakeEarningsUpdated.GetRestakeEarnings(),
+		}}, nil
+	case *pb.BakerEvent_BakerKeysUpdated:
+		return BakerEvent{Event: BakerEventKeysUpdated{KeysEvent: parseBakerKeysEvent(v.BakerKeysUpdated)}}, nil
+	case *pb.BakerEvent_BakerSetOpenStatus_:
+		return BakerEvent{Event: BakerEventSetOpenStatus{
+			BakerId:    BakerId{Value: v.BakerSetOpenStatus.GetBakerId().GetValue()},
+			OpenStatus: OpenStatus(v.BakerSetOpenStatus.GetOpenStatus()),
+		}}, nil
+	case *pb.BakerEvent_BakerSetMetadataUrl_:
+		return BakerEvent{Event: BakerEventSetMetadataUrl{
+			BakerId: BakerId{Value: v.BakerSetMetadataUrl.GetBakerId().GetValue()},
+			Url:     v.BakerSetMetadataUrl.GetUrl(),
+		}}, nil
+	case *pb.BakerEvent_BakerSetTransactionFeeCommission_:
+		commission, err := parseAmountFraction(v.BakerSetTransactionFeeCommission.GetTransactionFeeCommission())
+		if err != nil {
+			return BakerEvent{}, errors.New("Error parsing BakerEvent: " + err.Error())
+		}
+		return BakerEvent{Event: BakerEventSetTransactionFeeCommission{
+			BakerId:                  BakerId{Value: v.BakerSetTransactionFeeCommission.GetBakerId().GetValue()},
+			TransactionFeeCommission: commission,
+		}}, nil
+	case *pb.BakerEvent_BakerSetBakingRewardCommission_:
+		commission, err := parseAmountFraction(v.BakerSetBakingRewardCommission.GetBakingRewardCommission())
+		if err != nil {
+			return BakerEvent{}, errors.New("Error parsing BakerEvent: " + err.Error())
+		}
+		return BakerEvent{Event: BakerEventSetBakingRewardCommission{
+			BakerId:                BakerId{Value: v.BakerSetBakingRewardCommission.GetBakerId().GetValue()},
+			BakingRewardCommission: commission,
+		}}, nil
+	case *pb.BakerEvent_BakerSetFinalizationRewardCommission_:
+		commission, err := parseAmountFraction(v.BakerSetFinalizationRewardCommission.GetFinalizationRewardCommission())
+		if err != nil {
+			return BakerEvent{}, errors.New("Error parsing BakerEvent: " + err.Error())
+		}
+		return BakerEvent{Event: BakerEventSetFinalizationRewardCommission{
+			BakerId:                      BakerId{Value: v.BakerSetFinalizationRewardCommission.GetBakerId().GetValue()},
+			FinalizationRewardCommission: commission,
+		}}, nil
+	case *pb.BakerEvent_DelegationRemoved_:
+		return BakerEvent{Event: BakerEventDelegationRemoved{
+			DelegatorId: parseDelegatorId(v.DelegationRemoved.GetDelegatorId()),
+		}}, nil
+	case *pb.BakerEvent_BakerSuspended_:
+		return BakerEvent{Event: BakerEventSuspended{BakerId: BakerId{Value: v.BakerSuspended.GetBakerId().GetValue()}}}, nil
+	case *pb.BakerEvent_BakerResumed_:
+		return BakerEvent{Event: BakerEventResumed{BakerId: BakerId{Value: v.BakerResumed.GetBakerId().GetValue()}}}, nil
+	}
+
+	return BakerEvent{}, errors.New("Error parsing BakerEvent: " + ErrUnknownVariant.Error())
+}
+
+// Parses *pb.DelegatorId to DelegatorId.
+func parseDelegatorId(d *pb.DelegatorId) DelegatorId {
+	return DelegatorId{Id: AccountIndex{Value: d.GetId().GetValue()}}
+}
+
+// DelegationEvent an event that occurred when configuring delegation. Event is one of the DelegationEvent* types.
+type DelegationEvent struct {
+	Event isDelegationEvent
+}
+
+type isDelegationEvent interface {
+	isDelegationEvent()
+}
+
+// DelegationEventStakeIncreased the delegator's stake increased.
+type DelegationEventStakeIncreased struct {
+	DelegatorId DelegatorId
+	NewStake    Amount
+}
+
+// DelegationEventStakeDecreased the delegator's stake decreased.
+type DelegationEventStakeDecreased struct {
+	DelegatorId DelegatorId
+	NewStake    Amount
+}
+
+// DelegationEventSetRestakeEarnings the delegator's restaking setting was updated.
+type DelegationEventSetRestakeEarnings struct {
+	DelegatorId     DelegatorId
+	RestakeEarnings bool
+}
+
+// DelegationEventSetDelegationTarget the delegator's delegation target was updated.
+type DelegationEventSetDelegationTarget struct {
+	DelegatorId      DelegatorId
+	DelegationTarget DelegationTarget
+}
+
+// DelegationEventAdded a delegator was added.
+type DelegationEventAdded struct {
+	DelegatorId DelegatorId
+}
+
+// DelegationEventRemoved a delegator was removed.
+type DelegationEventRemoved struct {
+	DelegatorId DelegatorId
+}
+
+// DelegationEventBakerRemoved an existing baker was removed when the account became a delegator.
+type DelegationEventBakerRemoved struct {
+	BakerId BakerId
+}
+
+func (DelegationEventStakeIncreased) isDelegationEvent()      {}
+func (DelegationEventStakeDecreased) isDelegationEvent()      {}
+func (DelegationEventSetRestakeEarnings) isDelegationEvent()  {}
+func (DelegationEventSetDelegationTarget) isDelegationEvent() {}
+func (DelegationEventAdded) isDelegationEvent()               {}
+func (DelegationEventRemoved) isDelegationEvent()             {}
+func (DelegationEventBakerRemoved) isDelegationEvent()        {}
+
+// Parses *pb.DelegationEvent to DelegationEvent.
+func parseDelegationEvent(d *pb.DelegationEvent) (DelegationEvent, error) {
+	switch v := d.GetEvent().(type) {
+	case *pb.DelegationEvent_DelegationStakeIncreased_:
+		return DelegationEvent{Event: DelegationEventStakeIncreased{
+			DelegatorId: parseDelegatorId(v.DelegationStakeIncreased.GetDelegatorId()),
+			NewStake:    Amount{Value: v.DelegationStakeIncreased.GetNewStake().GetValue()},
+		}}, nil
+	case *pb.DelegationEvent_DelegationStakeDecreased_:
+		return DelegationEvent{Event: DelegationEventStakeDecreased{
+			DelegatorId: parseDelegatorId(v.DelegationStakeDecreased.GetDelegatorId()),
+			NewStake:    Amount{Value: v.DelegationStakeDecreased.GetNewStake().GetValue()},
+		}}, nil
+	case *pb.DelegationEvent_DelegationSetRestakeEarnings_:
+		return DelegationEvent{Event: DelegationEventSetRestakeEarnings{
+			DelegatorId:     parseDelegatorId(v.DelegationSetRestakeEarnings.GetDelegatorId()),
+			RestakeEarnings: v.DelegationSetRestakeEarnings.GetRestakeEarnings(),
+		}}, nil
+	case *pb.DelegationEvent_DelegationSetDelegationTarget_:
+		return DelegationEvent{Event: DelegationEventSetDelegationTarget{
+			DelegatorId:      parseDelegatorId(v.DelegationSetDelegationTarget.GetDelegatorId()),
+			DelegationTarget: parseDelegationTarget(v.DelegationSetDelegationTarget.GetDelegationTarget()),
+		}}, nil
+	case *pb.DelegationEvent_DelegationAdded:
+		return DelegationEvent{Event: DelegationEventAdded{DelegatorId: parseDelegatorId(v.DelegationAdded)}}, nil
+	case *pb.DelegationEvent_DelegationRemoved:
+		return DelegationEvent{Event: DelegationEventRemoved{DelegatorId: parseDelegatorId(v.DelegationRemoved)}}, nil
+	case *pb.DelegationEvent_BakerRemoved_:
+		return DelegationEvent{Event: DelegationEventBakerRemoved{
+			BakerId: BakerId{Value: v.BakerRemoved.GetBakerId().GetValue()},
+		}}, nil
+	}
+
+	return DelegationEvent{}, errors.New("Error parsing DelegationEvent: " + ErrUnknownVariant.Error())
+}
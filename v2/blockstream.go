@@ -0,0 +1,312 @@
+package v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+const (
+	// DefaultBlockStreamMinBackoff is the default initial delay before a block stream reconnects to the node.
+	DefaultBlockStreamMinBackoff = 1 * time.Second
+	// DefaultBlockStreamMaxBackoff is the default maximum delay before a block stream reconnects to the node.
+	DefaultBlockStreamMaxBackoff = 1 * time.Minute
+	// arrivedBlocksRetainedHeights is the number of heights below the highest arrived block for which the hashes
+	// of emitted blocks are remembered, so that they are not emitted again after reconnecting.
+	arrivedBlocksRetainedHeights = 128
+)
+
+// ArrivedBlockInfo a block that arrived at the node.
+type ArrivedBlockInfo struct {
+	Hash   BlockHash
+	Height AbsoluteBlockHeight
+}
+
+// FinalizedBlockInfo a block that was finalized.
+type FinalizedBlockInfo struct {
+	Hash   BlockHash
+	Height AbsoluteBlockHeight
+}
+
+// BlockStreamConfig configuration of the streams returned by GetBlocksResilient and GetFinalizedBlocksResilient.
+type BlockStreamConfig struct {
+	// Initial delay before reconnecting to the node. Defaults to DefaultBlockStreamMinBackoff.
+	MinBackoff time.Duration
+	// Maximum delay before reconnecting to the node. Defaults to DefaultBlockStreamMaxBackoff.
+	MaxBackoff time.Duration
+	// Called when the connection to the node fails, before waiting to reconnect. May be nil.
+	OnError func(err error)
+}
+
+// ArrivedBlockStream a stream of arrived blocks that reconnects when the connection to the node fails.
+type ArrivedBlockStream struct {
+	s blockStream
+}
+
+// GetBlocksResilient returns a stream of blocks that arrive from the time of the first call to Recv onward.
+// Unlike GetBlocks, the stream reconnects with exponential backoff when the connection to the node fails, and
+// emits the live blocks that arrived in the meantime using GetBlocksAtHeight. No block is emitted twice.
+// Blocks missed during an outage are emitted in order of height, so heights are not necessarily increasing.
+// The stream ends when the context is cancelled.
+func (c *Client) GetBlocksResilient(ctx context.Context, config BlockStreamConfig) *ArrivedBlockStream {
+	return &ArrivedBlockStream{s: newBlockStream(ctx, c, config, false)}
+}
+
+// Recv returns the next arrived block. It only returns an error when the context of the stream is cancelled.
+func (s *ArrivedBlockStream) Recv() (ArrivedBlockInfo, error) {
+	block, err := s.s.recv()
+	if err != nil {
+		return ArrivedBlockInfo{}, err
+	}
+	return ArrivedBlockInfo{Hash: block.hash, Height: block.height}, nil
+}
+
+// FinalizedBlockStream a stream of finalized blocks that reconnects when the connection to the node fails.
+type FinalizedBlockStream struct {
+	s blockStream
+}
+
+// GetFinalizedBlocksResilient returns a stream of blocks that are finalized from the time of the first call to
+// Recv onward. Unlike GetFinalizedBlocks, the stream reconnects with exponential backoff when the connection to
+// the node fails, and fills any gap in heights, whether caused by an outage or by the node skipping blocks, using
+// GetBlocksAtHeight. Blocks are emitted exactly once and by increasing height without gaps.
+// The stream ends when the context is cancelled.
+func (c *Client) GetFinalizedBlocksResilient(ctx context.Context, config BlockStreamConfig) *FinalizedBlockStream {
+	return &FinalizedBlockStream{s: newBlockStream(ctx, c, config, true)}
+}
+
+// Recv returns the next finalized block. It only returns an error when the context of the stream is cancelled.
+func (s *FinalizedBlockStream) Recv() (FinalizedBlockInfo, error) {
+	block, err := s.s.recv()
+	if err != nil {
+		return FinalizedBlockInfo{}, err
+	}
+	return FinalizedBlockInfo{Hash: block.hash, Height: block.height}, nil
+}
+
+type streamedBlock struct {
+	hash   BlockHash
+	height AbsoluteBlockHeight
+}
+
+// blockStream implements the reconnecting streams of arrived and finalized blocks.
+type blockStream struct {
+	ctx       context.Context
+	client    *Client
+	config    BlockStreamConfig
+	finalized bool
+
+	// the current connection, nil if not connected.
+	recvBlock func() (streamedBlock, error)
+	cancel    context.CancelFunc
+	backoff   time.Duration
+	// blocks that are received or backfilled, but not yet emitted.
+	pending []streamedBlock
+
+	// whether any block has been emitted.
+	started bool
+	// the height of the last emitted finalized block, or the highest emitted arrived block.
+	height AbsoluteBlockHeight
+	// hashes of the emitted arrived blocks at the retained heights.
+	seen map[BlockHash]AbsoluteBlockHeight
+}
+
+func newBlockStream(ctx context.Context, client *Client, config BlockStreamConfig, finalized bool) blockStream {
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = DefaultBlockStreamMinBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultBlockStreamMaxBackoff
+	}
+	return blockStream{
+		ctx:       ctx,
+		client:    client,
+		config:    config,
+		finalized: finalized,
+		backoff:   config.MinBackoff,
+		seen:      make(map[BlockHash]AbsoluteBlockHeight),
+	}
+}
+
+func (s *blockStream) recv() (streamedBlock, error) {
+	for {
+		if s.ctx.Err() != nil {
+			s.disconnect()
+			return streamedBlock{}, s.ctx.Err()
+		}
+
+		if len(s.pending) > 0 {
+			block := s.pending[0]
+			s.pending = s.pending[1:]
+			if s.emit(block) {
+				return block, nil
+			}
+			continue
+		}
+
+		var err error
+		if s.recvBlock == nil {
+			err = s.connect()
+		} else {
+			var block streamedBlock
+			if block, err = s.recvBlock(); err == nil {
+				err = s.receive(block)
+			}
+		}
+		if err != nil {
+			if waitErr := s.wait(err); waitErr != nil {
+				return streamedBlock{}, waitErr
+			}
+		}
+	}
+}
+
+// emit records the block as emitted and returns whether it was not emitted before.
+func (s *blockStream) emit(block streamedBlock) bool {
+	if s.finalized {
+		if s.started && block.height.Value <= s.height.Value {
+			return false
+		}
+	} else {
+		if _, ok := s.seen[block.hash]; ok {
+			return false
+		}
+		s.seen[block.hash] = block.height
+		if block.height.Value > s.height.Value {
+			for hash, height := range s.seen {
+				if height.Value+arrivedBlocksRetainedHeights < block.height.Value {
+					delete(s.seen, hash)
+				}
+			}
+		}
+	}
+	if !s.started || block.height.Value > s.height.Value {
+		s.height = block.height
+	}
+	s.started = true
+	s.backoff = s.config.MinBackoff
+	return true
+}
+
+// receive queues a block received on the stream, preceded by any finalized blocks the stream skipped.
+func (s *blockStream) receive(block streamedBlock) error {
+	if s.finalized && s.started && block.height.Value > s.height.Value+1 {
+		if err := s.backfill(s.height.Value+1, block.height.Value-1); err != nil {
+			return err
+		}
+	}
+	s.pending = append(s.pending, block)
+	return nil
+}
+
+// connect opens the stream and queues the blocks missed since the last emitted block.
+func (s *blockStream) connect() error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	var recvBlock func() (streamedBlock, error)
+	if s.finalized {
+		stream, err := s.client.GetFinalizedBlocks(ctx)
+		if err != nil {
+			cancel()
+			return err
+		}
+		recvBlock = func() (streamedBlock, error) {
+			info, err := stream.Recv()
+			if err != nil {
+				return streamedBlock{}, err
+			}
+			return parseStreamedBlock(info.GetHash(), info.GetHeight())
+		}
+	} else {
+		stream, err := s.client.GetBlocks(ctx)
+		if err != nil {
+			cancel()
+			return err
+		}
+		recvBlock = func() (streamedBlock, error) {
+			info, err := stream.Recv()
+			if err != nil {
+				return streamedBlock{}, err
+			}
+			return parseStreamedBlock(info.GetHash(), info.GetHeight())
+		}
+	}
+	s.recvBlock = recvBlock
+	s.cancel = cancel
+
+	if !s.started {
+		return nil
+	}
+	// the stream is opened before backfilling so that no block is missed in between.
+	consensusInfo, err := s.client.GetConsensusInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if s.finalized {
+		return s.backfill(s.height.Value+1, consensusInfo.GetLastFinalizedBlockHeight().GetValue())
+	}
+	// siblings of the highest emitted block may have arrived during the outage.
+	return s.backfill(s.height.Value, consensusInfo.GetBestBlockHeight().GetValue())
+}
+
+// backfill queues the live blocks at the heights from and including from to and including to.
+func (s *blockStream) backfill(from, to uint64) error {
+	var blocks []streamedBlock
+	for height := from; height <= to; height++ {
+		hashes, err := s.client.GetBlocksAtHeight(s.ctx, &pb.BlocksAtHeightRequest{
+			BlocksAtHeight: &pb.BlocksAtHeightRequest_Absolute_{Absolute: &pb.BlocksAtHeightRequest_Absolute{
+				Height: &pb.AbsoluteBlockHeight{Value: height},
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			blocks = append(blocks, streamedBlock{hash: *hash, height: AbsoluteBlockHeight{Value: height}})
+		}
+	}
+	s.pending = append(s.pending, blocks...)
+	return nil
+}
+
+// wait closes the connection and waits before reconnecting. It returns an error if the context is cancelled.
+func (s *blockStream) wait(err error) error {
+	s.disconnect()
+	s.pending = nil
+	if s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+	if s.config.OnError != nil {
+		s.config.OnError(err)
+	}
+
+	timer := time.NewTimer(s.backoff)
+	defer timer.Stop()
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case <-timer.C:
+	}
+	s.backoff *= 2
+	if s.backoff > s.config.MaxBackoff {
+		s.backoff = s.config.MaxBackoff
+	}
+	return nil
+}
+
+func (s *blockStream) disconnect() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.recvBlock = nil
+	s.cancel = nil
+}
+
+// Parses the hash and height of *pb.ArrivedBlockInfo or *pb.FinalizedBlockInfo to streamedBlock.
+func parseStreamedBlock(hash *pb.BlockHash, height *pb.AbsoluteBlockHeight) (streamedBlock, error) {
+	blockHash, err := BlockHashFromBytes(hash.GetValue())
+	if err != nil {
+		return streamedBlock{}, err
+	}
+	return streamedBlock{hash: blockHash, height: AbsoluteBlockHeight{Value: height.GetValue()}}, nil
+}
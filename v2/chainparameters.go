@@ -0,0 +1,645 @@
+package v2
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// ChainParameters the chain parameters in effect in a block. The parameters are versioned, the version depends on
+// the protocol version of the block. ChainParameters is implemented by ChainParametersV0, ChainParametersV1,
+// ChainParametersV2 and ChainParametersV3. The accessors return the parameters common to all versions.
+type ChainParameters interface {
+	// EuroPerEnergy returns the euro per energy exchange rate.
+	EuroPerEnergy() *big.Rat
+	// MicroCcdPerEuro returns the microCCD per euro exchange rate.
+	MicroCcdPerEuro() *big.Rat
+	// FoundationAccount returns the address of the foundation account.
+	FoundationAccount() AccountAddress
+	// MinimumEquityCapital returns the minimum stake of a baker. For ChainParametersV0 this is
+	// the minimum threshold for baking.
+	MinimumEquityCapital() Amount
+	// CooldownParameters returns how long stake is locked after it is reduced or removed.
+	CooldownParameters() CooldownParameters
+
+	isChainParameters()
+}
+
+// ExchangeRates the exchange rates used to convert energy to CCD.
+type ExchangeRates struct {
+	// Euro per energy exchange rate.
+	EuroPerEnergy *big.Rat
+	// MicroCCD per euro exchange rate.
+	MicroCcdPerEuro *big.Rat
+}
+
+// CooldownParameters how long stake is locked after it is reduced or removed. For ChainParametersV0 only
+// BakerCooldownEpochs is set, for later versions only PoolOwnerCooldown and DelegatorCooldown are set.
+type CooldownParameters struct {
+	// Number of epochs a baker must cool down when removing its stake.
+	BakerCooldownEpochs Epoch
+	// Cooldown period of pool owners.
+	PoolOwnerCooldown time.Duration
+	// Cooldown period of delegators.
+	DelegatorCooldown time.Duration
+}
+
+// MintRate a minting rate of CCD. The value is Mantissa * 10^(-Exponent).
+type MintRate struct {
+	Mantissa uint32
+	Exponent uint32
+}
+
+// MintDistributionCpv0 the distribution of newly minted CCD in ChainParametersV0.
+type MintDistributionCpv0 struct {
+	// Mint rate per slot.
+	MintPerSlot MintRate
+	// The fraction of newly created CCD allocated to baker rewards.
+	BakingReward AmountFraction
+	// The fraction of newly created CCD allocated to finalization rewards.
+	FinalizationReward AmountFraction
+}
+
+// MintDistributionCpv1 the distribution of newly minted CCD in ChainParametersV1 onwards.
+type MintDistributionCpv1 struct {
+	// The fraction of newly created CCD allocated to baker rewards.
+	BakingReward AmountFraction
+	// The fraction of newly created CCD allocated to finalization rewards.
+	FinalizationReward AmountFraction
+}
+
+// TransactionFeeDistribution the distribution of transaction fees.
+type TransactionFeeDistribution struct {
+	// The fraction allocated to the baker.
+	Baker AmountFraction
+	// The fraction allocated to the GAS account.
+	GasAccount AmountFraction
+}
+
+// GasRewards the distribution of the GAS account in ChainParametersV0 and ChainParametersV1.
+type GasRewards struct {
+	// The fraction paid to the baker.
+	Baker AmountFraction
+	// Fraction paid for including a finalization proof in a block.
+	FinalizationProof AmountFraction
+	// Fraction paid for including each account creation transaction in a block.
+	AccountCreation AmountFraction
+	// Fraction paid for including an update transaction in a block.
+	ChainUpdate AmountFraction
+}
+
+// GasRewardsCpv2 the distribution of the GAS account in ChainParametersV2 onwards.
+type GasRewardsCpv2 struct {
+	// The fraction paid to the baker.
+	Baker AmountFraction
+	// Fraction paid for including each account creation transaction in a block.
+	AccountCreation AmountFraction
+	// Fraction paid for including an update transaction in a block.
+	ChainUpdate AmountFraction
+}
+
+// TimeParameters parameters of reward periods.
+type TimeParameters struct {
+	// Length of a reward period in epochs.
+	RewardPeriodLength Epoch
+	// Mint rate per payday.
+	MintPerPayday MintRate
+}
+
+// InclusiveRangeAmountFraction an inclusive range of amount fractions.
+type InclusiveRangeAmountFraction struct {
+	Min AmountFraction
+	Max AmountFraction
+}
+
+// CommissionRanges the ranges of allowed commission rates of pools.
+type CommissionRanges struct {
+	Finalization InclusiveRangeAmountFraction
+	Baking       InclusiveRangeAmountFraction
+	Transaction  InclusiveRangeAmountFraction
+}
+
+// PoolParameters parameters of staking pools.
+type PoolParameters struct {
+	// Fraction of finalization rewards charged by the passive delegation.
+	PassiveFinalizationCommission AmountFraction
+	// Fraction of baking rewards charged by the passive delegation.
+	PassiveBakingCommission AmountFraction
+	// Fraction of transaction rewards charged by the passive delegation.
+	PassiveTransactionCommission AmountFraction
+	// The ranges of allowed commission rates.
+	CommissionBounds CommissionRanges
+	// Minimum equity capital required for a new baker.
+	MinimumEquityCapital Amount
+	// Maximum fraction of the total staked capital that a new baker can have.
+	CapitalBound AmountFraction
+	// The maximum leverage that a baker can have as a ratio of total stake to equity capital.
+	LeverageBound *big.Rat
+}
+
+// ConsensusParameters parameters of the consensus protocol from ChainParametersV2 onwards.
+type ConsensusParameters struct {
+	// The base value for triggering a timeout.
+	TimeoutBase time.Duration
+	// Factor for increasing the timeout.
+	TimeoutIncrease *big.Rat
+	// Factor for decreasing the timeout.
+	TimeoutDecrease *big.Rat
+	// Minimum time interval between blocks.
+	MinBlockTime time.Duration
+	// Maximum energy allowed per block.
+	BlockEnergyLimit Energy
+}
+
+// FinalizationCommitteeParameters parameters of the finalization committee.
+type FinalizationCommitteeParameters struct {
+	// The minimum size of a finalization committee.
+	MinimumFinalizers uint32
+	// The maximum size of a finalization committee.
+	MaximumFinalizers uint32
+	// The threshold for determining the stake required for being eligible for the finalization committee.
+	FinalizerRelativeStakeThreshold AmountFraction
+}
+
+// ValidatorScoreParameters parameters for suspending validators.
+type ValidatorScoreParameters struct {
+	// The maximal number of missed rounds before a validator gets suspended.
+	MaximumMissedRounds uint64
+}
+
+// ChainParametersV0 chain parameters for protocol versions 1 to 3.
+type ChainParametersV0 struct {
+	// Election difficulty for consensus lottery.
+	ElectionDifficulty AmountFraction
+	// Exchange rates.
+	ExchangeRates ExchangeRates
+	// Number of epochs a baker must cool down when removing its stake.
+	BakerCooldownEpochs Epoch
+	// The limit for the number of account creations in a block.
+	AccountCreationLimit uint32
+	// Current mint distribution.
+	MintDistribution MintDistributionCpv0
+	// Current transaction fee distribution.
+	TransactionFeeDistribution TransactionFeeDistribution
+	// Current gas reward distribution.
+	GasRewards GasRewards
+	// The foundation account.
+	Foundation AccountAddress
+	// Minimum threshold for becoming a baker.
+	MinimumThresholdForBaking Amount
+	// Keys allowed to do root updates, as returned by the node.
+	RootKeys *pb.HigherLevelKeys
+	// Keys allowed to do level1 updates, as returned by the node.
+	Level1Keys *pb.HigherLevelKeys
+	// Keys allowed to do parameter updates, as returned by the node.
+	Level2Keys *pb.AuthorizationsV0
+}
+
+// ChainParametersV1 chain parameters for protocol versions 4 and 5.
+type ChainParametersV1 struct {
+	// Election difficulty for consensus lottery.
+	ElectionDifficulty AmountFraction
+	// Exchange rates.
+	ExchangeRates ExchangeRates
+	// Cooldown periods.
+	Cooldown CooldownParameters
+	// Parameters of reward periods.
+	TimeParameters TimeParameters
+	// The limit for the number of account creations in a block.
+	AccountCreationLimit uint32
+	// Current mint distribution.
+	MintDistribution MintDistributionCpv1
+	// Current transaction fee distribution.
+	TransactionFeeDistribution TransactionFeeDistribution
+	// Current gas reward distribution.
+	GasRewards GasRewards
+	// The foundation account.
+	Foundation AccountAddress
+	// Parameters of staking pools.
+	PoolParameters PoolParameters
+	// Keys allowed to do root updates, as returned by the node.
+	RootKeys *pb.HigherLevelKeys
+	// Keys allowed to do level1 updates, as returned by the node.
+	Level1Keys *pb.HigherLevelKeys
+	// Keys allowed to do parameter updates, as returned by the node.
+	Level2Keys *pb.AuthorizationsV1
+}
+
+// ChainParametersV2 chain parameters for protocol versions 6 and 7.
+type ChainParametersV2 struct {
+	// Consensus parameters.
+	ConsensusParameters ConsensusParameters
+	// Exchange rates.
+	ExchangeRates ExchangeRates
+	// Cooldown periods.
+	Cooldown CooldownParameters
+	// Parameters of reward periods.
+	TimeParameters TimeParameters
+	// The limit for the number of account creations in a block.
+	AccountCreationLimit uint32
+	// Current mint distribution.
+	MintDistribution MintDistributionCpv1
+	// Current transaction fee distribution.
+	TransactionFeeDistribution TransactionFeeDistribution
+	// Current gas reward distribution.
+	GasRewards GasRewardsCpv2
+	// The foundation account.
+	Foundation AccountAddress
+	// Parameters of staking pools.
+	PoolParameters PoolParameters
+	// Keys allowed to do root updates, as returned by the node.
+	RootKeys *pb.HigherLevelKeys
+	// Keys allowed to do level1 updates, as returned by the node.
+	Level1Keys *pb.HigherLevelKeys
+	// Keys allowed to do parameter updates, as returned by the node.
+	Level2Keys *pb.AuthorizationsV1
+	// Finalization committee parameters.
+	FinalizationCommitteeParameters FinalizationCommitteeParameters
+}
+
+// ChainParametersV3 chain parameters from protocol version 8.
+type ChainParametersV3 struct {
+	// Consensus parameters.
+	ConsensusParameters ConsensusParameters
+	// Exchange rates.
+	ExchangeRates ExchangeRates
+	// Cooldown periods.
+	Cooldown CooldownParameters
+	// Parameters of reward periods.
+	TimeParameters TimeParameters
+	// The limit for the number of account creations in a block.
+	AccountCreationLimit uint32
+	// Current mint distribution.
+	MintDistribution MintDistributionCpv1
+	// Current transaction fee distribution.
+	TransactionFeeDistribution TransactionFeeDistribution
+	// Current gas reward distribution.
+	GasRewards GasRewardsCpv2
+	// The foundation account.
+	Foundation AccountAddress
+	// Parameters of staking pools.
+	PoolParameters PoolParameters
+	// Keys allowed to do root updates, as returned by the node.
+	RootKeys *pb.HigherLevelKeys
+	// Keys allowed to do level1 updates, as returned by the node.
+	Level1Keys *pb.HigherLevelKeys
+	// Keys allowed to do parameter updates, as returned by the node.
+	Level2Keys *pb.AuthorizationsV1
+	// Finalization committee parameters.
+	FinalizationCommitteeParameters FinalizationCommitteeParameters
+	// Parameters for suspending validators.
+	ValidatorScoreParameters ValidatorScoreParameters
+}
+
+func (ChainParametersV0) isChainParameters() {}
+func (ChainParametersV1) isChainParameters() {}
+func (ChainParametersV2) isChainParameters() {}
+func (ChainParametersV3) isChainParameters() {}
+
+func (p ChainParametersV0) EuroPerEnergy() *big.Rat { return p.ExchangeRates.EuroPerEnergy }
+func (p ChainParametersV1) EuroPerEnergy() *big.Rat { return p.ExchangeRates.EuroPerEnergy }
+func (p ChainParametersV2) EuroPerEnergy() *big.Rat { return p.ExchangeRates.EuroPerEnergy }
+func (p ChainParametersV3) EuroPerEnergy() *big.Rat { return p.ExchangeRates.EuroPerEnergy }
+
+func (p ChainParametersV0) MicroCcdPerEuro() *big.Rat { return p.ExchangeRates.MicroCcdPerEuro }
+func (p ChainParametersV1) MicroCcdPerEuro() *big.Rat { return p.ExchangeRates.MicroCcdPerEuro }
+func (p ChainParametersV2) MicroCcdPerEuro() *big.Rat { return p.ExchangeRates.MicroCcdPerEuro }
+func (p ChainParametersV3) MicroCcdPerEuro() *big.Rat { return p.ExchangeRates.MicroCcdPerEuro }
+
+func (p ChainParametersV0) FoundationAccount() AccountAddress { return p.Foundation }
+func (p ChainParametersV1) FoundationAccount() AccountAddress { return p.Foundation }
+func (p ChainParametersV2) FoundationAccount() AccountAddress { return p.Foundation }
+func (p ChainParametersV3) FoundationAccount() AccountAddress { return p.Foundation }
+
+func (p ChainParametersV0) MinimumEquityCapital() Amount { return p.MinimumThresholdForBaking }
+func (p ChainParametersV1) MinimumEquityCapital() Amount {
+	return p.PoolParameters.MinimumEquityCapital
+}
+func (p ChainParametersV2) MinimumEquityCapital() Amount {
+	return p.PoolParameters.MinimumEquityCapital
+}
+func (p ChainParametersV3) MinimumEquityCapital() Amount {
+	return p.PoolParameters.MinimumEquityCapital
+}
+
+func (p ChainParametersV0) CooldownParameters() CooldownParameters {
+	return CooldownParameters{BakerCooldownEpochs: p.BakerCooldownEpochs}
+}
+func (p ChainParametersV1) CooldownParameters() CooldownParameters { return p.Cooldown }
+func (p ChainParametersV2) CooldownParameters() CooldownParameters { return p.Cooldown }
+func (p ChainParametersV3) CooldownParameters() CooldownParameters { return p.Cooldown }
+
+// Parses *pb.ChainParameters to ChainParameters.
+func parseChainParameters(p *pb.ChainParameters) (ChainParameters, error) {
+	var res ChainParameters
+	var err error
+	switch v := p.GetParameters().(type) {
+	case *pb.ChainParameters_V0:
+		res, err = parseChainParametersV0(v.V0)
+	case *pb.ChainParameters_V1:
+		res, err = parseChainParametersV1(v.V1)
+	case *pb.ChainParameters_V2:
+		res, err = parseChainParametersV2(v.V2)
+	case *pb.ChainParameters_V3:
+		res, err = parseChainParametersV3(v.V3)
+	default:
+		return nil, errors.New("Error parsing ChainParameters: " + ErrUnknownVariant.Error())
+	}
+	if err != nil {
+		return nil, errors.New("Error parsing ChainParameters: " + err.Error())
+	}
+	return res, nil
+}
+
+// fractionParser parses amount fractions and keeps the first error, so that many fractions can be parsed in a row.
+type fractionParser struct {
+	err error
+}
+
+func (f *fractionParser) parse(a *pb.AmountFraction) AmountFraction {
+	res, err := parseAmountFraction(a)
+	if err != nil && f.err == nil {
+		f.err = err
+	}
+	return res
+}
+
+func (f *fractionParser) inclusiveRange(r *pb.InclusiveRangeAmountFraction) InclusiveRangeAmountFraction {
+	return InclusiveRangeAmountFraction{Min: f.parse(r.GetMin()), Max: f.parse(r.GetMax_())}
+}
+
+// Parses *pb.ChainParametersV0 to ChainParametersV0.
+func parseChainParametersV0(p *pb.ChainParametersV0) (ChainParametersV0, error) {
+	exchangeRates, err := parseExchangeRates(p.GetEuroPerEnergy(), p.GetMicroCcdPerEuro())
+	if err != nil {
+		return ChainParametersV0{}, err
+	}
+	f := &fractionParser{}
+	res := ChainParametersV0{
+		ElectionDifficulty:   f.parse(p.GetElectionDifficulty().GetValue()),
+		ExchangeRates:        exchangeRates,
+		BakerCooldownEpochs:  Epoch{Value: p.GetBakerCooldownEpochs().GetValue()},
+		AccountCreationLimit: p.GetAccountCreationLimit().GetValue(),
+		MintDistribution: MintDistributionCpv0{
+			MintPerSlot:        parseMintRate(p.GetMintDistribution().GetMintPerSlot()),
+			BakingReward:       f.parse(p.GetMintDistribution().GetBakingReward()),
+			FinalizationReward: f.parse(p.GetMintDistribution().GetFinalizationReward()),
+		},
+		TransactionFeeDistribution: parseTransactionFeeDistribution(f, p.GetTransactionFeeDistribution()),
+		GasRewards:                 parseGasRewards(f, p.GetGasRewards()),
+		Foundation:                 parseAccountAddress(p.GetFoundationAccount()),
+		MinimumThresholdForBaking:  Amount{Value: p.GetMinimumThresholdForBaking().GetValue()},
+		RootKeys:                   p.GetRootKeys(),
+		Level1Keys:                 p.GetLevel1Keys(),
+		Level2Keys:                 p.GetLevel2Keys(),
+	}
+	if f.err != nil {
+		return ChainParametersV0{}, f.err
+	}
+	return res, nil
+}
+
+// Parses *pb.ChainParametersV1 to ChainParametersV1.
+func parseChainParametersV1(p *pb.ChainParametersV1) (ChainParametersV1, error) {
+	exchangeRates, err := parseExchangeRates(p.GetEuroPerEnergy(), p.GetMicroCcdPerEuro())
+	if err != nil {
+		return ChainParametersV1{}, err
+	}
+	poolParameters, err := parsePoolParameters(p.GetPoolParameters())
+	if err != nil {
+		return ChainParametersV1{}, err
+	}
+	f := &fractionParser{}
+	res := ChainParametersV1{
+		ElectionDifficulty:         f.parse(p.GetElectionDifficulty().GetValue()),
+		ExchangeRates:              exchangeRates,
+		Cooldown:                   parseCooldownParameters(p.GetCooldownParameters()),
+		TimeParameters:             parseTimeParameters(p.GetTimeParameters()),
+		AccountCreationLimit:       p.GetAccountCreationLimit().GetValue(),
+		MintDistribution:           parseMintDistributionCpv1(f, p.GetMintDistribution()),
+		TransactionFeeDistribution: parseTransactionFeeDistribution(f, p.GetTransactionFeeDistribution()),
+		GasRewards:                 parseGasRewards(f, p.GetGasRewards()),
+		Foundation:                 parseAccountAddress(p.GetFoundationAccount()),
+		PoolParameters:             poolParameters,
+		RootKeys:                   p.GetRootKeys(),
+		Level1Keys:                 p.GetLevel1Keys(),
+		Level2Keys:                 p.GetLevel2Keys(),
+	}
+	if f.err != nil {
+		return ChainParametersV1{}, f.err
+	}
+	return res, nil
+}
+
+// Parses *pb.ChainParametersV2 to ChainParametersV2.
+func parseChainParametersV2(p *pb.ChainParametersV2) (ChainParametersV2, error) {
+	exchangeRates, err := parseExchangeRates(p.GetEuroPerEnergy(), p.GetMicroCcdPerEuro())
+	if err != nil {
+		return ChainParametersV2{}, err
+	}
+	poolParameters, err := parsePoolParameters(p.GetPoolParameters())
+	if err != nil {
+		return ChainParametersV2{}, err
+	}
+	consensusParameters, err := parseConsensusParameters(p.GetConsensusParameters())
+	if err != nil {
+		return ChainParametersV2{}, err
+	}
+	f := &fractionParser{}
+	res := ChainParametersV2{
+		ConsensusParameters:             consensusParameters,
+		ExchangeRates:                   exchangeRates,
+		Cooldown:                        parseCooldownParameters(p.GetCooldownParameters()),
+		TimeParameters:                  parseTimeParameters(p.GetTimeParameters()),
+		AccountCreationLimit:            p.GetAccountCreationLimit().GetValue(),
+		MintDistribution:                parseMintDistributionCpv1(f, p.GetMintDistribution()),
+		TransactionFeeDistribution:      parseTransactionFeeDistribution(f, p.GetTransactionFeeDistribution()),
+		GasRewards:                      parseGasRewardsCpv2(f, p.GetGasRewards()),
+		Foundation:                      parseAccountAddress(p.GetFoundationAccount()),
+		PoolParameters:                  poolParameters,
+		RootKeys:                        p.GetRootKeys(),
+		Level1Keys:                      p.GetLevel1Keys(),
+		Level2Keys:                      p.GetLevel2Keys(),
+		FinalizationCommitteeParameters: parseFinalizationCommitteeParameters(f, p.GetFinalizationCommitteeParameters()),
+	}
+	if f.err != nil {
+		return ChainParametersV2{}, f.err
+	}
+	return res, nil
+}
+
+// Parses *pb.ChainParametersV3 to ChainParametersV3.
+func parseChainParametersV3(p *pb.ChainParametersV3) (ChainParametersV3, error) {
+	exchangeRates, err := parseExchangeRates(p.GetEuroPerEnergy(), p.GetMicroCcdPerEuro())
+	if err != nil {
+		return ChainParametersV3{}, err
+	}
+	poolParameters, err := parsePoolParameters(p.GetPoolParameters())
+	if err != nil {
+		return ChainParametersV3{}, err
+	}
+	consensusParameters, err := parseConsensusParameters(p.GetConsensusParameters())
+	if err != nil {
+		return ChainParametersV3{}, err
+	}
+	f := &fractionParser{}
+	res := ChainParametersV3{
+		ConsensusParameters:             consensusParameters,
+		ExchangeRates:                   exchangeRates,
+		Cooldown:                        parseCooldownParameters(p.GetCooldownParameters()),
+		TimeParameters:                  parseTimeParameters(p.GetTimeParameters()),
+		AccountCreationLimit:            p.GetAccountCreationLimit().GetValue(),
+		MintDistribution:                parseMintDistributionCpv1(f, p.GetMintDistribution()),
+		TransactionFeeDistribution:      parseTransactionFeeDistribution(f, p.GetTransactionFeeDistribution()),
+		GasRewards:                      parseGasRewardsCpv2(f, p.GetGasRewards()),
+		Foundation:                      parseAccountAddress(p.GetFoundationAccount()),
+		PoolParameters:                  poolParameters,
+		RootKeys:                        p.GetRootKeys(),
+		Level1Keys:                      p.GetLevel1Keys(),
+		Level2Keys:                      p.GetLevel2Keys(),
+		FinalizationCommitteeParameters: parseFinalizationCommitteeParameters(f, p.GetFinalizationCommitteeParameters()),
+		ValidatorScoreParameters: ValidatorScoreParameters{
+			MaximumMissedRounds: p.GetValidatorScoreParameters().GetMaximumMissedRounds(),
+		},
+	}
+	if f.err != nil {
+		return ChainParametersV3{}, f.err
+	}
+	return res, nil
+}
+
+// Parses the exchange rates of chain parameters to ExchangeRates.
+func parseExchangeRates(euroPerEnergy, microCcdPerEuro *pb.ExchangeRate) (ExchangeRates, error) {
+	euroPerEnergyRat, err := parseRatio(euroPerEnergy.GetValue())
+	if err != nil {
+		return ExchangeRates{}, errors.New("Error parsing EuroPerEnergy: " + err.Error())
+	}
+	microCcdPerEuroRat, err := parseRatio(microCcdPerEuro.GetValue())
+	if err != nil {
+		return ExchangeRates{}, errors.New("Error parsing MicroCcdPerEuro: " + err.Error())
+	}
+	return ExchangeRates{EuroPerEnergy: euroPerEnergyRat, MicroCcdPerEuro: microCcdPerEuroRat}, nil
+}
+
+// Parses *pb.Ratio to *big.Rat.
+func parseRatio(r *pb.Ratio) (*big.Rat, error) {
+	if r.GetDenominator() == 0 {
+		return nil, errors.New("denominator is zero")
+	}
+	return new(big.Rat).SetFrac(
+		new(big.Int).SetUint64(r.GetNumerator()),
+		new(big.Int).SetUint64(r.GetDenominator()),
+	), nil
+}
+
+// Parses *pb.MintRate to MintRate.
+func parseMintRate(r *pb.MintRate) MintRate {
+	return MintRate{Mantissa: r.GetMantissa(), Exponent: r.GetExponent()}
+}
+
+// Parses *pb.CooldownParametersCpv1 to CooldownParameters.
+func parseCooldownParameters(c *pb.CooldownParametersCpv1) CooldownParameters {
+	return CooldownParameters{
+		PoolOwnerCooldown: time.Duration(c.GetPoolOwnerCooldown().GetValue()) * time.Second,
+		DelegatorCooldown: time.Duration(c.GetDelegatorCooldown().GetValue()) * time.Second,
+	}
+}
+
+// Parses *pb.TimeParametersCpv1 to TimeParameters.
+func parseTimeParameters(t *pb.TimeParametersCpv1) TimeParameters {
+	return TimeParameters{
+		RewardPeriodLength: Epoch{Value: t.GetRewardPeriodLength().GetValue().GetValue()},
+		MintPerPayday:      parseMintRate(t.GetMintPerPayday()),
+	}
+}
+
+// Parses *pb.MintDistributionCpv1 to MintDistributionCpv1.
+func parseMintDistributionCpv1(f *fractionParser, m *pb.MintDistributionCpv1) MintDistributionCpv1 {
+	return MintDistributionCpv1{
+		BakingReward:       f.parse(m.GetBakingReward()),
+		FinalizationReward: f.parse(m.GetFinalizationReward()),
+	}
+}
+
+// Parses *pb.TransactionFeeDistribution to TransactionFeeDistribution.
+func parseTransactionFeeDistribution(f *fractionParser, t *pb.TransactionFeeDistribution) TransactionFeeDistribution {
+	return TransactionFeeDistribution{
+		Baker:      f.parse(t.GetBaker()),
+		GasAccount: f.parse(t.GetGasAccount()),
+	}
+}
+
+// Parses *pb.GasRewards to GasRewards.
+func parseGasRewards(f *fractionParser, g *pb.GasRewards) GasRewards {
+	return GasRewards{
+		Baker:             f.parse(g.GetBaker()),
+		FinalizationProof: f.parse(g.GetFinalizationProof()),
+		AccountCreation:   f.parse(g.GetAccountCreation()),
+		ChainUpdate:       f.parse(g.GetChainUpdate()),
+	}
+}
+
+// Parses *pb.GasRewardsCpv2 to GasRewardsCpv2.
+func parseGasRewardsCpv2(f *fractionParser, g *pb.GasRewardsCpv2) GasRewardsCpv2 {
+	return GasRewardsCpv2{
+		Baker:           f.parse(g.GetBaker()),
+		AccountCreation: f.parse(g.GetAccountCreation()),
+		ChainUpdate:     f.parse(g.GetChainUpdate()),
+	}
+}
+
+// Parses *pb.PoolParametersCpv1 to PoolParameters.
+func parsePoolParameters(p *pb.PoolParametersCpv1) (PoolParameters, error) {
+	leverageBound, err := parseRatio(p.GetLeverageBound().GetValue())
+	if err != nil {
+		return PoolParameters{}, errors.New("Error parsing PoolParameters: " + err.Error())
+	}
+	f := &fractionParser{}
+	res := PoolParameters{
+		PassiveFinalizationCommission: f.parse(p.GetPassiveFinalizationCommission()),
+		PassiveBakingCommission:       f.parse(p.GetPassiveBakingCommission()),
+		PassiveTransactionCommission:  f.parse(p.GetPassiveTransactionCommission()),
+		CommissionBounds: CommissionRanges{
+			Finalization: f.inclusiveRange(p.GetCommissionBounds().GetFinalization()),
+			Baking:       f.inclusiveRange(p.GetCommissionBounds().GetBaking()),
+			Transaction:  f.inclusiveRange(p.GetCommissionBounds().GetTransaction()),
+		},
+		MinimumEquityCapital: Amount{Value: p.GetMinimumEquityCapital().GetValue()},
+		CapitalBound:         f.parse(p.GetCapitalBound().GetValue()),
+		LeverageBound:        leverageBound,
+	}
+	if f.err != nil {
+		return PoolParameters{}, errors.New("Error parsing PoolParameters: " + f.err.Error())
+	}
+	return res, nil
+}
+
+// Parses *pb.ConsensusParametersV1 to ConsensusParameters.
+func parseConsensusParameters(c *pb.ConsensusParametersV1) (ConsensusParameters, error) {
+	timeoutIncrease, err := parseRatio(c.GetTimeoutParameters().GetTimeoutIncrease())
+	if err != nil {
+		return ConsensusParameters{}, errors.New("Error parsing ConsensusParameters: " + err.Error())
+	}
+	timeoutDecrease, err := parseRatio(c.GetTimeoutParameters().GetTimeoutDecrease())
+	if err != nil {
+		return ConsensusParameters{}, errors.New("Error parsing ConsensusParameters: " + err.Error())
+	}
+	return ConsensusParameters{
+		TimeoutBase:      time.Duration(c.GetTimeoutParameters().GetTimeoutBase().GetValue()) * time.Millisecond,
+		TimeoutIncrease:  timeoutIncrease,
+		TimeoutDecrease:  timeoutDecrease,
+		MinBlockTime:     time.Duration(c.GetMinBlockTime().GetValue()) * time.Millisecond,
+		BlockEnergyLimit: Energy{Value: c.GetBlockEnergyLimit().GetValue()},
+	}, nil
+}
+
+// Parses *pb.FinalizationCommitteeParameters to FinalizationCommitteeParameters.
+func parseFinalizationCommitteeParameters(f *fractionParser, p *pb.FinalizationCommitteeParameters) FinalizationCommitteeParameters {
+	return FinalizationCommitteeParameters{
+		MinimumFinalizers:               p.GetMinimumFinalizers(),
+		MaximumFinalizers:               p.GetMaximumFinalizers(),
+		FinalizerRelativeStakeThreshold: f.parse(p.GetFinalizerRelativeStakeThreshold()),
+	}
+}
@@ -0,0 +1,179 @@
+// Package cis0 implements the CIS-0 standard, which allows querying which other standards a smart contract supports.
+//
+// See https://proposals.concordium.software/CIS/cis-0.html for the specification.
+package cis0
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+var (
+	// ErrInvalidStandardIdentifier indicates that a standard identifier is too long to be serialized.
+	ErrInvalidStandardIdentifier = errors.New("standard identifier must be at most 255 bytes")
+	// ErrTooManyQueries indicates that too many standards are queried at once.
+	ErrTooManyQueries = errors.New("too many queries")
+	// ErrInvalidResponse indicates that the contract returned a value that is not a valid CIS-0 response.
+	ErrInvalidResponse = errors.New("invalid supports response")
+)
+
+// MaxInvokeEnergy is the energy that view functions of CIS standards are invoked with.
+var MaxInvokeEnergy = v2.Energy{Value: 1_000_000}
+
+// StandardIdentifier identifies a standard, e.g. "CIS-2".
+type StandardIdentifier string
+
+const (
+	// CIS0 the identifier of the CIS-0 standard.
+	CIS0 StandardIdentifier = "CIS-0"
+	// CIS1 the identifier of the CIS-1 standard.
+	CIS1 StandardIdentifier = "CIS-1"
+	// CIS2 the identifier of the CIS-2 standard.
+	CIS2 StandardIdentifier = "CIS-2"
+	// CIS3 the identifier of the CIS-3 standard.
+	CIS3 StandardIdentifier = "CIS-3"
+)
+
+// SupportResult whether a standard is supported by a contract. Result is one of SupportResultNoSupport,
+// SupportResultSupport or SupportResultSupportBy.
+type SupportResult struct {
+	Result isSupportResult
+}
+
+type isSupportResult interface {
+	isSupportResult()
+}
+
+// SupportResultNoSupport the standard is not supported.
+type SupportResultNoSupport struct{}
+
+func (SupportResultNoSupport) isSupportResult() {}
+
+// SupportResultSupport the standard is supported by the contract itself.
+type SupportResultSupport struct{}
+
+func (SupportResultSupport) isSupportResult() {}
+
+// SupportResultSupportBy the standard is supported by delegating to one of the given contracts.
+type SupportResultSupportBy struct {
+	Contracts []v2.ContractAddress
+}
+
+func (SupportResultSupportBy) isSupportResult() {}
+
+// IsSupported returns whether the standard is supported, either directly or by another contract.
+func (s SupportResult) IsSupported() bool {
+	switch s.Result.(type) {
+	case SupportResultSupport, SupportResultSupportBy:
+		return true
+	}
+	return false
+}
+
+// Supports invokes the "supports" entrypoint of the contract with the given name at the given address and returns
+// whether it supports each of the given standards, in the same order.
+func Supports(
+	ctx context.Context,
+	client *v2.Client,
+	block v2.BlockHashInput,
+	address v2.ContractAddress,
+	contractName string,
+	identifiers ...StandardIdentifier,
+) ([]SupportResult, error) {
+	parameter, err := SerializeSupportsParameter(identifiers)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.InvokeInstance(ctx, v2.UpdateContractPayload{
+		Amount:      &v2.Amount{},
+		Address:     &address,
+		ReceiveName: &v2.ReceiveName{Value: contractName + ".supports"},
+		Parameter:   &v2.Parameter{Value: parameter},
+	}, block, MaxInvokeEnergy, nil)
+	if err != nil {
+		return nil, err
+	}
+	success, err := v2.ParseInvokeInstanceResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := DeserializeSupportsResponse(success.ReturnValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(identifiers) {
+		return nil, fmt.Errorf("%w: expected %d results, got %d", ErrInvalidResponse, len(identifiers), len(results))
+	}
+	return results, nil
+}
+
+// SerializeSupportsParameter serializes the parameter of the "supports" entrypoint.
+func SerializeSupportsParameter(identifiers []StandardIdentifier) ([]byte, error) {
+	if len(identifiers) > math.MaxUint16 {
+		return nil, ErrTooManyQueries
+	}
+	buf := binary.LittleEndian.AppendUint16(nil, uint16(len(identifiers)))
+	for _, id := range identifiers {
+		if len(id) > math.MaxUint8 {
+			return nil, ErrInvalidStandardIdentifier
+		}
+		buf = append(buf, uint8(len(id)))
+		buf = append(buf, id...)
+	}
+	return buf, nil
+}
+
+// DeserializeSupportsResponse deserializes the return value of the "supports" entrypoint.
+func DeserializeSupportsResponse(data []byte) ([]SupportResult, error) {
+	if len(data) < 2 {
+		return nil, ErrInvalidResponse
+	}
+	n := int(binary.LittleEndian.Uint16(data))
+	data = data[2:]
+
+	results := make([]SupportResult, 0, n)
+	for i := 0; i < n; i++ {
+		if len(data) < 1 {
+			return nil, ErrInvalidResponse
+		}
+		tag := data[0]
+		data = data[1:]
+		switch tag {
+		case 0:
+			results = append(results, SupportResult{Result: SupportResultNoSupport{}})
+		case 1:
+			results = append(results, SupportResult{Result: SupportResultSupport{}})
+		case 2:
+			if len(data) < 1 {
+				return nil, ErrInvalidResponse
+			}
+			m := int(data[0])
+			data = data[1:]
+			if len(data) < 16*m {
+				return nil, ErrInvalidResponse
+			}
+			contracts := make([]v2.ContractAddress, m)
+			for j := range contracts {
+				contracts[j] = v2.ContractAddress{
+					Index:    binary.LittleEndian.Uint64(data),
+					Subindex: binary.LittleEndian.Uint64(data[8:]),
+				}
+				data = data[16:]
+			}
+			results = append(results, SupportResult{Result: SupportResultSupportBy{Contracts: contracts}})
+		default:
+			return nil, ErrInvalidResponse
+		}
+	}
+	if len(data) != 0 {
+		return nil, ErrInvalidResponse
+	}
+	return results, nil
+}
@@ -0,0 +1,212 @@
+// Package cis2 implements a client for smart contracts following the CIS-2 token standard. It supports querying
+// balances, operators and token metadata, building transactions for transfers and operator updates and
+// parsing the events logged by CIS-2 contracts.
+//
+// See https://proposals.concordium.software/CIS/cis-2.html for the specification.
+package cis2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/cis0"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+var (
+	// ErrInvalidTokenId indicates that the contract rejected the call because a token id is unknown.
+	ErrInvalidTokenId = errors.New("invalid token id")
+	// ErrInsufficientFunds indicates that the contract rejected the call because the balance is insufficient.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrUnauthorized indicates that the contract rejected the call because the sender is not authorized.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Error codes defined by CIS-2 that contracts reject calls with.
+const (
+	errorCodeInvalidTokenId    int32 = -42000001
+	errorCodeInsufficientFunds int32 = -42000002
+	errorCodeUnauthorized      int32 = -42000003
+)
+
+// Cis2Contract a smart contract instance following the CIS-2 standard.
+type Cis2Contract struct {
+	client *v2.Client
+	// Address of the contract instance.
+	Address v2.ContractAddress
+	// Name of the contract, without the "init_" prefix.
+	ContractName string
+}
+
+// New creates a Cis2Contract for the contract with the given name at the given address.
+func New(client *v2.Client, address v2.ContractAddress, contractName string) *Cis2Contract {
+	return &Cis2Contract{
+		client:       client,
+		Address:      address,
+		ContractName: contractName,
+	}
+}
+
+// Create creates a Cis2Contract for the contract instance at the given address. The name of the contract is
+// looked up in the last finalized block.
+func Create(ctx context.Context, client *v2.Client, address v2.ContractAddress) (*Cis2Contract, error) {
+	info, err := client.GetInstanceInfo(ctx, v2.BlockHashInputLastFinal{}, address)
+	if err != nil {
+		return nil, err
+	}
+	var initName string
+	switch {
+	case info.GetV0() != nil:
+		initName = info.GetV0().GetName().GetValue()
+	case info.GetV1() != nil:
+		initName = info.GetV1().GetName().GetValue()
+	default:
+		return nil, errors.New("Error parsing InstanceInfo: " + v2.ErrUnknownVariant.Error())
+	}
+	return New(client, address, strings.TrimPrefix(initName, "init_")), nil
+}
+
+// BalanceOf queries the balances of the given tokens and addresses in the given block.
+func (c *Cis2Contract) BalanceOf(ctx context.Context, block v2.BlockHashInput, queries []BalanceOfQuery) ([]TokenAmount, error) {
+	parameter, err := SerializeBalanceOfParams(queries)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.invoke(ctx, block, "balanceOf", parameter)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := DeserializeBalanceOfResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	if len(amounts) != len(queries) {
+		return nil, fmt.Errorf("%w: expected %d results, got %d", ErrInvalidData, len(queries), len(amounts))
+	}
+	return amounts, nil
+}
+
+// OperatorOf queries whether addresses are operators of owners in the given block.
+func (c *Cis2Contract) OperatorOf(ctx context.Context, block v2.BlockHashInput, queries []OperatorOfQuery) ([]bool, error) {
+	parameter, err := SerializeOperatorOfParams(queries)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.invoke(ctx, block, "operatorOf", parameter)
+	if err != nil {
+		return nil, err
+	}
+	operators, err := DeserializeOperatorOfResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	if len(operators) != len(queries) {
+		return nil, fmt.Errorf("%w: expected %d results, got %d", ErrInvalidData, len(queries), len(operators))
+	}
+	return operators, nil
+}
+
+// TokenMetadata queries the metadata URLs of the given tokens in the given block.
+func (c *Cis2Contract) TokenMetadata(ctx context.Context, block v2.BlockHashInput, tokenIds []TokenId) ([]MetadataUrl, error) {
+	parameter, err := SerializeTokenMetadataParams(tokenIds)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.invoke(ctx, block, "tokenMetadata", parameter)
+	if err != nil {
+		return nil, err
+	}
+	urls, err := DeserializeTokenMetadataResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) != len(tokenIds) {
+		return nil, fmt.Errorf("%w: expected %d results, got %d", ErrInvalidData, len(tokenIds), len(urls))
+	}
+	return urls, nil
+}
+
+// Supports queries whether the contract supports the given standards in the given block, as defined by CIS-0.
+func (c *Cis2Contract) Supports(ctx context.Context, block v2.BlockHashInput, identifiers ...cis0.StandardIdentifier) ([]cis0.SupportResult, error) {
+	return cis0.Supports(ctx, c.client, block, c.Address, c.ContractName, identifiers...)
+}
+
+// Transfer constructs a transaction transferring tokens. The sender must be the owner of the tokens or an operator of it.
+func (c *Cis2Contract) Transfer(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	energy v2.Energy, transfers []Transfer) (*v2.PreAccountTransaction, error) {
+	parameter, err := SerializeTransferParams(transfers)
+	if err != nil {
+		return nil, err
+	}
+	return c.Update(numSigs, sender, nonce, expiry, energy, "transfer", v2.Parameter{Value: parameter}), nil
+}
+
+// UpdateOperator constructs a transaction adding or removing operators of the sender.
+func (c *Cis2Contract) UpdateOperator(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	energy v2.Energy, updates []UpdateOperator) (*v2.PreAccountTransaction, error) {
+	parameter, err := SerializeUpdateOperatorParams(updates)
+	if err != nil {
+		return nil, err
+	}
+	return c.Update(numSigs, sender, nonce, expiry, energy, "updateOperator", v2.Parameter{Value: parameter}), nil
+}
+
+// Mint constructs a transaction invoking the "mint" entrypoint of the contract. Minting is not part of CIS-2,
+// so the parameter is specific to the contract. It can for instance be serialized with the schema package.
+func (c *Cis2Contract) Mint(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	energy v2.Energy, parameter v2.Parameter) *v2.PreAccountTransaction {
+	return c.Update(numSigs, sender, nonce, expiry, energy, "mint", parameter)
+}
+
+// Update constructs a transaction invoking the given entrypoint of the contract, without the contract name,
+// with the given parameter and no CCD.
+func (c *Cis2Contract) Update(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	energy v2.Energy, entrypoint string, parameter v2.Parameter) *v2.PreAccountTransaction {
+	address := c.Address
+	return construct.UpdateContract(numSigs, sender, nonce, expiry, v2.UpdateContractPayload{
+		Amount:      &v2.Amount{},
+		Address:     &address,
+		ReceiveName: &v2.ReceiveName{Value: c.ContractName + "." + entrypoint},
+		Parameter:   &parameter,
+	}, energy)
+}
+
+// invoke invokes the given view entrypoint and returns the return value. If the contract rejects the call
+// with one of the error codes defined by CIS-2, the returned error wraps the corresponding error.
+func (c *Cis2Contract) invoke(ctx context.Context, block v2.BlockHashInput, entrypoint string, parameter []byte) ([]byte, error) {
+	address := c.Address
+	res, err := c.client.InvokeInstance(ctx, v2.UpdateContractPayload{
+		Amount:      &v2.Amount{},
+		Address:     &address,
+		ReceiveName: &v2.ReceiveName{Value: c.ContractName + "." + entrypoint},
+		Parameter:   &v2.Parameter{Value: parameter},
+	}, block, cis0.MaxInvokeEnergy, nil)
+	if err != nil {
+		return nil, err
+	}
+	success, err := v2.ParseInvokeInstanceResponse(res)
+	if err != nil {
+		return nil, wrapRejectReason(err)
+	}
+	return success.ReturnValue, nil
+}
+
+// wrapRejectReason wraps the CIS-2 error corresponding to the reject reason of a failed invocation, if any.
+func wrapRejectReason(err error) error {
+	var rejected v2.RejectReasonRejectedReceive
+	if !errors.As(err, &rejected) {
+		return err
+	}
+	switch rejected.RejectReason {
+	case errorCodeInvalidTokenId:
+		return fmt.Errorf("%w: %w", ErrInvalidTokenId, err)
+	case errorCodeInsufficientFunds:
+		return fmt.Errorf("%w: %w", ErrInsufficientFunds, err)
+	case errorCodeUnauthorized:
+		return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return err
+}
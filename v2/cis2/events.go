@@ -0,0 +1,204 @@
+package cis2
+
+import (
+	"errors"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// ErrNotCis2Event indicates that a contract event is not one of the events defined by CIS-2. Contracts may log
+// custom events with tags below 251.
+var ErrNotCis2Event = errors.New("not a CIS-2 event")
+
+const (
+	eventTagTokenMetadata  uint8 = 251
+	eventTagUpdateOperator uint8 = 252
+	eventTagBurn           uint8 = 253
+	eventTagMint           uint8 = 254
+	eventTagTransfer       uint8 = 255
+)
+
+// Event an event logged by a CIS-2 contract. Event is one of EventTransfer, EventMint, EventBurn,
+// EventUpdateOperator or EventTokenMetadata.
+type Event struct {
+	Event isEvent
+}
+
+type isEvent interface {
+	isEvent()
+}
+
+// EventTransfer tokens were transferred.
+type EventTransfer struct {
+	TokenId TokenId
+	Amount  TokenAmount
+	From    Address
+	To      Address
+}
+
+func (EventTransfer) isEvent() {}
+
+// EventMint tokens were minted.
+type EventMint struct {
+	TokenId TokenId
+	Amount  TokenAmount
+	Owner   Address
+}
+
+func (EventMint) isEvent() {}
+
+// EventBurn tokens were burned.
+type EventBurn struct {
+	TokenId TokenId
+	Amount  TokenAmount
+	Owner   Address
+}
+
+func (EventBurn) isEvent() {}
+
+// EventUpdateOperator an operator of Owner was added or removed.
+type EventUpdateOperator struct {
+	Update   OperatorUpdate
+	Owner    Address
+	Operator Address
+}
+
+func (EventUpdateOperator) isEvent() {}
+
+// EventTokenMetadata the metadata URL of a token was set.
+type EventTokenMetadata struct {
+	TokenId     TokenId
+	MetadataUrl MetadataUrl
+}
+
+func (EventTokenMetadata) isEvent() {}
+
+// ParseEvent parses a contract event logged by a CIS-2 contract. If the event is not a CIS-2 event,
+// ErrNotCis2Event is returned.
+func ParseEvent(event v2.ContractEvent) (Event, error) {
+	r := &reader{data: event.Value}
+	tag, err := r.u8()
+	if err != nil {
+		return Event{}, err
+	}
+
+	var res isEvent
+	switch tag {
+	case eventTagTransfer:
+		e := EventTransfer{}
+		if e.TokenId, err = r.tokenId(); err != nil {
+			return Event{}, err
+		}
+		if e.Amount, err = r.tokenAmount(); err != nil {
+			return Event{}, err
+		}
+		if e.From, err = r.address(); err != nil {
+			return Event{}, err
+		}
+		if e.To, err = r.address(); err != nil {
+			return Event{}, err
+		}
+		res = e
+	case eventTagMint, eventTagBurn:
+		var (
+			tokenId TokenId
+			amount  TokenAmount
+			owner   Address
+		)
+		if tokenId, err = r.tokenId(); err != nil {
+			return Event{}, err
+		}
+		if amount, err = r.tokenAmount(); err != nil {
+			return Event{}, err
+		}
+		if owner, err = r.address(); err != nil {
+			return Event{}, err
+		}
+		if tag == eventTagMint {
+			res = EventMint{TokenId: tokenId, Amount: amount, Owner: owner}
+		} else {
+			res = EventBurn{TokenId: tokenId, Amount: amount, Owner: owner}
+		}
+	case eventTagUpdateOperator:
+		e := EventUpdateOperator{}
+		update, err := r.u8()
+		if err != nil {
+			return Event{}, err
+		}
+		if update > uint8(OperatorUpdateAdd) {
+			return Event{}, ErrInvalidData
+		}
+		e.Update = OperatorUpdate(update)
+		if e.Owner, err = r.address(); err != nil {
+			return Event{}, err
+		}
+		if e.Operator, err = r.address(); err != nil {
+			return Event{}, err
+		}
+		res = e
+	case eventTagTokenMetadata:
+		e := EventTokenMetadata{}
+		if e.TokenId, err = r.tokenId(); err != nil {
+			return Event{}, err
+		}
+		if e.MetadataUrl, err = r.metadataUrl(); err != nil {
+			return Event{}, err
+		}
+		res = e
+	default:
+		return Event{}, ErrNotCis2Event
+	}
+
+	if err = r.end(); err != nil {
+		return Event{}, err
+	}
+	return Event{Event: res}, nil
+}
+
+// ParseEvents parses the CIS-2 events among the given contract events. Events that are not CIS-2 events are skipped.
+func ParseEvents(events []v2.ContractEvent) ([]Event, error) {
+	var res []Event
+	for _, event := range events {
+		e, err := ParseEvent(event)
+		if errors.Is(err, ErrNotCis2Event) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+// EventsFromSummary returns the CIS-2 events logged by the contract instance at the given address in the
+// block item, in the order they were logged.
+func EventsFromSummary(summary *v2.BlockItemSummary, address v2.ContractAddress) ([]Event, error) {
+	d, ok := summary.Details.(v2.AccountTransactionDetails)
+	if !ok {
+		return nil, nil
+	}
+
+	var events []v2.ContractEvent
+	switch e := d.Effects.(type) {
+	case v2.ContractInitialized:
+		if e.Address == address {
+			events = e.Events
+		}
+	case v2.ContractUpdateIssued:
+		for _, element := range e.Effects {
+			switch t := element.Element.(type) {
+			case v2.ContractTraceElementUpdated:
+				if t.Address == address {
+					events = append(events, t.Events...)
+				}
+			case v2.ContractTraceElementInterrupted:
+				if t.Address == address {
+					events = append(events, t.Events...)
+				}
+			}
+		}
+	}
+
+	return ParseEvents(events)
+}
@@ -0,0 +1,488 @@
+package cis2
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+const (
+	// TokenIdMaxLength is the maximum length of a token id in bytes.
+	TokenIdMaxLength = 255
+	// TokenAmountMaxLength is the maximum length of the LEB128 encoding of a token amount in bytes.
+	TokenAmountMaxLength = 37
+)
+
+var (
+	// ErrTokenIdTooLong indicates that a token id is longer than TokenIdMaxLength.
+	ErrTokenIdTooLong = errors.New("token id is too long")
+	// ErrInvalidTokenAmount indicates that a token amount is negative or does not fit in TokenAmountMaxLength bytes.
+	ErrInvalidTokenAmount = errors.New("token amount must be non-negative and at most 2^256 - 1")
+	// ErrTooManyEntries indicates that a list has more entries than can be serialized.
+	ErrTooManyEntries = errors.New("too many entries")
+	// ErrTooLong indicates that a string or byte array is too long to be serialized.
+	ErrTooLong = errors.New("value is too long")
+	// ErrInvalidData indicates that data returned or logged by the contract is not valid according to CIS-2.
+	ErrInvalidData = errors.New("invalid CIS-2 data")
+)
+
+// TokenId identifies a token within a CIS-2 contract. It is at most TokenIdMaxLength bytes.
+type TokenId struct {
+	Value []byte
+}
+
+// TokenAmount an amount of a CIS-2 token. The amount is a non-negative integer of at most 256 bits.
+type TokenAmount struct {
+	Value *big.Int
+}
+
+// NewTokenAmount creates a TokenAmount from an uint64.
+func NewTokenAmount(amount uint64) TokenAmount {
+	return TokenAmount{Value: new(big.Int).SetUint64(amount)}
+}
+
+// Address the address of an account or contract instance. Address is either AddressAccount or AddressContract.
+type Address struct {
+	Address isAddress
+}
+
+type isAddress interface {
+	isAddress()
+}
+
+// AddressAccount the address of an account.
+type AddressAccount struct {
+	Address v2.AccountAddress
+}
+
+func (AddressAccount) isAddress() {}
+
+// AddressContract the address of a contract instance.
+type AddressContract struct {
+	Address v2.ContractAddress
+}
+
+func (AddressContract) isAddress() {}
+
+// Receiver the receiver of a transfer. Receiver is either ReceiverAccount or ReceiverContract.
+type Receiver struct {
+	Receiver isReceiver
+}
+
+type isReceiver interface {
+	isReceiver()
+}
+
+// ReceiverAccount the tokens are sent to an account.
+type ReceiverAccount struct {
+	Address v2.AccountAddress
+}
+
+func (ReceiverAccount) isReceiver() {}
+
+// ReceiverContract the tokens are sent to a contract instance, which is notified by invoking the given entrypoint.
+type ReceiverContract struct {
+	Address v2.ContractAddress
+	// Name of the entrypoint to invoke, without the contract name, e.g. "onReceivingCIS2".
+	EntrypointName string
+}
+
+func (ReceiverContract) isReceiver() {}
+
+// AdditionalData additional data included in a transfer, which is passed on to receiving contracts.
+type AdditionalData struct {
+	Value []byte
+}
+
+// Transfer a single transfer of tokens.
+type Transfer struct {
+	TokenId TokenId
+	Amount  TokenAmount
+	From    Address
+	To      Receiver
+	Data    AdditionalData
+}
+
+// OperatorUpdate whether an operator is added or removed.
+type OperatorUpdate uint8
+
+const (
+	// OperatorUpdateRemove removes the operator.
+	OperatorUpdateRemove OperatorUpdate = 0
+	// OperatorUpdateAdd adds the operator.
+	OperatorUpdateAdd OperatorUpdate = 1
+)
+
+// UpdateOperator a single update of an operator of the sender.
+type UpdateOperator struct {
+	Update   OperatorUpdate
+	Operator Address
+}
+
+// BalanceOfQuery queries the balance of the given token owned by the given address.
+type BalanceOfQuery struct {
+	TokenId TokenId
+	Address Address
+}
+
+// OperatorOfQuery queries whether Address is an operator of Owner.
+type OperatorOfQuery struct {
+	Owner   Address
+	Address Address
+}
+
+// MetadataUrl the URL of the metadata of a token, optionally with the SHA256 hash of the metadata.
+type MetadataUrl struct {
+	Url  string
+	Hash *[32]byte
+}
+
+// SerializeTransferParams serializes the parameter of the "transfer" entrypoint.
+func SerializeTransferParams(transfers []Transfer) ([]byte, error) {
+	buf, err := appendLength16(nil, len(transfers))
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transfers {
+		if buf, err = appendTokenId(buf, t.TokenId); err != nil {
+			return nil, err
+		}
+		if buf, err = appendTokenAmount(buf, t.Amount); err != nil {
+			return nil, err
+		}
+		if buf, err = appendAddress(buf, t.From); err != nil {
+			return nil, err
+		}
+		if buf, err = appendReceiver(buf, t.To); err != nil {
+			return nil, err
+		}
+		if buf, err = appendBytes16(buf, t.Data.Value); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// SerializeUpdateOperatorParams serializes the parameter of the "updateOperator" entrypoint.
+func SerializeUpdateOperatorParams(updates []UpdateOperator) ([]byte, error) {
+	buf, err := appendLength16(nil, len(updates))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range updates {
+		if u.Update > OperatorUpdateAdd {
+			return nil, errors.New("invalid operator update")
+		}
+		buf = append(buf, uint8(u.Update))
+		if buf, err = appendAddress(buf, u.Operator); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// SerializeBalanceOfParams serializes the parameter of the "balanceOf" entrypoint.
+func SerializeBalanceOfParams(queries []BalanceOfQuery) ([]byte, error) {
+	buf, err := appendLength16(nil, len(queries))
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range queries {
+		if buf, err = appendTokenId(buf, q.TokenId); err != nil {
+			return nil, err
+		}
+		if buf, err = appendAddress(buf, q.Address); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// DeserializeBalanceOfResponse deserializes the return value of the "balanceOf" entrypoint.
+func DeserializeBalanceOfResponse(data []byte) ([]TokenAmount, error) {
+	r := &reader{data: data}
+	n, err := r.u16()
+	if err != nil {
+		return nil, err
+	}
+	amounts := make([]TokenAmount, n)
+	for i := range amounts {
+		if amounts[i], err = r.tokenAmount(); err != nil {
+			return nil, err
+		}
+	}
+	return amounts, r.end()
+}
+
+// SerializeOperatorOfParams serializes the parameter of the "operatorOf" entrypoint.
+func SerializeOperatorOfParams(queries []OperatorOfQuery) ([]byte, error) {
+	buf, err := appendLength16(nil, len(queries))
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range queries {
+		if buf, err = appendAddress(buf, q.Owner); err != nil {
+			return nil, err
+		}
+		if buf, err = appendAddress(buf, q.Address); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// DeserializeOperatorOfResponse deserializes the return value of the "operatorOf" entrypoint.
+func DeserializeOperatorOfResponse(data []byte) ([]bool, error) {
+	r := &reader{data: data}
+	n, err := r.u16()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bool, n)
+	for i := range res {
+		if res[i], err = r.bool(); err != nil {
+			return nil, err
+		}
+	}
+	return res, r.end()
+}
+
+// SerializeTokenMetadataParams serializes the parameter of the "tokenMetadata" entrypoint.
+func SerializeTokenMetadataParams(tokenIds []TokenId) ([]byte, error) {
+	buf, err := appendLength16(nil, len(tokenIds))
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range tokenIds {
+		if buf, err = appendTokenId(buf, id); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// DeserializeTokenMetadataResponse deserializes the return value of the "tokenMetadata" entrypoint.
+func DeserializeTokenMetadataResponse(data []byte) ([]MetadataUrl, error) {
+	r := &reader{data: data}
+	n, err := r.u16()
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]MetadataUrl, n)
+	for i := range urls {
+		if urls[i], err = r.metadataUrl(); err != nil {
+			return nil, err
+		}
+	}
+	return urls, r.end()
+}
+
+func appendLength16(buf []byte, n int) ([]byte, error) {
+	if n > math.MaxUint16 {
+		return nil, ErrTooManyEntries
+	}
+	return binary.LittleEndian.AppendUint16(buf, uint16(n)), nil
+}
+
+func appendBytes16(buf []byte, b []byte) ([]byte, error) {
+	if len(b) > math.MaxUint16 {
+		return nil, ErrTooLong
+	}
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(b)))
+	return append(buf, b...), nil
+}
+
+func appendTokenId(buf []byte, id TokenId) ([]byte, error) {
+	if len(id.Value) > TokenIdMaxLength {
+		return nil, ErrTokenIdTooLong
+	}
+	buf = append(buf, uint8(len(id.Value)))
+	return append(buf, id.Value...), nil
+}
+
+// appendTokenAmount appends the amount as unsigned LEB128.
+func appendTokenAmount(buf []byte, amount TokenAmount) ([]byte, error) {
+	v := amount.Value
+	if v == nil {
+		v = new(big.Int)
+	}
+	if v.Sign() < 0 || v.BitLen() > 256 {
+		return nil, ErrInvalidTokenAmount
+	}
+	v = new(big.Int).Set(v)
+	digit := new(big.Int)
+	mask := big.NewInt(0x7f)
+	for {
+		b := uint8(digit.And(v, mask).Uint64())
+		v.Rsh(v, 7)
+		if v.Sign() == 0 {
+			return append(buf, b), nil
+		}
+		buf = append(buf, b|0x80)
+	}
+}
+
+func appendAddress(buf []byte, address Address) ([]byte, error) {
+	switch a := address.Address.(type) {
+	case AddressAccount:
+		buf = append(buf, 0)
+		return append(buf, a.Address.Value[:]...), nil
+	case AddressContract:
+		buf = append(buf, 1)
+		return appendContractAddress(buf, a.Address), nil
+	}
+	return nil, errors.New("address must be AddressAccount or AddressContract")
+}
+
+func appendReceiver(buf []byte, receiver Receiver) ([]byte, error) {
+	switch r := receiver.Receiver.(type) {
+	case ReceiverAccount:
+		buf = append(buf, 0)
+		return append(buf, r.Address.Value[:]...), nil
+	case ReceiverContract:
+		buf = append(buf, 1)
+		buf = appendContractAddress(buf, r.Address)
+		return appendBytes16(buf, []byte(r.EntrypointName))
+	}
+	return nil, errors.New("receiver must be ReceiverAccount or ReceiverContract")
+}
+
+func appendContractAddress(buf []byte, address v2.ContractAddress) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, address.Index)
+	return binary.LittleEndian.AppendUint64(buf, address.Subindex)
+}
+
+// reader reads little-endian encoded CIS-2 values from a byte slice.
+type reader struct {
+	data []byte
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n > len(r.data) {
+		return nil, ErrInvalidData
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b, nil
+}
+
+func (r *reader) u8() (uint8, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *reader) u16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *reader) bool() (bool, error) {
+	b, err := r.u8()
+	if err != nil {
+		return false, err
+	}
+	if b > 1 {
+		return false, ErrInvalidData
+	}
+	return b == 1, nil
+}
+
+// end returns an error if not all data has been read.
+func (r *reader) end() error {
+	if len(r.data) != 0 {
+		return ErrInvalidData
+	}
+	return nil
+}
+
+func (r *reader) tokenId() (TokenId, error) {
+	n, err := r.u8()
+	if err != nil {
+		return TokenId{}, err
+	}
+	b, err := r.bytes(int(n))
+	if err != nil {
+		return TokenId{}, err
+	}
+	return TokenId{Value: append([]byte{}, b...)}, nil
+}
+
+func (r *reader) tokenAmount() (TokenAmount, error) {
+	v := new(big.Int)
+	for i := 0; i < TokenAmountMaxLength; i++ {
+		b, err := r.u8()
+		if err != nil {
+			return TokenAmount{}, err
+		}
+		v.Or(v, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), uint(7*i)))
+		if b&0x80 == 0 {
+			if v.BitLen() > 256 {
+				return TokenAmount{}, ErrInvalidData
+			}
+			return TokenAmount{Value: v}, nil
+		}
+	}
+	return TokenAmount{}, ErrInvalidData
+}
+
+func (r *reader) address() (Address, error) {
+	tag, err := r.u8()
+	if err != nil {
+		return Address{}, err
+	}
+	switch tag {
+	case 0:
+		b, err := r.bytes(v2.AccountAddressLength)
+		if err != nil {
+			return Address{}, err
+		}
+		var a v2.AccountAddress
+		copy(a.Value[:], b)
+		return Address{Address: AddressAccount{Address: a}}, nil
+	case 1:
+		b, err := r.bytes(16)
+		if err != nil {
+			return Address{}, err
+		}
+		return Address{Address: AddressContract{Address: v2.ContractAddress{
+			Index:    binary.LittleEndian.Uint64(b),
+			Subindex: binary.LittleEndian.Uint64(b[8:]),
+		}}}, nil
+	}
+	return Address{}, ErrInvalidData
+}
+
+func (r *reader) metadataUrl() (MetadataUrl, error) {
+	n, err := r.u16()
+	if err != nil {
+		return MetadataUrl{}, err
+	}
+	url, err := r.bytes(int(n))
+	if err != nil {
+		return MetadataUrl{}, err
+	}
+	res := MetadataUrl{Url: string(url)}
+	hasHash, err := r.bool()
+	if err != nil {
+		return MetadataUrl{}, err
+	}
+	if hasHash {
+		b, err := r.bytes(32)
+		if err != nil {
+			return MetadataUrl{}, err
+		}
+		var hash [32]byte
+		copy(hash[:], b)
+		res.Hash = &hash
+	}
+	return res, nil
+}
@@ -0,0 +1,139 @@
+// Package cis3 implements the CIS-3 standard for sponsored transactions. An account signs a PermitMessage, which
+// a sponsor account submits to the "permit" entrypoint of the contract and pays for.
+//
+// See https://proposals.concordium.software/CIS/cis-3.html for the specification.
+package cis3
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/cis0"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// ErrInvalidData indicates that data returned or logged by the contract is not valid according to CIS-3.
+var ErrInvalidData = errors.New("invalid CIS-3 data")
+
+// eventTagNonce is the tag of the event logged when a permit message is executed.
+const eventTagNonce uint8 = 250
+
+// Cis3Contract a smart contract instance following the CIS-3 standard.
+type Cis3Contract struct {
+	client *v2.Client
+	// Address of the contract instance.
+	Address v2.ContractAddress
+	// Name of the contract, without the "init_" prefix.
+	ContractName string
+}
+
+// New creates a Cis3Contract for the contract with the given name at the given address.
+func New(client *v2.Client, address v2.ContractAddress, contractName string) *Cis3Contract {
+	return &Cis3Contract{
+		client:       client,
+		Address:      address,
+		ContractName: contractName,
+	}
+}
+
+// SupportsPermit queries whether the given entrypoints, without the contract name, can be invoked
+// through "permit" in the given block.
+func (c *Cis3Contract) SupportsPermit(ctx context.Context, block v2.BlockHashInput, entrypoints []string) ([]cis0.SupportResult, error) {
+	if len(entrypoints) > math.MaxUint16 {
+		return nil, ErrTooLong
+	}
+	parameter := binary.LittleEndian.AppendUint16(nil, uint16(len(entrypoints)))
+	for _, entrypoint := range entrypoints {
+		if len(entrypoint) > math.MaxUint16 {
+			return nil, ErrTooLong
+		}
+		parameter = binary.LittleEndian.AppendUint16(parameter, uint16(len(entrypoint)))
+		parameter = append(parameter, entrypoint...)
+	}
+
+	address := c.Address
+	res, err := c.client.InvokeInstance(ctx, v2.UpdateContractPayload{
+		Amount:      &v2.Amount{},
+		Address:     &address,
+		ReceiveName: &v2.ReceiveName{Value: c.ContractName + ".supportsPermit"},
+		Parameter:   &v2.Parameter{Value: parameter},
+	}, block, cis0.MaxInvokeEnergy, nil)
+	if err != nil {
+		return nil, err
+	}
+	success, err := v2.ParseInvokeInstanceResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	// the response has the same format as the response of CIS-0 "supports".
+	results, err := cis0.DeserializeSupportsResponse(success.ReturnValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(entrypoints) {
+		return nil, fmt.Errorf("%w: expected %d results, got %d", ErrInvalidData, len(entrypoints), len(results))
+	}
+	return results, nil
+}
+
+// PermitPayload returns the payload of a transaction invoking the "permit" entrypoint with the given parameter.
+func (c *Cis3Contract) PermitPayload(param *PermitParam) (v2.UpdateContractPayload, error) {
+	parameter, err := param.Serialize()
+	if err != nil {
+		return v2.UpdateContractPayload{}, err
+	}
+	address := c.Address
+	return v2.UpdateContractPayload{
+		Amount:      &v2.Amount{},
+		Address:     &address,
+		ReceiveName: &v2.ReceiveName{Value: c.ContractName + ".permit"},
+		Parameter:   &v2.Parameter{Value: parameter},
+	}, nil
+}
+
+// Permit constructs a transaction from the sponsor invoking the "permit" entrypoint with the given parameter.
+func (c *Cis3Contract) Permit(numSigs uint32, sponsor v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	energy v2.Energy, param *PermitParam) (*v2.PreAccountTransaction, error) {
+	payload, err := c.PermitPayload(param)
+	if err != nil {
+		return nil, err
+	}
+	return construct.UpdateContract(numSigs, sponsor, nonce, expiry, payload, energy), nil
+}
+
+// SponsorPermit constructs and signs a transaction from the sponsor invoking the "permit" entrypoint
+// with the given parameter.
+func (c *Cis3Contract) SponsorPermit(signer v2.ExactSizeTransactionSigner, sponsor v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, energy v2.Energy, param *PermitParam) (*v2.AccountTransaction, error) {
+	tx, err := c.Permit(signer.NumberOfKeys(), sponsor, nonce, expiry, energy, param)
+	if err != nil {
+		return &v2.AccountTransaction{}, err
+	}
+	return tx.Sign(signer)
+}
+
+// NonceEvent the event logged when a permit message of Account with Nonce is executed.
+type NonceEvent struct {
+	Nonce   uint64
+	Account v2.AccountAddress
+}
+
+// ParseNonceEvent parses a nonce event logged by a CIS-3 contract. The second return value is false if
+// the event is not a nonce event.
+func ParseNonceEvent(event v2.ContractEvent) (NonceEvent, bool, error) {
+	if len(event.Value) == 0 || event.Value[0] != eventTagNonce {
+		return NonceEvent{}, false, nil
+	}
+	if len(event.Value) != 1+v2.AccountAddressLength+8 {
+		return NonceEvent{}, false, ErrInvalidData
+	}
+	var res NonceEvent
+	res.Nonce = binary.LittleEndian.Uint64(event.Value[1:])
+	copy(res.Account.Value[:], event.Value[9:])
+	return res, true, nil
+}
@@ -0,0 +1,156 @@
+package cis3
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+var (
+	// ErrTooLong indicates that an entrypoint name or payload is too long to be serialized.
+	ErrTooLong = errors.New("value is too long")
+	// ErrInvalidSignature indicates that a signature is not an ed25519 signature.
+	ErrInvalidSignature = errors.New("signature must be an ed25519 signature")
+)
+
+// signatureTagEd25519 is the tag of ed25519 signatures in the serialization of account signatures.
+const signatureTagEd25519 uint8 = 0
+
+// PermitMessage the message an account signs to permit a sponsor to invoke an entrypoint on its behalf.
+type PermitMessage struct {
+	// The contract instance the message is intended for.
+	ContractAddress v2.ContractAddress
+	// The nonce of the signer in the contract, which prevents replay of the message.
+	Nonce uint64
+	// The time until which the message is valid.
+	Timestamp v2.Timestamp
+	// The entrypoint to invoke, without the contract name.
+	EntryPoint string
+	// The parameter of the entrypoint.
+	Payload []byte
+}
+
+// Serialize serializes the message as it is signed and passed to the contract.
+func (m *PermitMessage) Serialize() ([]byte, error) {
+	if len(m.EntryPoint) > math.MaxUint16 || len(m.Payload) > math.MaxUint16 {
+		return nil, ErrTooLong
+	}
+	buf := binary.LittleEndian.AppendUint64(nil, m.ContractAddress.Index)
+	buf = binary.LittleEndian.AppendUint64(buf, m.ContractAddress.Subindex)
+	buf = binary.LittleEndian.AppendUint64(buf, m.Nonce)
+	buf = binary.LittleEndian.AppendUint64(buf, m.Timestamp.Value)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(m.EntryPoint)))
+	buf = append(buf, m.EntryPoint...)
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(m.Payload)))
+	return append(buf, m.Payload...), nil
+}
+
+// Hash computes the hash of the message that the signer signs. This is the SHA256 hash of the address of the signer,
+// followed by 8 zero bytes and the serialized message, which guarantees that the signed data cannot be
+// a transaction.
+func (m *PermitMessage) Hash(signer v2.AccountAddress) ([sha256.Size]byte, error) {
+	message, err := m.Serialize()
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	h := sha256.New()
+	h.Write(signer.Value[:])
+	h.Write(make([]byte, 8))
+	h.Write(message)
+
+	var res [sha256.Size]byte
+	copy(res[:], h.Sum(nil))
+	return res, nil
+}
+
+// PermitParam the parameter of the "permit" entrypoint.
+type PermitParam struct {
+	// Signatures of the message hash, indexed by credential and key index.
+	Signature v2.AccountTransactionSignature
+	// The account that signed the message.
+	Signer v2.AccountAddress
+	// The signed message.
+	Message PermitMessage
+}
+
+// SignPermitMessage signs the message with all keys of the account and returns the parameter of the "permit" entrypoint.
+func SignPermitMessage(account *v2.WalletAccount, message PermitMessage) (*PermitParam, error) {
+	if account.Address == nil || account.Keys == nil {
+		return nil, errors.New("'AccountAddress' or 'Keys' field is not initialized or empty")
+	}
+	hash, err := message.Hash(*account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make(map[uint8]*v2.AccountSignatureMap, len(account.Keys.Keys))
+	for credIdx, credData := range account.Keys.Keys {
+		credSignatures := make(map[uint8]*v2.Signature, len(credData.Keys))
+		for keyIdx, keyPair := range credData.Keys {
+			signature := keyPair.Sign(hash[:])
+			credSignatures[uint8(keyIdx)] = &signature
+		}
+		signatures[uint8(credIdx)] = &v2.AccountSignatureMap{Signatures: credSignatures}
+	}
+
+	return &PermitParam{
+		Signature: v2.AccountTransactionSignature{Signatures: signatures},
+		Signer:    *account.Address,
+		Message:   message,
+	}, nil
+}
+
+// Serialize serializes the parameter of the "permit" entrypoint.
+func (p *PermitParam) Serialize() ([]byte, error) {
+	buf, err := appendAccountSignatures(nil, p.Signature)
+	if err != nil {
+		return nil, err
+	}
+	buf = append(buf, p.Signer.Value[:]...)
+	message, err := p.Message.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, message...), nil
+}
+
+// appendAccountSignatures appends the signatures ordered by credential and key index, as expected by contracts.
+func appendAccountSignatures(buf []byte, signatures v2.AccountTransactionSignature) ([]byte, error) {
+	if len(signatures.Signatures) > math.MaxUint8 {
+		return nil, ErrTooLong
+	}
+	buf = append(buf, uint8(len(signatures.Signatures)))
+	credIndices := make([]uint8, 0, len(signatures.Signatures))
+	for credIdx := range signatures.Signatures {
+		credIndices = append(credIndices, credIdx)
+	}
+	sort.Slice(credIndices, func(i, j int) bool { return credIndices[i] < credIndices[j] })
+
+	for _, credIdx := range credIndices {
+		credSignatures := signatures.Signatures[credIdx]
+		if credSignatures == nil || len(credSignatures.Signatures) > math.MaxUint8 {
+			return nil, ErrInvalidSignature
+		}
+		buf = append(buf, credIdx, uint8(len(credSignatures.Signatures)))
+		keyIndices := make([]uint8, 0, len(credSignatures.Signatures))
+		for keyIdx := range credSignatures.Signatures {
+			keyIndices = append(keyIndices, keyIdx)
+		}
+		sort.Slice(keyIndices, func(i, j int) bool { return keyIndices[i] < keyIndices[j] })
+
+		for _, keyIdx := range keyIndices {
+			signature := credSignatures.Signatures[keyIdx]
+			if signature == nil || len(signature.Value) != ed25519.SignatureSize {
+				return nil, ErrInvalidSignature
+			}
+			buf = append(buf, keyIdx, signatureTagEd25519)
+			buf = append(buf, signature.Value...)
+		}
+	}
+	return buf, nil
+}
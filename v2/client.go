@@ -3,51 +3,164 @@ package v2
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"time"
 
 	"github.com/Concordium/concordium-go-sdk/v2/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 // Config contains Concordium configurable values.
 type Config struct {
 	NodeAddress    string `env:"NODE_ADDRESS"`
 	TlsCredentials credentials.TransportCredentials
+
+	// Addresses of additional nodes. If any are given, the client routes requests to the healthy nodes,
+	// see NewClient for details.
+	NodeAddresses []string `env:"NODE_ADDRESSES" envSeparator:","`
+	// Whether block items are sent to all nodes instead of one when several nodes are configured.
+	BroadcastBlockItems bool
+	// Interval between health checks when several nodes are configured. Defaults to DefaultHealthCheckInterval.
+	HealthCheckInterval time.Duration
+	// Maximum number of blocks the last finalized block of a node may be behind that of the most advanced node
+	// for the node to be considered healthy. Defaults to DefaultMaxFinalizedHeightLag.
+	MaxFinalizedHeightLag uint64
+
+	// Policy for retrying failed requests and the default deadline of unary requests. If nil, requests are not
+	// retried and have no default deadline.
+	RetryPolicy *RetryPolicy
+
+	// Credentials attached to every request, for example an API key required by a gateway.
+	// See StaticCredentials and RefreshingCredentials.
+	PerRPCCredentials credentials.PerRPCCredentials
+	// Metadata added to every request.
+	Metadata map[string]string
+	// Certificates presented to the node for mutual TLS. If set, TLS is used with RootCAs and TlsCredentials must
+	// be nil.
+	ClientCertificates []tls.Certificate
+	// Root certificates used to verify the node if ClientCertificates is set. Defaults to the system roots.
+	RootCAs *x509.CertPool
+	// Interceptors run on every unary request, in order.
+	UnaryInterceptors []grpc.UnaryClientInterceptor
+	// Interceptors run on every streaming request, in order.
+	StreamInterceptors []grpc.StreamClientInterceptor
+	// Keepalive parameters of the connection. If nil, the gRPC defaults are used.
+	Keepalive *keepalive.ClientParameters
+	// Maximum size in bytes of a response. Defaults to DefaultMaxRecvMsgSize.
+	MaxRecvMsgSize int
+	// Additional options used when connecting to the node, applied after the options above.
+	DialOptions []grpc.DialOption
 }
 
+// DefaultMaxRecvMsgSize is the default maximum size of a response. It is larger than the gRPC default of 4 MB,
+// since module sources and contract states can exceed that.
+const DefaultMaxRecvMsgSize = 64 * 1024 * 1024
+
 // Client provides grpc connection with node.
 type Client struct {
 	GrpcClient pb.QueriesClient
 	ClientConn *grpc.ClientConn
 	config     Config
+	// the nodes the client routes requests to, nil if only one node is configured.
+	nodes *nodePool
 }
 
 // NewClient creates new concordium grpc client.
+//
+// If Config.NodeAddresses is not empty, the client connects to Config.NodeAddress, if set, and every node in
+// Config.NodeAddresses. Requests are spread over the healthy nodes and fail over to the next node if a node is
+// unavailable. ClientConn is then the connection to the first node.
 func NewClient(config Config) (_ *Client, err error) {
-	if config.TlsCredentials != nil {
-		conn, err := grpc.NewClient(
-			config.NodeAddress,
-			grpc.WithTransportCredentials(config.TlsCredentials),
-		)
+	var addresses []string
+	if config.NodeAddress != "" || len(config.NodeAddresses) == 0 {
+		addresses = append(addresses, config.NodeAddress)
+	}
+	addresses = append(addresses, config.NodeAddresses...)
+
+	options, err := dialOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	conns := make([]*grpc.ClientConn, 0, len(addresses))
+	for _, address := range addresses {
+		conn, err := grpc.NewClient(address, options...)
 		if err != nil {
+			for _, c := range conns {
+				c.Close()
+			}
 			return nil, err
 		}
-		client := pb.NewQueriesClient(conn)
-
-		return &Client{GrpcClient: client, ClientConn: conn, config: config}, nil
-	} else {
-		conn, err := grpc.NewClient(
-			config.NodeAddress,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-		)
-		if err != nil {
-			return nil, err
+		conns = append(conns, conn)
+	}
+
+	client := &Client{ClientConn: conns[0], config: config}
+	var cc grpc.ClientConnInterface = conns[0]
+	if len(conns) > 1 {
+		client.nodes = newNodePool(config, addresses, conns)
+		cc = client.nodes
+	}
+	if config.RetryPolicy != nil {
+		cc = newRetryConn(cc, *config.RetryPolicy)
+	}
+	client.GrpcClient = pb.NewQueriesClient(cc)
+
+	return client, nil
+}
+
+// dialOptions returns the options used to connect to a node.
+func dialOptions(config Config) ([]grpc.DialOption, error) {
+	transportCredentials := config.TlsCredentials
+	switch {
+	case len(config.ClientCertificates) > 0 && transportCredentials != nil:
+		return nil, errors.New("TlsCredentials and ClientCertificates cannot both be set")
+	case len(config.ClientCertificates) > 0:
+		rootCAs := config.RootCAs
+		if rootCAs == nil {
+			var err error
+			if rootCAs, err = x509.SystemCertPool(); err != nil {
+				return nil, err
+			}
 		}
-		client := pb.NewQueriesClient(conn)
+		transportCredentials = credentials.NewTLS(&tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: config.ClientCertificates,
+		})
+	case transportCredentials == nil:
+		transportCredentials = insecure.NewCredentials()
+	}
+
+	maxRecvMsgSize := config.MaxRecvMsgSize
+	if maxRecvMsgSize <= 0 {
+		maxRecvMsgSize = DefaultMaxRecvMsgSize
+	}
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMsgSize)),
+	}
+	if config.PerRPCCredentials != nil {
+		options = append(options, grpc.WithPerRPCCredentials(config.PerRPCCredentials))
+	}
+	if config.Keepalive != nil {
+		options = append(options, grpc.WithKeepaliveParams(*config.Keepalive))
+	}
 
-		return &Client{GrpcClient: client, ClientConn: conn, config: config}, nil
+	unaryInterceptors := config.UnaryInterceptors
+	streamInterceptors := config.StreamInterceptors
+	if len(config.Metadata) > 0 {
+		unaryInterceptors = append([]grpc.UnaryClientInterceptor{metadataUnaryInterceptor(config.Metadata)}, unaryInterceptors...)
+		streamInterceptors = append([]grpc.StreamClientInterceptor{metadataStreamInterceptor(config.Metadata)}, streamInterceptors...)
+	}
+	if len(unaryInterceptors) > 0 {
+		options = append(options, grpc.WithChainUnaryInterceptor(unaryInterceptors...))
 	}
+	if len(streamInterceptors) > 0 {
+		options = append(options, grpc.WithChainStreamInterceptor(streamInterceptors...))
+	}
+
+	return append(options, config.DialOptions...), nil
 }
 
 func HostTLSRoots() (_ credentials.TransportCredentials, err error) {
@@ -64,5 +177,8 @@ func HostTLSRoots() (_ credentials.TransportCredentials, err error) {
 
 // Close closes client connection.
 func (c *Client) Close() error {
+	if c.nodes != nil {
+		return c.nodes.close()
+	}
 	return c.ClientConn.Close()
 }
@@ -0,0 +1,744 @@
+package v2
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// ConsensusDetailedStatus detailed internal state of the consensus of a node, as returned by
+// GetConsensusDetailedStatus. It is intended for diagnosing problems such as stalled rounds.
+type ConsensusDetailedStatus struct {
+	// The hash of the genesis block.
+	GenesisBlock BlockHash
+	// The persisted elements of the round status.
+	PersistentRoundStatus PersistentRoundStatus
+	// The status of the current round.
+	RoundStatus RoundStatus
+	// The number of non-finalized transactions.
+	NonFinalizedTransactionCount uint64
+	// The purge counter for the transaction table.
+	TransactionTablePurgeCounter int64
+	// Summary of the block table.
+	BlockTable BlockTableSummary
+	// The live blocks organized by height after the last finalized block.
+	Branches [][]BlockHash
+	// Which bakers the node has seen legally-signed blocks with live parents from in non-finalized rounds.
+	RoundExistingBlocks []RoundExistingBlock
+	// Which non-finalized rounds the node has seen quorum certificates for.
+	RoundExistingQCs []RoundExistingQC
+	// The absolute block height of the genesis block of the era.
+	GenesisBlockHeight AbsoluteBlockHeight
+	// The hash of the last finalized block.
+	LastFinalizedBlock BlockHash
+	// The height of the last finalized block.
+	LastFinalizedBlockHeight BlockHeight
+	// Unless the last finalized block is the genesis block, this is the finalization entry for the last
+	// finalized block.
+	LatestFinalizationEntry *FinalizationEntry
+	// The bakers and finalizers for the previous, current and next epoch, relative to the last finalized block.
+	EpochBakers EpochBakers
+	// The timeout messages collected by the node for the current round, nil if there are none.
+	TimeoutMessages *TimeoutMessages
+	// If a protocol update has occurred, this is the hash of the terminal block.
+	TerminalBlock *BlockHash
+}
+
+// FinalizerIndex the index of a finalizer in the finalization committee of an epoch.
+type FinalizerIndex struct {
+	Value uint32
+}
+
+// QuorumCertificate a quorum certificate on a block, as included in the detailed consensus status.
+type QuorumCertificate struct {
+	// The hash of the block that the quorum certificate refers to.
+	BlockHash BlockHash
+	// The round of the block.
+	Round Round
+	// The epoch of the block.
+	Epoch Epoch
+	// The aggregated signature by the finalization committee on the block.
+	AggregateSignature []byte
+	// The finalizers that contributed to the aggregate signature, by their index in the finalization
+	// committee of the epoch.
+	Signatories []FinalizerIndex
+}
+
+// FinalizerRound the finalizers that signed off in a round.
+type FinalizerRound struct {
+	Round      Round
+	Finalizers []FinalizerIndex
+}
+
+// TimeoutCertificate a certificate that a round timed out.
+type TimeoutCertificate struct {
+	// The round that timed out.
+	Round Round
+	// The minimum epoch of which signatures are included in the aggregate signature.
+	MinEpoch Epoch
+	// The rounds of which finalizers have their best quorum certificates in MinEpoch.
+	QcRoundsFirstEpoch []FinalizerRound
+	// The rounds of which finalizers have their best quorum certificates in the epoch after MinEpoch.
+	QcRoundsSecondEpoch []FinalizerRound
+	// The aggregated signature by the finalization committee that witnessed the round timed out.
+	AggregateSignature []byte
+}
+
+// FinalizationEntry a proof that a block is finalized.
+type FinalizationEntry struct {
+	// The quorum certificate for the finalized block.
+	FinalizedQC QuorumCertificate
+	// The quorum certificate for the block that finalizes the block that FinalizedQC points to.
+	SuccessorQC QuorumCertificate
+	// A proof that the successor block is an immediate successor of the finalized block.
+	SuccessorProof []byte
+}
+
+// RoundTimeout the certificates of a round that timed out.
+type RoundTimeout struct {
+	// Timeout certificate for the round that timed out.
+	TimeoutCertificate TimeoutCertificate
+	// The highest known quorum certificate when the round timed out.
+	QuorumCertificate QuorumCertificate
+}
+
+// QuorumMessage a signature of a finalizer on a block.
+type QuorumMessage struct {
+	// Signature on the relevant quorum signature message.
+	Signature []byte
+	// Hash of the block that is signed.
+	Block BlockHash
+	// Index of the finalizer signing the message.
+	Finalizer FinalizerIndex
+	// Round of the block.
+	Round Round
+	// Epoch of the block.
+	Epoch Epoch
+}
+
+// TimeoutMessage a message of a finalizer that a round timed out.
+type TimeoutMessage struct {
+	// Index of the finalizer signing the message, in the finalization committee of Epoch.
+	Finalizer FinalizerIndex
+	// Round which timed out.
+	Round Round
+	// Current epoch of the finalizer sending the message. This can differ from the epoch of the quorum certificate.
+	Epoch Epoch
+	// Highest quorum certificate known to the finalizer at the time of the timeout.
+	QuorumCertificate QuorumCertificate
+	// Signature on the appropriate timeout signature message.
+	Signature []byte
+	// Signature of the finalizer on the message as a whole.
+	MessageSignature []byte
+}
+
+// PersistentRoundStatus the persisted elements of the round status of a node.
+type PersistentRoundStatus struct {
+	// The last quorum message signed by the node, nil if there is none.
+	LastSignedQuorumMessage *QuorumMessage
+	// The last timeout message signed by the node, nil if there is none.
+	LastSignedTimeoutMessage *TimeoutMessage
+	// The last round the node baked in.
+	LastBakedRound Round
+	// The latest timeout certificate seen by the node. This is nil if the node has seen a quorum certificate
+	// for a more recent round.
+	LatestTimeout *TimeoutCertificate
+}
+
+// RoundStatus the status of the current round of a node.
+type RoundStatus struct {
+	// The current round from the perspective of the node. This is one more than the round of
+	// HighestCertifiedBlock, or of PreviousRoundTimeout if the previous round timed out.
+	CurrentRound Round
+	// The quorum certificate for the highest certified block.
+	HighestCertifiedBlock QuorumCertificate
+	// If the last round timed out, this is the timeout certificate for that round and the highest quorum
+	// certificate at the time the round timed out.
+	PreviousRoundTimeout *RoundTimeout
+	// Whether the node should attempt to bake in the current round.
+	RoundEligibleToBake bool
+	// The current epoch.
+	CurrentEpoch Epoch
+	// If present, an epoch finalization entry for the epoch before CurrentEpoch. It is present if the current
+	// epoch is greater than the epoch of the last finalized block.
+	LastEpochFinalizationEntry *FinalizationEntry
+	// The current duration the node will wait before the round times out.
+	CurrentTimeout time.Duration
+}
+
+// BlockTableSummary summary of the block table of a node.
+type BlockTableSummary struct {
+	// The number of blocks in the dead block cache.
+	DeadBlockCacheSize uint64
+	// The blocks that are currently live (not dead and not finalized).
+	LiveBlocks []BlockHash
+}
+
+// RoundExistingBlock a block that a node has seen in a non-finalized round.
+type RoundExistingBlock struct {
+	Round Round
+	// The baker that baked the block.
+	Baker BakerId
+	Block BlockHash
+}
+
+// RoundExistingQC a non-finalized round that a node has seen a quorum certificate for.
+type RoundExistingQC struct {
+	Round Round
+	Epoch Epoch
+}
+
+// FullBakerInfo the keys and stake of a baker.
+type FullBakerInfo struct {
+	BakerId              BakerId
+	ElectionVerifyKey    BakerElectionVerifyKey
+	SignatureVerifyKey   BakerSignatureVerifyKey
+	AggregationVerifyKey BakerAggregationVerifyKey
+	Stake                Amount
+}
+
+// BakersAndFinalizers the bakers and the finalization committee of an epoch.
+type BakersAndFinalizers struct {
+	Bakers []FullBakerInfo
+	// The bakers that are finalizers. The order determines the finalizer index.
+	Finalizers []BakerId
+	// The total effective stake of the bakers.
+	BakerTotalStake Amount
+	// The total effective stake of the finalizers.
+	FinalizerTotalStake Amount
+	// The hash of the finalization committee.
+	FinalizationCommitteeHash []byte
+}
+
+// EpochBakers the bakers and finalizers of the epochs around the last finalized block.
+type EpochBakers struct {
+	// The bakers and finalizers for the epoch before the epoch of the last finalized block.
+	PreviousEpochBakers BakersAndFinalizers
+	// The bakers and finalizers for the epoch of the last finalized block, nil if they are the same as
+	// PreviousEpochBakers.
+	CurrentEpochBakers *BakersAndFinalizers
+	// The bakers and finalizers for the epoch after the last finalized block, nil if they are the same as
+	// the bakers for the current epoch.
+	NextEpochBakers *BakersAndFinalizers
+	// The first epoch of the next payday.
+	NextPayday Epoch
+}
+
+// TimeoutMessages the timeout messages collected by a node for the current round.
+type TimeoutMessages struct {
+	// The first epoch for which timeout messages are present.
+	FirstEpoch Epoch
+	// The timeout messages for FirstEpoch.
+	FirstEpochTimeouts []TimeoutMessage
+	// The timeout messages for the epoch after FirstEpoch.
+	SecondEpochTimeouts []TimeoutMessage
+}
+
+// ConsensusProgress a summary of how far consensus has progressed from the perspective of a node.
+type ConsensusProgress struct {
+	CurrentRound Round
+	CurrentEpoch Epoch
+	// The round of the highest certified block.
+	HighestCertifiedRound Round
+	// The round and epoch of the last finalized block, zero if it is the genesis block.
+	LastFinalizedRound Round
+	LastFinalizedEpoch Epoch
+	// The number of rounds since the highest certified round. This is 1 while blocks are certified in every
+	// round, and grows while rounds time out.
+	RoundsSinceCertified uint64
+	// The number of rounds since the round of the last finalized block.
+	RoundsSinceFinalized uint64
+	// Whether the round before the current round timed out.
+	PreviousRoundTimedOut bool
+	// The duration the node will wait before the current round times out.
+	CurrentTimeout time.Duration
+	// The number of timeout messages for the current round.
+	Timeouts int
+	// The number of live blocks that are not finalized yet.
+	LiveBlocks int
+}
+
+// Finalizer a member of the finalization committee of an epoch.
+type Finalizer struct {
+	Index   FinalizerIndex
+	BakerId BakerId
+	// The effective stake of the baker in the epoch.
+	Stake Amount
+}
+
+// Progress summarizes the progress of the current round and epoch.
+func (s *ConsensusDetailedStatus) Progress() ConsensusProgress {
+	round := s.RoundStatus.CurrentRound.Value
+	progress := ConsensusProgress{
+		CurrentRound:          s.RoundStatus.CurrentRound,
+		CurrentEpoch:          s.RoundStatus.CurrentEpoch,
+		HighestCertifiedRound: s.RoundStatus.HighestCertifiedBlock.Round,
+		PreviousRoundTimedOut: s.RoundStatus.PreviousRoundTimeout != nil,
+		CurrentTimeout:        s.RoundStatus.CurrentTimeout,
+		Timeouts:              len(s.CurrentRoundTimeouts()),
+		LiveBlocks:            len(s.BlockTable.LiveBlocks),
+	}
+	if s.LatestFinalizationEntry != nil {
+		progress.LastFinalizedRound = s.LatestFinalizationEntry.FinalizedQC.Round
+		progress.LastFinalizedEpoch = s.LatestFinalizationEntry.FinalizedQC.Epoch
+	}
+	if round > progress.HighestCertifiedRound.Value {
+		progress.RoundsSinceCertified = round - progress.HighestCertifiedRound.Value
+	}
+	if round > progress.LastFinalizedRound.Value {
+		progress.RoundsSinceFinalized = round - progress.LastFinalizedRound.Value
+	}
+	return progress
+}
+
+// CurrentRoundTimeouts returns the timeout messages for the current round.
+func (s *ConsensusDetailedStatus) CurrentRoundTimeouts() []TimeoutMessage {
+	if s.TimeoutMessages == nil {
+		return nil
+	}
+	var res []TimeoutMessage
+	for _, timeouts := range [][]TimeoutMessage{s.TimeoutMessages.FirstEpochTimeouts, s.TimeoutMessages.SecondEpochTimeouts} {
+		for _, timeout := range timeouts {
+			if timeout.Round == s.RoundStatus.CurrentRound {
+				res = append(res, timeout)
+			}
+		}
+	}
+	return res
+}
+
+// BakersForEpoch returns the bakers and finalizers of an epoch. Only the epoch of the last finalized block and
+// the epochs before and after it are known.
+func (s *ConsensusDetailedStatus) BakersForEpoch(epoch Epoch) (BakersAndFinalizers, bool) {
+	var finalizedEpoch uint64
+	if s.LatestFinalizationEntry != nil {
+		finalizedEpoch = s.LatestFinalizationEntry.FinalizedQC.Epoch.Value
+	}
+
+	previous := s.EpochBakers.PreviousEpochBakers
+	current := previous
+	if s.EpochBakers.CurrentEpochBakers != nil {
+		current = *s.EpochBakers.CurrentEpochBakers
+	}
+	next := current
+	if s.EpochBakers.NextEpochBakers != nil {
+		next = *s.EpochBakers.NextEpochBakers
+	}
+
+	switch {
+	case epoch.Value == finalizedEpoch:
+		return current, true
+	case epoch.Value+1 == finalizedEpoch:
+		return previous, true
+	case epoch.Value == finalizedEpoch+1:
+		return next, true
+	}
+	return BakersAndFinalizers{}, false
+}
+
+// QuorumSigners splits the finalization committee of the epoch of a quorum certificate into the finalizers that
+// signed it and those that did not. It returns false if the committee of the epoch is not known.
+func (s *ConsensusDetailedStatus) QuorumSigners(qc QuorumCertificate) (signed, missing []Finalizer, ok bool) {
+	bakers, ok := s.BakersForEpoch(qc.Epoch)
+	if !ok {
+		return nil, nil, false
+	}
+	signatories := make(map[FinalizerIndex]bool, len(qc.Signatories))
+	for _, index := range qc.Signatories {
+		signatories[index] = true
+	}
+	for _, finalizer := range bakers.FinalizationCommittee() {
+		if signatories[finalizer.Index] {
+			signed = append(signed, finalizer)
+		} else {
+			missing = append(missing, finalizer)
+		}
+	}
+	return signed, missing, true
+}
+
+// TimeoutSigners splits the finalization committee of the current epoch into the finalizers that sent a timeout
+// message for the current round and those that did not. Messages sent in another epoch are attributed to the
+// baker they identify in the committee of that epoch. It returns false if the committee is not known.
+func (s *ConsensusDetailedStatus) TimeoutSigners() (signed, missing []Finalizer, ok bool) {
+	bakers, ok := s.BakersForEpoch(s.RoundStatus.CurrentEpoch)
+	if !ok {
+		return nil, nil, false
+	}
+	timedOut := make(map[BakerId]bool)
+	for _, timeout := range s.CurrentRoundTimeouts() {
+		epochBakers, ok := s.BakersForEpoch(timeout.Epoch)
+		if ok && int(timeout.Finalizer.Value) < len(epochBakers.Finalizers) {
+			timedOut[epochBakers.Finalizers[timeout.Finalizer.Value]] = true
+		}
+	}
+	for _, finalizer := range bakers.FinalizationCommittee() {
+		if timedOut[finalizer.BakerId] {
+			signed = append(signed, finalizer)
+		} else {
+			missing = append(missing, finalizer)
+		}
+	}
+	return signed, missing, true
+}
+
+// FinalizationCommittee returns the finalizers in the order of their finalizer index, with their stake.
+func (b *BakersAndFinalizers) FinalizationCommittee() []Finalizer {
+	stakes := make(map[BakerId]Amount, len(b.Bakers))
+	for _, baker := range b.Bakers {
+		stakes[baker.BakerId] = baker.Stake
+	}
+	committee := make([]Finalizer, 0, len(b.Finalizers))
+	for i, bakerId := range b.Finalizers {
+		committee = append(committee, Finalizer{
+			Index:   FinalizerIndex{Value: uint32(i)},
+			BakerId: bakerId,
+			Stake:   stakes[bakerId],
+		})
+	}
+	return committee
+}
+
+// Parses *pb.ConsensusDetailedStatus to ConsensusDetailedStatus.
+func parseConsensusDetailedStatus(s *pb.ConsensusDetailedStatus) (ConsensusDetailedStatus, error) {
+	genesisBlock, err := BlockHashFromBytes(s.GetGenesisBlock().GetValue())
+	if err != nil {
+		return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+	}
+	lastFinalizedBlock, err := BlockHashFromBytes(s.GetLastFinalizedBlock().GetValue())
+	if err != nil {
+		return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+	}
+	persistentRoundStatus, err := parsePersistentRoundStatus(s.GetPersistentRoundStatus())
+	if err != nil {
+		return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+	}
+	roundStatus, err := parseRoundStatus(s.GetRoundStatus())
+	if err != nil {
+		return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+	}
+	liveBlocks, err := parseBlockHashes(s.GetBlockTable().GetLiveBlocks())
+	if err != nil {
+		return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+	}
+
+	branches := make([][]BlockHash, 0, len(s.GetBranches()))
+	for _, branch := range s.GetBranches() {
+		blocks, err := parseBlockHashes(branch.GetBlocksAtBranchHeight())
+		if err != nil {
+			return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+		}
+		branches = append(branches, blocks)
+	}
+
+	roundExistingBlocks := make([]RoundExistingBlock, 0, len(s.GetRoundExistingBlocks()))
+	for _, b := range s.GetRoundExistingBlocks() {
+		block, err := BlockHashFromBytes(b.GetBlock().GetValue())
+		if err != nil {
+			return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+		}
+		roundExistingBlocks = append(roundExistingBlocks, RoundExistingBlock{
+			Round: Round{Value: b.GetRound().GetValue()},
+			Baker: BakerId{Value: b.GetBaker().GetValue()},
+			Block: block,
+		})
+	}
+
+	roundExistingQCs := make([]RoundExistingQC, 0, len(s.GetRoundExistingQcs()))
+	for _, qc := range s.GetRoundExistingQcs() {
+		roundExistingQCs = append(roundExistingQCs, RoundExistingQC{
+			Round: Round{Value: qc.GetRound().GetValue()},
+			Epoch: Epoch{Value: qc.GetEpoch().GetValue()},
+		})
+	}
+
+	var latestFinalizationEntry *FinalizationEntry
+	if s.GetLatestFinalizationEntry() != nil {
+		entry, err := parseFinalizationEntry(s.GetLatestFinalizationEntry())
+		if err != nil {
+			return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+		}
+		latestFinalizationEntry = &entry
+	}
+
+	var timeoutMessages *TimeoutMessages
+	if s.GetTimeoutMessages() != nil {
+		first, err := parseTimeoutMessages(s.GetTimeoutMessages().GetFirstEpochTimeouts())
+		if err != nil {
+			return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+		}
+		second, err := parseTimeoutMessages(s.GetTimeoutMessages().GetSecondEpochTimeouts())
+		if err != nil {
+			return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+		}
+		timeoutMessages = &TimeoutMessages{
+			FirstEpoch:          Epoch{Value: s.GetTimeoutMessages().GetFirstEpoch().GetValue()},
+			FirstEpochTimeouts:  first,
+			SecondEpochTimeouts: second,
+		}
+	}
+
+	var terminalBlock *BlockHash
+	if s.GetTerminalBlock() != nil {
+		hash, err := BlockHashFromBytes(s.GetTerminalBlock().GetValue())
+		if err != nil {
+			return ConsensusDetailedStatus{}, errors.New("Error parsing ConsensusDetailedStatus: " + err.Error())
+		}
+		terminalBlock = &hash
+	}
+
+	return ConsensusDetailedStatus{
+		GenesisBlock:                 genesisBlock,
+		PersistentRoundStatus:        persistentRoundStatus,
+		RoundStatus:                  roundStatus,
+		NonFinalizedTransactionCount: s.GetNonFinalizedTransactionCount(),
+		TransactionTablePurgeCounter: s.GetTransactionTablePurgeCounter(),
+		BlockTable: BlockTableSummary{
+			DeadBlockCacheSize: s.GetBlockTable().GetDeadBlockCacheSize(),
+			LiveBlocks:         liveBlocks,
+		},
+		Branches:                 branches,
+		RoundExistingBlocks:      roundExistingBlocks,
+		RoundExistingQCs:         roundExistingQCs,
+		GenesisBlockHeight:       AbsoluteBlockHeight{Value: s.GetGenesisBlockHeight().GetValue()},
+		LastFinalizedBlock:       lastFinalizedBlock,
+		LastFinalizedBlockHeight: BlockHeight{Value: s.GetLastFinalizedBlockHeight().GetValue()},
+		LatestFinalizationEntry:  latestFinalizationEntry,
+		EpochBakers:              parseEpochBakers(s.GetEpochBakers()),
+		TimeoutMessages:          timeoutMessages,
+		TerminalBlock:            terminalBlock,
+	}, nil
+}
+
+// Parses []*pb.BlockHash to []BlockHash.
+func parseBlockHashes(hashes []*pb.BlockHash) ([]BlockHash, error) {
+	res := make([]BlockHash, 0, len(hashes))
+	for _, h := range hashes {
+		hash, err := BlockHashFromBytes(h.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, hash)
+	}
+	return res, nil
+}
+
+// Parses []*pb.FinalizerIndex to []FinalizerIndex.
+func parseFinalizerIndices(indices []*pb.FinalizerIndex) []FinalizerIndex {
+	res := make([]FinalizerIndex, 0, len(indices))
+	for _, i := range indices {
+		res = append(res, FinalizerIndex{Value: i.GetValue()})
+	}
+	return res
+}
+
+// Parses *pb.RawQuorumCertificate to QuorumCertificate.
+func parseRawQuorumCertificate(qc *pb.RawQuorumCertificate) (QuorumCertificate, error) {
+	hash, err := BlockHashFromBytes(qc.GetBlockHash().GetValue())
+	if err != nil {
+		return QuorumCertificate{}, errors.New("Error parsing QuorumCertificate: " + err.Error())
+	}
+	return QuorumCertificate{
+		BlockHash:          hash,
+		Round:              Round{Value: qc.GetRound().GetValue()},
+		Epoch:              Epoch{Value: qc.GetEpoch().GetValue()},
+		AggregateSignature: qc.GetAggregateSignature().GetValue(),
+		Signatories:        parseFinalizerIndices(qc.GetSignatories()),
+	}, nil
+}
+
+// Parses []*pb.RawFinalizerRound to []FinalizerRound.
+func parseFinalizerRounds(rounds []*pb.RawFinalizerRound) []FinalizerRound {
+	res := make([]FinalizerRound, 0, len(rounds))
+	for _, r := range rounds {
+		res = append(res, FinalizerRound{
+			Round:      Round{Value: r.GetRound().GetValue()},
+			Finalizers: parseFinalizerIndices(r.GetFinalizers()),
+		})
+	}
+	return res
+}
+
+// Parses *pb.RawTimeoutCertificate to TimeoutCertificate.
+func parseRawTimeoutCertificate(tc *pb.RawTimeoutCertificate) TimeoutCertificate {
+	return TimeoutCertificate{
+		Round:               Round{Value: tc.GetRound().GetValue()},
+		MinEpoch:            Epoch{Value: tc.GetMinEpoch().GetValue()},
+		QcRoundsFirstEpoch:  parseFinalizerRounds(tc.GetQcRoundsFirstEpoch()),
+		QcRoundsSecondEpoch: parseFinalizerRounds(tc.GetQcRoundsSecondEpoch()),
+		AggregateSignature:  tc.GetAggregateSignature().GetValue(),
+	}
+}
+
+// Parses *pb.RawFinalizationEntry to FinalizationEntry.
+func parseFinalizationEntry(e *pb.RawFinalizationEntry) (FinalizationEntry, error) {
+	finalizedQC, err := parseRawQuorumCertificate(e.GetFinalizedQc())
+	if err != nil {
+		return FinalizationEntry{}, errors.New("Error parsing FinalizationEntry: " + err.Error())
+	}
+	successorQC, err := parseRawQuorumCertificate(e.GetSuccessorQc())
+	if err != nil {
+		return FinalizationEntry{}, errors.New("Error parsing FinalizationEntry: " + err.Error())
+	}
+	return FinalizationEntry{
+		FinalizedQC:    finalizedQC,
+		SuccessorQC:    successorQC,
+		SuccessorProof: e.GetSuccessorProof().GetValue(),
+	}, nil
+}
+
+// Parses *pb.TimeoutMessage to TimeoutMessage.
+func parseTimeoutMessage(m *pb.TimeoutMessage) (TimeoutMessage, error) {
+	qc, err := parseRawQuorumCertificate(m.GetQuorumCertificate())
+	if err != nil {
+		return TimeoutMessage{}, errors.New("Error parsing TimeoutMessage: " + err.Error())
+	}
+	return TimeoutMessage{
+		Finalizer:         FinalizerIndex{Value: m.GetFinalizer().GetValue()},
+		Round:             Round{Value: m.GetRound().GetValue()},
+		Epoch:             Epoch{Value: m.GetEpoch().GetValue()},
+		QuorumCertificate: qc,
+		Signature:         m.GetSignature().GetValue(),
+		MessageSignature:  m.GetMessageSignature().GetValue(),
+	}, nil
+}
+
+// Parses []*pb.TimeoutMessage to []TimeoutMessage.
+func parseTimeoutMessages(messages []*pb.TimeoutMessage) ([]TimeoutMessage, error) {
+	res := make([]TimeoutMessage, 0, len(messages))
+	for _, m := range messages {
+		message, err := parseTimeoutMessage(m)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, message)
+	}
+	return res, nil
+}
+
+// Parses *pb.PersistentRoundStatus to PersistentRoundStatus.
+func parsePersistentRoundStatus(s *pb.PersistentRoundStatus) (PersistentRoundStatus, error) {
+	var lastSignedQuorumMessage *QuorumMessage
+	if m := s.GetLastSignedQuorumMessage(); m != nil {
+		block, err := BlockHashFromBytes(m.GetBlock().GetValue())
+		if err != nil {
+			return PersistentRoundStatus{}, errors.New("Error parsing PersistentRoundStatus: " + err.Error())
+		}
+		lastSignedQuorumMessage = &QuorumMessage{
+			Signature: m.GetSignature().GetValue(),
+			Block:     block,
+			Finalizer: FinalizerIndex{Value: m.GetFinalizer().GetValue()},
+			Round:     Round{Value: m.GetRound().GetValue()},
+			Epoch:     Epoch{Value: m.GetEpoch().GetValue()},
+		}
+	}
+
+	var lastSignedTimeoutMessage *TimeoutMessage
+	if m := s.GetLastSignedTimeoutMessage(); m != nil {
+		message, err := parseTimeoutMessage(m)
+		if err != nil {
+			return PersistentRoundStatus{}, errors.New("Error parsing PersistentRoundStatus: " + err.Error())
+		}
+		lastSignedTimeoutMessage = &message
+	}
+
+	var latestTimeout *TimeoutCertificate
+	if s.GetLatestTimeout() != nil {
+		tc := parseRawTimeoutCertificate(s.GetLatestTimeout())
+		latestTimeout = &tc
+	}
+
+	return PersistentRoundStatus{
+		LastSignedQuorumMessage:  lastSignedQuorumMessage,
+		LastSignedTimeoutMessage: lastSignedTimeoutMessage,
+		LastBakedRound:           Round{Value: s.GetLastBakedRound().GetValue()},
+		LatestTimeout:            latestTimeout,
+	}, nil
+}
+
+// Parses *pb.RoundStatus to RoundStatus.
+func parseRoundStatus(s *pb.RoundStatus) (RoundStatus, error) {
+	highestCertifiedBlock, err := parseRawQuorumCertificate(s.GetHighestCertifiedBlock())
+	if err != nil {
+		return RoundStatus{}, errors.New("Error parsing RoundStatus: " + err.Error())
+	}
+
+	var previousRoundTimeout *RoundTimeout
+	if t := s.GetPreviousRoundTimeout(); t != nil {
+		qc, err := parseRawQuorumCertificate(t.GetQuorumCertificate())
+		if err != nil {
+			return RoundStatus{}, errors.New("Error parsing RoundStatus: " + err.Error())
+		}
+		previousRoundTimeout = &RoundTimeout{
+			TimeoutCertificate: parseRawTimeoutCertificate(t.GetTimeoutCertificate()),
+			QuorumCertificate:  qc,
+		}
+	}
+
+	var lastEpochFinalizationEntry *FinalizationEntry
+	if s.GetLastEpochFinalizationEntry() != nil {
+		entry, err := parseFinalizationEntry(s.GetLastEpochFinalizationEntry())
+		if err != nil {
+			return RoundStatus{}, errors.New("Error parsing RoundStatus: " + err.Error())
+		}
+		lastEpochFinalizationEntry = &entry
+	}
+
+	return RoundStatus{
+		CurrentRound:               Round{Value: s.GetCurrentRound().GetValue()},
+		HighestCertifiedBlock:      highestCertifiedBlock,
+		PreviousRoundTimeout:       previousRoundTimeout,
+		RoundEligibleToBake:        s.GetRoundEligibleToBake(),
+		CurrentEpoch:               Epoch{Value: s.GetCurrentEpoch().GetValue()},
+		LastEpochFinalizationEntry: lastEpochFinalizationEntry,
+		CurrentTimeout:             time.Duration(s.GetCurrentTimeout().GetValue()) * time.Millisecond,
+	}, nil
+}
+
+// Parses *pb.BakersAndFinalizers to BakersAndFinalizers.
+func parseBakersAndFinalizers(b *pb.BakersAndFinalizers) BakersAndFinalizers {
+	bakers := make([]FullBakerInfo, 0, len(b.GetBakers()))
+	for _, baker := range b.GetBakers() {
+		bakers = append(bakers, FullBakerInfo{
+			BakerId:              BakerId{Value: baker.GetBakerIdentity().GetValue()},
+			ElectionVerifyKey:    BakerElectionVerifyKey{Value: baker.GetElectionVerifyKey().GetValue()},
+			SignatureVerifyKey:   BakerSignatureVerifyKey{Value: baker.GetSignatureVerifyKey().GetValue()},
+			AggregationVerifyKey: BakerAggregationVerifyKey{Value: baker.GetAggregationVerifyKey().GetValue()},
+			Stake:                Amount{Value: baker.GetStake().GetValue()},
+		})
+	}
+
+	finalizers := make([]BakerId, 0, len(b.GetFinalizers()))
+	for _, f := range b.GetFinalizers() {
+		finalizers = append(finalizers, BakerId{Value: f.GetValue()})
+	}
+
+	return BakersAndFinalizers{
+		Bakers:                    bakers,
+		Finalizers:                finalizers,
+		BakerTotalStake:           Amount{Value: b.GetBakerTotalStake().GetValue()},
+		FinalizerTotalStake:       Amount{Value: b.GetFinalizerTotalStake().GetValue()},
+		FinalizationCommitteeHash: b.GetFinalizationCommitteeHash().GetValue(),
+	}
+}
+
+// Parses *pb.EpochBakers to EpochBakers.
+func parseEpochBakers(e *pb.EpochBakers) EpochBakers {
+	var current, next *BakersAndFinalizers
+	if e.GetCurrentEpochBakers() != nil {
+		b := parseBakersAndFinalizers(e.GetCurrentEpochBakers())
+		current = &b
+	}
+	if e.GetNextEpochBakers() != nil {
+		b := parseBakersAndFinalizers(e.GetNextEpochBakers())
+		next = &b
+	}
+
+	return EpochBakers{
+		PreviousEpochBakers: parseBakersAndFinalizers(e.GetPreviousEpochBakers()),
+		CurrentEpochBakers:  current,
+		NextEpochBakers:     next,
+		NextPayday:          Epoch{Value: e.GetNextPayday().GetValue()},
+	}
+}
@@ -0,0 +1,104 @@
+package v2
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// refreshMargin is how long before their expiry RefreshingCredentials are refreshed.
+const refreshMargin = 30 * time.Second
+
+// StaticCredentials per-RPC credentials that attach the same metadata, for example an API key header,
+// to every request. Use them as Config.PerRPCCredentials.
+type StaticCredentials struct {
+	Metadata map[string]string
+	// Whether the credentials may be sent over a connection without TLS.
+	AllowInsecure bool
+}
+
+// GetRequestMetadata returns the metadata attached to a request.
+func (c StaticCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return c.Metadata, nil
+}
+
+// RequireTransportSecurity returns whether the credentials may only be sent over TLS.
+func (c StaticCredentials) RequireTransportSecurity() bool {
+	return !c.AllowInsecure
+}
+
+// RefreshFunc fetches fresh credentials, for example a token from an authentication server, together with the
+// time they expire. A zero expiry means that the credentials never expire.
+type RefreshFunc func(ctx context.Context) (metadata map[string]string, expiry time.Time, err error)
+
+// RefreshingCredentials per-RPC credentials that are fetched on first use and fetched again shortly before they
+// expire. Use them as Config.PerRPCCredentials.
+type RefreshingCredentials struct {
+	refresh       RefreshFunc
+	allowInsecure bool
+
+	mu       sync.Mutex
+	metadata map[string]string
+	expiry   time.Time
+	fetched  bool
+}
+
+// NewRefreshingCredentials creates RefreshingCredentials that are fetched with refresh. If allowInsecure is set,
+// the credentials may be sent over a connection without TLS.
+func NewRefreshingCredentials(refresh RefreshFunc, allowInsecure bool) *RefreshingCredentials {
+	return &RefreshingCredentials{refresh: refresh, allowInsecure: allowInsecure}
+}
+
+// GetRequestMetadata returns the current credentials, refreshing them if they are about to expire.
+func (c *RefreshingCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.fetched || (!c.expiry.IsZero() && time.Now().Add(refreshMargin).After(c.expiry)) {
+		metadata, expiry, err := c.refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.metadata, c.expiry, c.fetched = metadata, expiry, true
+	}
+	return c.metadata, nil
+}
+
+// RequireTransportSecurity returns whether the credentials may only be sent over TLS.
+func (c *RefreshingCredentials) RequireTransportSecurity() bool {
+	return !c.allowInsecure
+}
+
+// Invalidate discards the current credentials, so that they are fetched again on the next request. Use it when
+// the node rejects the credentials before they expire.
+func (c *RefreshingCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetched = false
+}
+
+// appendMetadata adds the metadata to the outgoing metadata of the context.
+func appendMetadata(ctx context.Context, md map[string]string) context.Context {
+	pairs := make([]string, 0, 2*len(md))
+	for key, value := range md {
+		pairs = append(pairs, key, value)
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// metadataUnaryInterceptor adds the metadata to every unary request.
+func metadataUnaryInterceptor(md map[string]string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(appendMetadata(ctx, md), method, req, reply, cc, opts...)
+	}
+}
+
+// metadataStreamInterceptor adds the metadata to every streaming request.
+func metadataStreamInterceptor(md map[string]string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(appendMetadata(ctx, md), desc, cc, method, opts...)
+	}
+}
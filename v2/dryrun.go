@@ -0,0 +1,554 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrDryRunQuotaExceeded indicates that the node terminated the dry-run session because the energy quota was exceeded.
+	ErrDryRunQuotaExceeded = errors.New("dry run energy quota exceeded")
+	// ErrDryRunTimeout indicates that the node terminated the dry-run session because the session timeout elapsed.
+	ErrDryRunTimeout = errors.New("dry run session timed out")
+	// ErrDryRunUnexpectedResponse indicates that the node responded with a response of an unexpected kind.
+	ErrDryRunUnexpectedResponse = errors.New("unexpected dry run response")
+	// ErrDryRunMissingPayload indicates that a transaction to dry run has no payload.
+	ErrDryRunMissingPayload = errors.New("dry run transaction has no payload")
+)
+
+// DryRun starts a new dry-run session on the node. A dry-run session makes it possible to run a series of
+// queries, operations and transactions on a block state without affecting the chain.
+// The first request in the session should be DryRunSession.LoadBlockState, every other request will fail with
+// DryRunNoStateError until a block state is successfully loaded.
+//
+// The node limits the total energy that may be expended in a session and the duration of a session.
+// These limits are available via DryRunSession.Quota and DryRunSession.Timeout. If the quota is exceeded
+// the session fails with ErrDryRunQuotaExceeded and if the timeout elapses the session fails with ErrDryRunTimeout.
+//
+// The session must be closed with DryRunSession.Close when it is no longer needed.
+//
+// This endpoint is only supported for protocol version 6 and onwards.
+func (c *Client) DryRun(ctx context.Context) (_ *DryRunSession, err error) {
+	stream, err := c.GrpcClient.DryRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		return nil, convertDryRunError(err)
+	}
+
+	session := &DryRunSession{stream: stream}
+	if v := header.Get("quota"); len(v) > 0 {
+		quota, err := strconv.ParseUint(v[0], 10, 64)
+		if err == nil {
+			session.quota = Energy{Value: quota}
+			session.quotaRemaining = session.quota
+		}
+	}
+	if v := header.Get("timeout"); len(v) > 0 {
+		timeout, err := strconv.ParseUint(v[0], 10, 64)
+		if err == nil {
+			session.timeout = timeout
+		}
+	}
+
+	return session, nil
+}
+
+// DryRunSession a dry-run session opened with Client.DryRun. Requests in a session are processed in order,
+// so a DryRunSession must not be used concurrently from multiple goroutines.
+type DryRunSession struct {
+	stream         pb.Queries_DryRunClient
+	quota          Energy
+	quotaRemaining Energy
+	timeout        uint64
+}
+
+// Quota returns the total energy quota of the session, as reported by the node.
+func (s *DryRunSession) Quota() Energy {
+	return s.quota
+}
+
+// QuotaRemaining returns the energy quota remaining after the last request in the session.
+func (s *DryRunSession) QuotaRemaining() Energy {
+	return s.quotaRemaining
+}
+
+// Timeout returns the session timeout in milliseconds, as reported by the node.
+func (s *DryRunSession) Timeout() uint64 {
+	return s.timeout
+}
+
+// Close closes the session. The node releases the block state associated with the session.
+func (s *DryRunSession) Close() error {
+	if err := s.stream.CloseSend(); err != nil {
+		return err
+	}
+	for {
+		_, err := s.stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return convertDryRunError(err)
+		}
+	}
+}
+
+// LoadBlockState loads the state of the specified block to use for subsequent requests.
+// The state is taken at the end of execution of the block, and the block’s timestamp is used as the current timestamp.
+//
+// The energy cost for this operation is 2000.
+func (s *DryRunSession) LoadBlockState(b isBlockHashInput) (_ DryRunBlockStateLoaded, err error) {
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_LoadBlockState{LoadBlockState: convertBlockHashInput(b)},
+	})
+	if err != nil {
+		return DryRunBlockStateLoaded{}, err
+	}
+
+	loaded, ok := res.Response.(*pb.DryRunSuccessResponse_BlockStateLoaded_)
+	if !ok {
+		return DryRunBlockStateLoaded{}, ErrDryRunUnexpectedResponse
+	}
+
+	blockHash, err := parseBlockHash(loaded.BlockStateLoaded.BlockHash)
+	if err != nil {
+		return DryRunBlockStateLoaded{}, err
+	}
+
+	return DryRunBlockStateLoaded{
+		CurrentTimestamp: parseTimestamp(loaded.BlockStateLoaded.CurrentTimestamp),
+		BlockHash:        blockHash,
+		ProtocolVersion:  ProtocolVersion{Value: int32(loaded.BlockStateLoaded.ProtocolVersion)},
+	}, nil
+}
+
+// GetAccountInfo looks up information on a particular account in the current state of the session.
+// The account can be identified by its AccountAddress, a CredentialRegistrationId of one of its credentials or its AccountIndex.
+//
+// The energy cost for this query is 200.
+func (s *DryRunSession) GetAccountInfo(accId isAccountIdentifier) (_ AccountInfo, err error) {
+	accountIdentifier, err := convertAccountIdentifier(accId)
+	if err != nil {
+		return AccountInfo{}, err
+	}
+
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_StateQuery{StateQuery: &pb.DryRunStateQuery{
+			Query: &pb.DryRunStateQuery_GetAccountInfo{GetAccountInfo: accountIdentifier},
+		}},
+	})
+	if err != nil {
+		return AccountInfo{}, err
+	}
+
+	accountInfo, ok := res.Response.(*pb.DryRunSuccessResponse_AccountInfo)
+	if !ok {
+		return AccountInfo{}, ErrDryRunUnexpectedResponse
+	}
+
+	return parseAccountInfo(accountInfo.AccountInfo)
+}
+
+// GetInstanceInfo looks up information about a particular smart contract in the current state of the session.
+//
+// The energy cost for this query is 200.
+func (s *DryRunSession) GetInstanceInfo(address ContractAddress) (_ InstanceInfo, err error) {
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_StateQuery{StateQuery: &pb.DryRunStateQuery{
+			Query: &pb.DryRunStateQuery_GetInstanceInfo{GetInstanceInfo: &pb.ContractAddress{
+				Index:    address.Index,
+				Subindex: address.Subindex,
+			}},
+		}},
+	})
+	if err != nil {
+		return InstanceInfo{}, err
+	}
+
+	instanceInfo, ok := res.Response.(*pb.DryRunSuccessResponse_InstanceInfo)
+	if !ok {
+		return InstanceInfo{}, ErrDryRunUnexpectedResponse
+	}
+
+	return parseInstanceInfo(instanceInfo.InstanceInfo)
+}
+
+// InvokeInstance invokes an entrypoint on a smart contract instance in the current state of the session.
+// No changes made to the state are retained at the completion of the operation.
+// If the invocation fails, the returned error is a *DryRunInvokeFailedError.
+//
+// The energy cost for this query is 200 plus the energy used by the smart contract execution.
+func (s *DryRunSession) InvokeInstance(input DryRunInvokeInstance) (_ DryRunInvokeSuccess, err error) {
+	req := &pb.DryRunInvokeInstance{
+		Instance: &pb.ContractAddress{
+			Index:    input.Instance.Index,
+			Subindex: input.Instance.Subindex,
+		},
+		Amount:     &pb.Amount{Value: input.Amount.Value},
+		Entrypoint: &pb.ReceiveName{Value: input.Entrypoint.Value},
+		Parameter:  &pb.Parameter{Value: input.Parameter.Value},
+		Energy:     &pb.Energy{Value: input.Energy.Value},
+	}
+	if input.Invoker != nil {
+		req.Invoker = convertAddress(input.Invoker)
+	}
+
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_StateQuery{StateQuery: &pb.DryRunStateQuery{
+			Query: &pb.DryRunStateQuery_InvokeInstance{InvokeInstance: req},
+		}},
+	})
+	if err != nil {
+		return DryRunInvokeSuccess{}, err
+	}
+
+	invoked, ok := res.Response.(*pb.DryRunSuccessResponse_InvokeSucceeded)
+	if !ok {
+		return DryRunInvokeSuccess{}, ErrDryRunUnexpectedResponse
+	}
+
+	success, err := parseInvokeSuccess(invoked.InvokeSucceeded.GetReturnValue(), invoked.InvokeSucceeded.GetUsedEnergy(),
+		invoked.InvokeSucceeded.GetEffects())
+	if err != nil {
+		return DryRunInvokeSuccess{}, err
+	}
+	return DryRunInvokeSuccess(success), nil
+}
+
+// SetTimestamp sets the current block time to the given timestamp for the purposes of future transactions.
+//
+// The energy cost of this operation is 50.
+func (s *DryRunSession) SetTimestamp(timestamp Timestamp) error {
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_StateOperation{StateOperation: &pb.DryRunStateOperation{
+			Operation: &pb.DryRunStateOperation_SetTimestamp{SetTimestamp: &pb.Timestamp{Value: timestamp.Value}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := res.Response.(*pb.DryRunSuccessResponse_TimestampSet_); !ok {
+		return ErrDryRunUnexpectedResponse
+	}
+
+	return nil
+}
+
+// MintToAccount adds a specified amount of newly-minted CCDs to a specified account.
+// The amount cannot cause the total circulating supply to overflow, in which case a *DryRunAmountOverLimitError is returned.
+//
+// The energy cost of this operation is 400.
+func (s *DryRunSession) MintToAccount(account AccountAddress, amount Amount) error {
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_StateOperation{StateOperation: &pb.DryRunStateOperation{
+			Operation: &pb.DryRunStateOperation_MintToAccount{MintToAccount: &pb.DryRunMintToAccount{
+				Account: &pb.AccountAddress{Value: account.Value[:]},
+				Amount:  &pb.Amount{Value: amount.Value},
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, ok := res.Response.(*pb.DryRunSuccessResponse_MintedToAccount_); !ok {
+		return ErrDryRunUnexpectedResponse
+	}
+
+	return nil
+}
+
+// RunTransaction dry runs a transaction, updating the state of the session if it succeeds.
+// Note that a transaction which is rejected is still executed, and the rejection is reported in the outcome.
+//
+// The energy cost of this operation is 400 plus the energy used by executing the transaction.
+func (s *DryRunSession) RunTransaction(transaction DryRunTransaction) (_ DryRunTransactionExecuted, err error) {
+	if transaction.Payload == nil || transaction.Payload.Payload == nil {
+		return DryRunTransactionExecuted{}, ErrDryRunMissingPayload
+	}
+
+	signatures := make([]*pb.DryRunSignature, 0, len(transaction.Signatures))
+	for _, signature := range transaction.Signatures {
+		signatures = append(signatures, &pb.DryRunSignature{
+			Credential: uint32(signature.Credential),
+			Key:        uint32(signature.Key),
+		})
+	}
+
+	res, err := s.request(&pb.DryRunRequest{
+		Request: &pb.DryRunRequest_StateOperation{StateOperation: &pb.DryRunStateOperation{
+			Operation: &pb.DryRunStateOperation_RunTransaction{RunTransaction: &pb.DryRunTransaction{
+				Sender:       &pb.AccountAddress{Value: transaction.Sender.Value[:]},
+				EnergyAmount: &pb.Energy{Value: transaction.EnergyAmount.Value},
+				Payload: &pb.AccountTransactionPayload{Payload: &pb.AccountTransactionPayload_RawPayload{
+					RawPayload: transaction.Payload.Payload.Encode().Value,
+				}},
+				Signatures: signatures,
+			}},
+		}},
+	})
+	if err != nil {
+		return DryRunTransactionExecuted{}, err
+	}
+
+	executed, ok := res.Response.(*pb.DryRunSuccessResponse_TransactionExecuted_)
+	if !ok {
+		return DryRunTransactionExecuted{}, ErrDryRunUnexpectedResponse
+	}
+
+	details, err := parseAccountTransactionDetails(executed.TransactionExecuted.Details)
+	if err != nil {
+		return DryRunTransactionExecuted{}, err
+	}
+
+	return DryRunTransactionExecuted{
+		EnergyCost:  Energy{Value: executed.TransactionExecuted.GetEnergyCost().GetValue()},
+		Details:     details,
+		ReturnValue: executed.TransactionExecuted.ReturnValue,
+	}, nil
+}
+
+// request sends a request in the session and waits for its response. Error responses are converted to typed errors.
+func (s *DryRunSession) request(req *pb.DryRunRequest) (_ *pb.DryRunSuccessResponse, err error) {
+	if err := s.stream.Send(req); err != nil {
+		if err == io.EOF {
+			// The actual status of the stream is returned by Recv.
+			_, err = s.stream.Recv()
+		}
+		return nil, convertDryRunError(err)
+	}
+
+	res, err := s.stream.Recv()
+	if err != nil {
+		return nil, convertDryRunError(err)
+	}
+
+	if res.QuotaRemaining != nil {
+		s.quotaRemaining = Energy{Value: res.GetQuotaRemaining().GetValue()}
+	}
+
+	switch r := res.Response.(type) {
+	case *pb.DryRunResponse_Error:
+		return nil, parseDryRunErrorResponse(r.Error)
+	case *pb.DryRunResponse_Success:
+		return r.Success, nil
+	}
+
+	return nil, ErrDryRunUnexpectedResponse
+}
+
+// convertDryRunError converts gRPC status errors that terminate a dry-run session to ErrDryRunQuotaExceeded or ErrDryRunTimeout.
+func convertDryRunError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch status.Code(err) {
+	case codes.ResourceExhausted:
+		return fmt.Errorf("%w: %v", ErrDryRunQuotaExceeded, err)
+	case codes.DeadlineExceeded:
+		return fmt.Errorf("%w: %v", ErrDryRunTimeout, err)
+	}
+
+	return err
+}
+
+// DryRunBlockStateLoaded the result of loading a block state in a dry-run session.
+type DryRunBlockStateLoaded struct {
+	// The timestamp of the block, taken to be the current timestamp.
+	CurrentTimestamp Timestamp
+	// The hash of the block that was loaded.
+	BlockHash BlockHash
+	// The protocol version at the specified block. The behavior of operations can vary across protocol versions.
+	ProtocolVersion ProtocolVersion
+}
+
+// DryRunInvokeInstance input for invoking an entrypoint on a smart contract instance in a dry-run session.
+type DryRunInvokeInstance struct {
+	// Invoker of the contract. If this is nil then the contract will be invoked by an account with address 0,
+	// no credentials and sufficient amount of CCD to cover the transfer amount. If given, the relevant address
+	// (either account or contract) must exist in the block state.
+	Invoker isAddress
+	// Address of the contract instance to invoke.
+	Instance ContractAddress
+	// Amount to invoke the smart contract instance with.
+	Amount Amount
+	// The entrypoint of the smart contract instance to invoke.
+	Entrypoint ReceiveName
+	// The parameter bytes to include in the invocation of the entrypoint.
+	Parameter Parameter
+	// The maximum energy to allow for the invocation. Note that the node imposes an energy quota
+	// that is enforced in addition to this limit.
+	Energy Energy
+}
+
+// DryRunInvokeSuccess the result of a successful invocation of a smart contract instance in a dry-run session.
+type DryRunInvokeSuccess struct {
+	// If invoking a V0 contract this is nil. Otherwise it is the return value produced by the contract.
+	ReturnValue []byte
+	// Energy used by the execution.
+	UsedEnergy Energy
+	// Effects produced by contract execution.
+	Effects []ContractTraceElement
+}
+
+// DryRunSignature identifies the credential and key that is presumed to have signed a dry-run transaction.
+// No actual cryptographic signature is included.
+type DryRunSignature struct {
+	Credential CredentialIndex
+	Key        KeyIndex
+}
+
+// DryRunTransaction an account transaction to dry run.
+type DryRunTransaction struct {
+	// The account to use as the sender of the transaction.
+	Sender AccountAddress
+	// The energy limit set for executing the transaction.
+	EnergyAmount Energy
+	// The payload of the transaction.
+	Payload *AccountTransactionPayload
+	// Which credentials and keys should be treated as having signed the transaction. If none is given,
+	// then the transaction is treated as having one signature for credential 0, key 0.
+	// Note that the signature thresholds are not checked as part of the dry run.
+	Signatures []DryRunSignature
+}
+
+// DryRunTransactionExecuted the result of executing a transaction in a dry-run session.
+type DryRunTransactionExecuted struct {
+	// The amount of energy actually expended in executing the transaction.
+	EnergyCost Energy
+	// The details of the outcome of the transaction. If the transaction was rejected, Details.Effects is TransactionRejected.
+	Details AccountTransactionDetails
+	// If this is an invocation of a V1 contract that produced a return value, this is that value. Otherwise it is nil.
+	ReturnValue []byte
+}
+
+// DryRunNoStateError the current block state is undefined. It should be initialized with
+// DryRunSession.LoadBlockState before any other operations.
+type DryRunNoStateError struct{}
+
+func (DryRunNoStateError) Error() string {
+	return "dry run: no block state loaded"
+}
+
+// DryRunBlockNotFoundError the requested block was not found, so its state could not be loaded.
+type DryRunBlockNotFoundError struct{}
+
+func (DryRunBlockNotFoundError) Error() string {
+	return "dry run: block not found"
+}
+
+// DryRunAccountNotFoundError the specified account was not found.
+type DryRunAccountNotFoundError struct{}
+
+func (DryRunAccountNotFoundError) Error() string {
+	return "dry run: account not found"
+}
+
+// DryRunInstanceNotFoundError the specified instance was not found.
+type DryRunInstanceNotFoundError struct{}
+
+func (DryRunInstanceNotFoundError) Error() string {
+	return "dry run: instance not found"
+}
+
+// DryRunAmountOverLimitError the amount to mint would overflow the total CCD supply.
+type DryRunAmountOverLimitError struct {
+	// The maximum amount that can be minted without overflowing the supply.
+	AmountLimit Amount
+}
+
+func (e *DryRunAmountOverLimitError) Error() string {
+	return fmt.Sprintf("dry run: amount over limit, the maximum amount that can be minted is %d microCCD", e.AmountLimit.Value)
+}
+
+// DryRunBalanceInsufficientError the balance of the sender account is not sufficient to pay for the operation.
+type DryRunBalanceInsufficientError struct {
+	// The minimum balance required to perform the operation.
+	RequiredAmount Amount
+	// The currently-available balance on the account to pay for the operation.
+	AvailableAmount Amount
+}
+
+func (e *DryRunBalanceInsufficientError) Error() string {
+	return fmt.Sprintf("dry run: balance insufficient, required %d microCCD, available %d microCCD",
+		e.RequiredAmount.Value, e.AvailableAmount.Value)
+}
+
+// DryRunEnergyInsufficientError the energy supplied for the transaction was not sufficient to perform the basic checks.
+type DryRunEnergyInsufficientError struct {
+	// The minimum energy required for the transaction to be included in the chain.
+	EnergyRequired Energy
+}
+
+func (e *DryRunEnergyInsufficientError) Error() string {
+	return fmt.Sprintf("dry run: energy insufficient, required %d NRG", e.EnergyRequired.Value)
+}
+
+// DryRunInvokeFailedError invoking the smart contract instance failed.
+type DryRunInvokeFailedError struct {
+	// If invoking a V0 contract this is nil, otherwise it is potentially return value produced by the call
+	// unless the call failed with out of energy or runtime error.
+	ReturnValue []byte
+	// Energy used by the execution.
+	UsedEnergy Energy
+	// Contract execution failed for the given reason.
+	Reason RejectReason
+}
+
+func (e *DryRunInvokeFailedError) Error() string {
+	return fmt.Sprintf("dry run: invoke failed: %v", e.Reason)
+}
+
+// Unwrap returns the RejectReason, so it can be inspected with errors.As.
+func (e *DryRunInvokeFailedError) Unwrap() error {
+	return e.Reason
+}
+
+// parseDryRunErrorResponse converts *pb.DryRunErrorResponse to a typed error.
+func parseDryRunErrorResponse(e *pb.DryRunErrorResponse) error {
+	switch v := e.Error.(type) {
+	case *pb.DryRunErrorResponse_NoState_:
+		return DryRunNoStateError{}
+	case *pb.DryRunErrorResponse_BlockNotFound_:
+		return DryRunBlockNotFoundError{}
+	case *pb.DryRunErrorResponse_AccountNotFound_:
+		return DryRunAccountNotFoundError{}
+	case *pb.DryRunErrorResponse_InstanceNotFound_:
+		return DryRunInstanceNotFoundError{}
+	case *pb.DryRunErrorResponse_AmountOverLimit_:
+		return &DryRunAmountOverLimitError{
+			AmountLimit: parseAmount(v.AmountOverLimit.AmountLimit),
+		}
+	case *pb.DryRunErrorResponse_BalanceInsufficient_:
+		return &DryRunBalanceInsufficientError{
+			RequiredAmount:  parseAmount(v.BalanceInsufficient.RequiredAmount),
+			AvailableAmount: parseAmount(v.BalanceInsufficient.AvailableAmount),
+		}
+	case *pb.DryRunErrorResponse_EnergyInsufficient_:
+		return &DryRunEnergyInsufficientError{
+			EnergyRequired: Energy{Value: v.EnergyInsufficient.GetEnergyRequired().GetValue()},
+		}
+	case *pb.DryRunErrorResponse_InvokeFailed:
+		failure, err := parseInvokeFailure(v.InvokeFailed.GetReturnValue(), v.InvokeFailed.GetUsedEnergy(), v.InvokeFailed.GetReason())
+		if err != nil {
+			return err
+		}
+		res := DryRunInvokeFailedError(failure)
+		return &res
+	}
+
+	return ErrDryRunUnexpectedResponse
+}
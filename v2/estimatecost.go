@@ -0,0 +1,61 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"math/big"
+)
+
+// TransactionCost the cost of a transaction, both in energy and in CCD.
+type TransactionCost struct {
+	// The energy amount of the transaction. This is the maximum energy the transaction can use.
+	Energy Energy
+	// The cost of the energy in microCCD, rounded up to a whole microCCD.
+	Amount Amount
+	// The exact cost of the energy in microCCD.
+	ExactAmount *big.Rat
+}
+
+// EstimateCost computes the cost of the transaction using the exchange rates in effect in the given block.
+// Since the energy amount in the header is the maximum energy the transaction can use, the actual cost may be lower.
+// An error is returned if the cost does not fit in an Amount.
+func (c *Client) EstimateCost(ctx context.Context, tx *PreAccountTransaction, block isBlockHashInput) (_ TransactionCost, err error) {
+	if tx == nil || tx.Header == nil || tx.Header.EnergyAmount == nil {
+		return TransactionCost{}, errors.New("transaction header or energy amount is not initialized")
+	}
+	chainParameters, err := c.GetBlockChainParameters(ctx, block)
+	if err != nil {
+		return TransactionCost{}, err
+	}
+
+	exact := EnergyToMicroCcd(*tx.Header.EnergyAmount, chainParameters.EuroPerEnergy(), chainParameters.MicroCcdPerEuro())
+	amount, ok := ceilRat(exact)
+	if !ok {
+		return TransactionCost{}, errors.New("cost of transaction exceeds the maximum amount of microCCD")
+	}
+	return TransactionCost{
+		Energy:      *tx.Header.EnergyAmount,
+		Amount:      Amount{Value: amount},
+		ExactAmount: exact,
+	}, nil
+}
+
+// EnergyToMicroCcd converts an energy amount to microCCD using the given exchange rates.
+func EnergyToMicroCcd(energy Energy, euroPerEnergy, microCcdPerEuro *big.Rat) *big.Rat {
+	res := new(big.Rat).SetInt(new(big.Int).SetUint64(energy.Value))
+	res.Mul(res, euroPerEnergy)
+	return res.Mul(res, microCcdPerEuro)
+}
+
+// ceilRat returns the smallest integer not less than the non-negative rational r, and false if it does not fit
+// in an uint64.
+func ceilRat(r *big.Rat) (uint64, bool) {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if !q.IsUint64() {
+		return 0, false
+	}
+	return q.Uint64(), true
+}
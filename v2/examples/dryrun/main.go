@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	v2 "github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// This example loads the state of the last finalized block in a dry-run session, mints CCD to
+// an account and dry runs a transfer from it.
+func main() {
+	client, err := v2.NewClient(v2.Config{NodeAddress: "node.testnet.concordium.com:20000"})
+	if err != nil {
+		log.Fatalf("Failed to instantiate client, err: %v", err)
+	}
+
+	// sending empty context, can also use any other context instead.
+	session, err := client.DryRun(context.TODO())
+	if err != nil {
+		log.Fatalf("failed to start dry run session, err: %v", err)
+	}
+	defer session.Close()
+
+	loaded, err := session.LoadBlockState(v2.BlockHashInputLastFinal{})
+	if err != nil {
+		log.Fatalf("failed to load block state, err: %v", err)
+	}
+	fmt.Println("loaded block: ", loaded.BlockHash.Hex())
+
+	accounts, err := client.GetAccountList(context.TODO(), v2.BlockHashInputGiven{Given: loaded.BlockHash})
+	if err != nil {
+		log.Fatalf("failed to get account list, err: %v", err)
+	}
+	sender, receiver := *accounts[0], *accounts[1]
+
+	if err = session.MintToAccount(sender, v2.Amount{Value: 1_000_000}); err != nil {
+		log.Fatalf("failed to mint to account, err: %v", err)
+	}
+
+	executed, err := session.RunTransaction(v2.DryRunTransaction{
+		Sender:       sender,
+		EnergyAmount: v2.Energy{Value: 5000},
+		Payload: &v2.AccountTransactionPayload{Payload: &v2.Transfer{Payload: &v2.TransferPayload{
+			Receiver: &receiver,
+			Amount:   &v2.Amount{Value: 10},
+		}}},
+	})
+	if err != nil {
+		log.Fatalf("failed to run transaction, err: %v", err)
+	}
+
+	if rejected, ok := executed.Details.Effects.(v2.TransactionRejected); ok {
+		log.Fatalf("transaction would be rejected, reason: %v", rejected.Reason)
+	}
+
+	fmt.Println("energy cost: ", executed.EnergyCost.Value)
+	fmt.Println("remaining quota: ", session.QuotaRemaining().Value)
+}
@@ -6,7 +6,6 @@ import (
 	"log"
 
 	"github.com/Concordium/concordium-go-sdk/v2"
-	"github.com/Concordium/concordium-go-sdk/v2/pb"
 )
 
 // in this example we receive stream of events, receive all data and print.
@@ -20,7 +19,7 @@ func main() {
 		log.Fatalf("failed to get block transaction events, err: %v", err)
 	}
 
-	var totalSummaries []*pb.BlockItemSummary
+	var totalSummaries []v2.BlockItemSummary
 
 	for err == nil {
 		blockTxEvent, err := blockTxEventsStream.Recv()
@@ -37,6 +36,7 @@ func main() {
 
 	// print all events.
 	for i := 0; i < len(totalSummaries); i++ {
-		fmt.Println("event: ", totalSummaries[i].String())
+		fmt.Printf("event: %s, success: %t, affected accounts: %d\n",
+			totalSummaries[i].Hash.Hex(), totalSummaries[i].IsSuccess(), len(totalSummaries[i].AffectedAccounts()))
 	}
 }
@@ -12,7 +12,6 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 
 	"github.com/Concordium/concordium-go-sdk/v2"
-	"github.com/Concordium/concordium-go-sdk/v2/pb"
 	"github.com/Concordium/concordium-go-sdk/v2/transactions/send"
 )
 
@@ -119,36 +118,41 @@ func main() {
 	}
 	fmt.Println("transaction hash: ", txHash.Hex())
 
-	// wait till transaction status turns Finalized
-	time.Sleep(25 * time.Second)
-
-	status, err := client.GetBlockItemStatus(ctx, *txHash)
+	// wait till transaction is finalized, printing status changes on the way.
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	outcome, err := client.WaitUntilFinalizedWithCallback(ctx, *txHash, func(status v2.BlockItemStatus) {
+		switch s := status.Status.(type) {
+		case v2.BlockItemStatusReceived:
+			fmt.Println("transaction received")
+		case v2.BlockItemStatusCommitted:
+			fmt.Printf("transaction committed in %d blocks\n", len(s.Outcomes))
+		case v2.BlockItemStatusFinalized:
+			fmt.Println("transaction finalized")
+		}
+	})
 	if err != nil {
-		log.Fatalf("failed to get block item status, err: %v", err)
+		log.Fatalf("failed to wait until finalized, err: %v", err)
+	}
+	if !outcome.Outcome.IsSuccess() {
+		log.Fatalf("transaction rejected, reason: %v", outcome.Outcome.RejectReason())
 	}
 
-	switch v := status.Status.(type) {
-	case *pb.BlockItemStatus_Finalized_:
-		var bh [32]byte
-		copy(bh[:], v.Finalized.Outcome.BlockHash.Value)
-
-		// verify that transaction exists on block
-		items, err := client.GetBlockItems(ctx, v2.BlockHashInputGiven{
-			Given: v2.BlockHash{
-				Value: bh,
-			}})
-		if err != nil {
-			log.Fatalf("failed to get block item, err: %v", err)
-		}
-		fmt.Println("block item hash:", items[0].Hash.Hex())
+	// verify that transaction exists on block
+	items, err := client.GetBlockItems(ctx, v2.BlockHashInputGiven{
+		Given: outcome.BlockHash,
+	})
+	if err != nil {
+		log.Fatalf("failed to get block item, err: %v", err)
+	}
+	fmt.Println("block item hash:", items[0].Hash.Hex())
 
-		// compare transaction hash value
-		for _, item := range items {
-			if item.Hash.Value == txHash.Value {
-				return
-			}
+	// compare transaction hash value
+	for _, item := range items {
+		if item.Hash.Value == txHash.Value {
+			return
 		}
-
-		log.Fatalf("tx hash not match expected")
 	}
+
+	log.Fatalf("tx hash not match expected")
 }
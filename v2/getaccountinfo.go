@@ -7,14 +7,20 @@ import (
 )
 
 // GetAccountInfo retrieve the information about the given account in the given block.
-func (c *Client) GetAccountInfo(ctx context.Context, accId *pb.AccountIdentifierInput, b isBlockHashInput) (_ *pb.AccountInfo, err error) {
+// The account can be identified by its AccountAddress, a CredentialRegistrationId of one of its credentials or its AccountIndex.
+func (c *Client) GetAccountInfo(ctx context.Context, accId isAccountIdentifier, b isBlockHashInput) (_ AccountInfo, err error) {
+	accountIdentifier, err := convertAccountIdentifier(accId)
+	if err != nil {
+		return AccountInfo{}, err
+	}
+
 	accountInfo, err := c.GrpcClient.GetAccountInfo(ctx, &pb.AccountInfoRequest{
 		BlockHash:         convertBlockHashInput(b),
-		AccountIdentifier: accId,
+		AccountIdentifier: accountIdentifier,
 	})
 	if err != nil {
-		return &pb.AccountInfo{}, err
+		return AccountInfo{}, err
 	}
 
-	return accountInfo, nil
+	return parseAccountInfo(accountInfo)
 }
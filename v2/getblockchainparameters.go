@@ -2,16 +2,14 @@ package v2
 
 import (
 	"context"
-
-	"github.com/Concordium/concordium-go-sdk/v2/pb"
 )
 
 // GetBlockChainParameters get the values of chain parameters in effect in the given block.
-func (c *Client) GetBlockChainParameters(ctx context.Context, req isBlockHashInput) (_ *pb.ChainParameters, err error) {
+func (c *Client) GetBlockChainParameters(ctx context.Context, req isBlockHashInput) (_ ChainParameters, err error) {
 	chainParameters, err := c.GrpcClient.GetBlockChainParameters(ctx, convertBlockHashInput(req))
 	if err != nil {
-		return &pb.ChainParameters{}, err
+		return nil, err
 	}
 
-	return chainParameters, nil
+	return parseChainParameters(chainParameters)
 }
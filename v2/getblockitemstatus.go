@@ -7,13 +7,13 @@ import (
 )
 
 // GetBlockItemStatus get the status of and information about a specific block item (transaction).
-func (c *Client) GetBlockItemStatus(ctx context.Context, req TransactionHash) (_ *pb.BlockItemStatus, err error) {
+func (c *Client) GetBlockItemStatus(ctx context.Context, req TransactionHash) (_ BlockItemStatus, err error) {
 	blockItemStatus, err := c.GrpcClient.GetBlockItemStatus(ctx, &pb.TransactionHash{
 		Value: req.Value[:],
 	})
 	if err != nil {
-		return &pb.BlockItemStatus{}, err
+		return BlockItemStatus{}, err
 	}
 
-	return blockItemStatus, nil
+	return parseBlockItemStatus(blockItemStatus)
 }
@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// GetConsensusDetailedStatus retrieves the detailed internal state of consensus of the node, for the given genesis
+// index. If the genesis index is nil, the status is returned for the latest genesis index.
+//
+// This endpoint is only supported for protocol version 6 and onwards.
+func (c *Client) GetConsensusDetailedStatus(ctx context.Context, genesisIndex *GenesisIndex) (_ ConsensusDetailedStatus, err error) {
+	req := &pb.ConsensusDetailedStatusQuery{}
+	if genesisIndex != nil {
+		req.GenesisIndex = &pb.GenesisIndex{Value: genesisIndex.Value}
+	}
+
+	status, err := c.GrpcClient.GetConsensusDetailedStatus(ctx, req)
+	if err != nil {
+		return ConsensusDetailedStatus{}, err
+	}
+
+	return parseConsensusDetailedStatus(status)
+}
@@ -0,0 +1,19 @@
+package v2
+
+import (
+	"context"
+)
+
+// GetCooldownAccounts retrieves all accounts that have stake in cooldown at the end of a block, with the timestamp
+// of the first pending cooldown expiry for each account. This only identifies the accounts by index, and only
+// indicates the first pending cooldown of each account.
+//
+// Prior to protocol version 7, the resulting stream will always be empty.
+func (c *Client) GetCooldownAccounts(ctx context.Context, req isBlockHashInput) (_ AccountPendingStream, err error) {
+	stream, err := c.GrpcClient.GetCooldownAccounts(ctx, convertBlockHashInput(req))
+	if err != nil {
+		return AccountPendingStream{}, err
+	}
+
+	return AccountPendingStream{stream: stream}, nil
+}
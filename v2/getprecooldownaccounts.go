@@ -0,0 +1,18 @@
+package v2
+
+import (
+	"context"
+)
+
+// GetPreCooldownAccounts retrieves all accounts that have stake in pre-cooldown at the end of a block.
+// This only identifies the accounts by index.
+//
+// Prior to protocol version 7, the resulting stream will always be empty.
+func (c *Client) GetPreCooldownAccounts(ctx context.Context, req isBlockHashInput) (_ AccountIndexStream, err error) {
+	stream, err := c.GrpcClient.GetPreCooldownAccounts(ctx, convertBlockHashInput(req))
+	if err != nil {
+		return AccountIndexStream{}, err
+	}
+
+	return AccountIndexStream{stream: stream}, nil
+}
@@ -0,0 +1,17 @@
+package v2
+
+import (
+	"context"
+)
+
+// GetScheduledReleaseAccounts retrieves all accounts that have scheduled releases at the end of a block, with the
+// timestamp of the first pending scheduled release for each account. This only identifies the accounts by index,
+// and only indicates the first pending release of each account.
+func (c *Client) GetScheduledReleaseAccounts(ctx context.Context, req isBlockHashInput) (_ AccountPendingStream, err error) {
+	stream, err := c.GrpcClient.GetScheduledReleaseAccounts(ctx, convertBlockHashInput(req))
+	if err != nil {
+		return AccountPendingStream{}, err
+	}
+
+	return AccountPendingStream{stream: stream}, nil
+}
@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// Checkpointer stores the progress of an Indexer.
+type Checkpointer interface {
+	// LoadCheckpoint returns the height of the next block to process. The second return value is false
+	// if no checkpoint has been stored yet.
+	LoadCheckpoint(ctx context.Context) (v2.AbsoluteBlockHeight, bool, error)
+	// SaveCheckpoint stores the height of the next block to process. It is called after each processed block.
+	SaveCheckpoint(ctx context.Context, next v2.AbsoluteBlockHeight) error
+}
+
+// MemoryCheckpointer a Checkpointer that keeps the checkpoint in memory.
+type MemoryCheckpointer struct {
+	mu   sync.Mutex
+	next *v2.AbsoluteBlockHeight
+}
+
+// LoadCheckpoint returns the last saved checkpoint.
+func (m *MemoryCheckpointer) LoadCheckpoint(context.Context) (v2.AbsoluteBlockHeight, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next == nil {
+		return v2.AbsoluteBlockHeight{}, false, nil
+	}
+	return *m.next, true, nil
+}
+
+// SaveCheckpoint saves the checkpoint.
+func (m *MemoryCheckpointer) SaveCheckpoint(_ context.Context, next v2.AbsoluteBlockHeight) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.next = &next
+	return nil
+}
+
+// FileCheckpointer a Checkpointer that stores the checkpoint in a file. The file is replaced atomically,
+// so a crash never leaves a partially written checkpoint.
+type FileCheckpointer struct {
+	Path string
+}
+
+// LoadCheckpoint reads the checkpoint from the file. If the file does not exist, there is no checkpoint.
+func (f *FileCheckpointer) LoadCheckpoint(context.Context) (v2.AbsoluteBlockHeight, bool, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return v2.AbsoluteBlockHeight{}, false, nil
+	}
+	if err != nil {
+		return v2.AbsoluteBlockHeight{}, false, err
+	}
+	next, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return v2.AbsoluteBlockHeight{}, false, errors.New("invalid checkpoint file: " + err.Error())
+	}
+	return v2.AbsoluteBlockHeight{Value: next}, true, nil
+}
+
+// SaveCheckpoint writes the checkpoint to a temporary file and renames it to the checkpoint file.
+func (f *FileCheckpointer) SaveCheckpoint(_ context.Context, next v2.AbsoluteBlockHeight) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(strconv.FormatUint(next.Value, 10) + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
@@ -0,0 +1,284 @@
+// Package indexer traverses finalized blocks in order of height and passes them to a BlockProcessor.
+//
+// The Indexer first catches up from the start height to the last finalized block using GetBlocksAtHeight and
+// then follows the stream of finalized blocks, filling any gaps in the stream the same way, so that no block is
+// skipped or processed twice. Progress is stored with a Checkpointer, so that indexing resumes where it stopped
+// after a restart. If the connection to the node fails, the Indexer reconnects with exponential backoff.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+const (
+	// DefaultConcurrency is the default number of blocks fetched concurrently.
+	DefaultConcurrency = 4
+	// DefaultMinBackoff is the default initial delay before reconnecting to the node.
+	DefaultMinBackoff = 1 * time.Second
+	// DefaultMaxBackoff is the default maximum delay before reconnecting to the node.
+	DefaultMaxBackoff = 1 * time.Minute
+)
+
+// Block a finalized block.
+type Block struct {
+	Hash   v2.BlockHash
+	Height v2.AbsoluteBlockHeight
+}
+
+// BlockData the data of a finalized block passed to the BlockProcessor.
+type BlockData struct {
+	Block
+	// Information about the block.
+	Info *v2.BlockInfo
+	// Outcomes of the block items in the block. Only fetched if Config.FetchTransactionEvents is set.
+	TransactionEvents []v2.BlockItemSummary
+	// Special events in the block. Only fetched if Config.FetchSpecialEvents is set.
+	SpecialEvents []*pb.BlockSpecialEvent
+}
+
+// BlockProcessor processes finalized blocks.
+type BlockProcessor interface {
+	// ProcessBlock is called for every finalized block in order of height. Blocks are fetched concurrently,
+	// but ProcessBlock is never called concurrently. If it returns an error, the Indexer stops with that error.
+	ProcessBlock(ctx context.Context, block *BlockData) error
+}
+
+// BlockProcessorFunc adapts a function to a BlockProcessor.
+type BlockProcessorFunc func(ctx context.Context, block *BlockData) error
+
+// ProcessBlock calls f.
+func (f BlockProcessorFunc) ProcessBlock(ctx context.Context, block *BlockData) error {
+	return f(ctx, block)
+}
+
+// Config configuration of an Indexer.
+type Config struct {
+	// The height of the first block to process if the Checkpointer has no checkpoint.
+	StartHeight v2.AbsoluteBlockHeight
+	// Stores the progress of the Indexer. May be nil, in which case indexing always starts at StartHeight.
+	Checkpointer Checkpointer
+	// Number of blocks fetched concurrently. Defaults to DefaultConcurrency.
+	Concurrency int
+	// Whether to fetch the outcomes of block items of each block.
+	FetchTransactionEvents bool
+	// Whether to fetch the special events of each block.
+	FetchSpecialEvents bool
+	// Initial delay before reconnecting to the node. Defaults to DefaultMinBackoff.
+	MinBackoff time.Duration
+	// Maximum delay before reconnecting to the node. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+	// Called when the connection to the node fails, before waiting to reconnect. May be nil.
+	OnError func(err error)
+}
+
+// Indexer traverses finalized blocks and passes them to a BlockProcessor.
+type Indexer struct {
+	client    *v2.Client
+	processor BlockProcessor
+	config    Config
+}
+
+// New creates an Indexer.
+func New(client *v2.Client, processor BlockProcessor, config Config) *Indexer {
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultConcurrency
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = DefaultMinBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	return &Indexer{client: client, processor: processor, config: config}
+}
+
+// processError an error returned by the BlockProcessor or Checkpointer, which stops the Indexer.
+type processError struct {
+	err error
+}
+
+func (e *processError) Error() string {
+	return e.err.Error()
+}
+
+// Run processes finalized blocks until the context is cancelled or the BlockProcessor or Checkpointer fails.
+// Errors from the node are retried with exponential backoff.
+func (i *Indexer) Run(ctx context.Context) error {
+	next := i.config.StartHeight
+	if i.config.Checkpointer != nil {
+		checkpoint, ok, err := i.config.Checkpointer.LoadCheckpoint(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			next = checkpoint
+		}
+	}
+
+	backoff := i.config.MinBackoff
+	for {
+		progressed, err := i.run(ctx, &next)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var pErr *processError
+		if errors.As(err, &pErr) {
+			return pErr.err
+		}
+		if i.config.OnError != nil {
+			i.config.OnError(err)
+		}
+
+		if progressed {
+			backoff = i.config.MinBackoff
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > i.config.MaxBackoff {
+			backoff = i.config.MaxBackoff
+		}
+	}
+}
+
+// run catches up to the last finalized block and follows the stream of finalized blocks until an error occurs.
+// It returns whether any block was processed.
+func (i *Indexer) run(ctx context.Context, next *v2.AbsoluteBlockHeight) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// closes the stream of finalized blocks when done.
+	defer cancel()
+
+	start := *next
+	// the stream is opened before catching up so that no finalized block is missed in between.
+	stream, err := i.client.GetFinalizedBlocks(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	consensusInfo, err := i.client.GetConsensusInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	lastFinal := v2.AbsoluteBlockHeight{Value: consensusInfo.GetLastFinalizedBlockHeight().GetValue()}
+	if err = i.processUntil(ctx, next, lastFinal); err != nil {
+		return next.Value > start.Value, err
+	}
+
+	for {
+		finalized, err := stream.Recv()
+		if err != nil {
+			return next.Value > start.Value, err
+		}
+		height := v2.AbsoluteBlockHeight{Value: finalized.GetHeight().GetValue()}
+		if err = i.processUntil(ctx, next, height); err != nil {
+			return next.Value > start.Value, err
+		}
+	}
+}
+
+// processUntil processes the blocks from next up to and including the given height, and advances next.
+// Blocks are fetched in batches of Config.Concurrency blocks and processed in order.
+func (i *Indexer) processUntil(ctx context.Context, next *v2.AbsoluteBlockHeight, until v2.AbsoluteBlockHeight) error {
+	for next.Value <= until.Value {
+		n := until.Value - next.Value + 1
+		if n > uint64(i.config.Concurrency) {
+			n = uint64(i.config.Concurrency)
+		}
+
+		blocks := make([]*BlockData, n)
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for j := range blocks {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				blocks[j], errs[j] = i.fetch(ctx, v2.AbsoluteBlockHeight{Value: next.Value + uint64(j)})
+			}(j)
+		}
+		wg.Wait()
+
+		for j, block := range blocks {
+			if errs[j] != nil {
+				return errs[j]
+			}
+			if err := i.processor.ProcessBlock(ctx, block); err != nil {
+				return &processError{err: err}
+			}
+			next.Value++
+			if i.config.Checkpointer != nil {
+				if err := i.config.Checkpointer.SaveCheckpoint(ctx, *next); err != nil {
+					return &processError{err: err}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fetch fetches the data of the finalized block at the given height.
+func (i *Indexer) fetch(ctx context.Context, height v2.AbsoluteBlockHeight) (*BlockData, error) {
+	hashes, err := i.client.GetBlocksAtHeight(ctx, &pb.BlocksAtHeightRequest{
+		BlocksAtHeight: &pb.BlocksAtHeightRequest_Absolute_{Absolute: &pb.BlocksAtHeightRequest_Absolute{
+			Height: &pb.AbsoluteBlockHeight{Value: height.Value},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(hashes) != 1 {
+		return nil, fmt.Errorf("expected one finalized block at height %d, got %d", height.Value, len(hashes))
+	}
+
+	block := v2.BlockHashInputGiven{Given: *hashes[0]}
+	res := &BlockData{Block: Block{Hash: *hashes[0], Height: height}}
+	if res.Info, err = i.client.GetBlockInfo(ctx, block); err != nil {
+		return nil, err
+	}
+
+	if i.config.FetchTransactionEvents {
+		events, err := i.client.GetBlockTransactionEvents(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			summary, err := events.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			res.TransactionEvents = append(res.TransactionEvents, summary)
+		}
+	}
+
+	if i.config.FetchSpecialEvents {
+		events, err := i.client.GetBlockSpecialEvents(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			event, err := events.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			res.SpecialEvents = append(res.SpecialEvents, event)
+		}
+	}
+
+	return res, nil
+}
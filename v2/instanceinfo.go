@@ -0,0 +1,60 @@
+package v2
+
+import (
+	"errors"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// InstanceInfo information about a smart contract instance.
+type InstanceInfo struct {
+	// The version of the smart contract module of the instance.
+	Version ContractVersion
+	// The state of the instance. Only V0 instances have their state included, it is nil for V1 instances.
+	Model []byte
+	// The account address which deployed the instance.
+	Owner AccountAddress
+	// The amount of CCD owned by the instance.
+	Amount Amount
+	// The receive functions of the instance.
+	Methods []ReceiveName
+	// The name of the smart contract of the instance.
+	Name InitName
+	// The module reference of the smart contract module of the instance.
+	SourceModule ModuleRef
+}
+
+// Parses *pb.InstanceInfo to InstanceInfo.
+func parseInstanceInfo(i *pb.InstanceInfo) (InstanceInfo, error) {
+	switch v := i.GetVersion().(type) {
+	case *pb.InstanceInfo_V0_:
+		return InstanceInfo{
+			Version:      ContractVersionV0,
+			Model:        v.V0.GetModel().GetValue(),
+			Owner:        parseAccountAddress(v.V0.GetOwner()),
+			Amount:       Amount{Value: v.V0.GetAmount().GetValue()},
+			Methods:      parseReceiveNames(v.V0.GetMethods()),
+			Name:         InitName{Value: v.V0.GetName().GetValue()},
+			SourceModule: parseModuleRef(v.V0.GetSourceModule()),
+		}, nil
+	case *pb.InstanceInfo_V1_:
+		return InstanceInfo{
+			Version:      ContractVersionV1,
+			Owner:        parseAccountAddress(v.V1.GetOwner()),
+			Amount:       Amount{Value: v.V1.GetAmount().GetValue()},
+			Methods:      parseReceiveNames(v.V1.GetMethods()),
+			Name:         InitName{Value: v.V1.GetName().GetValue()},
+			SourceModule: parseModuleRef(v.V1.GetSourceModule()),
+		}, nil
+	}
+	return InstanceInfo{}, errors.New("Error parsing InstanceInfo: " + ErrUnknownVariant.Error())
+}
+
+// Parses []*pb.ReceiveName to []ReceiveName.
+func parseReceiveNames(names []*pb.ReceiveName) []ReceiveName {
+	res := make([]ReceiveName, 0, len(names))
+	for _, n := range names {
+		res = append(res, ReceiveName{Value: n.GetValue()})
+	}
+	return res
+}
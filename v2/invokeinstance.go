@@ -2,35 +2,17 @@ package v2
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/Concordium/concordium-go-sdk/v2/pb"
 )
 
 // InvokeInstance run the smart contract entrypoint in a given context and in the state at the end of the given block.
+// The address of the invoker may be nil, in which case the node uses the zero account address.
 func (c *Client) InvokeInstance(ctx context.Context, payload UpdateContractPayload, input isBlockHashInput, energy Energy, address isAddress) (_ *pb.InvokeInstanceResponse, err error) {
-	var pbAddress pb.Address
-
-	switch k := address.(type) {
-	case *AccountAddress:
-		accountAddress := make([]byte, AccountAddressLength)
-		copy(accountAddress, k.Value[:])
-		pbAddress.Type = &pb.Address_Account{
-			Account: &pb.AccountAddress{
-				Value: accountAddress,
-			},
-		}
-	case *ContractAddress:
-		pbAddress.Type = &pb.Address_Contract{
-			Contract: &pb.ContractAddress{
-				Index:    k.Index,
-				Subindex: k.Subindex,
-			},
-		}
-	}
-
-	invokeInstanceResponse, err := c.GrpcClient.InvokeInstance(ctx, &pb.InvokeInstanceRequest{
+	req := &pb.InvokeInstanceRequest{
 		BlockHash: convertBlockHashInput(input),
-		Invoker:   &pbAddress,
 		Instance: &pb.ContractAddress{
 			Index:    payload.Address.Index,
 			Subindex: payload.Address.Subindex,
@@ -47,10 +29,96 @@ func (c *Client) InvokeInstance(ctx context.Context, payload UpdateContractPaylo
 		Energy: &pb.Energy{
 			Value: energy.Value,
 		},
-	})
+	}
+	if address != nil {
+		req.Invoker = convertAddress(address)
+	}
+	invokeInstanceResponse, err := c.GrpcClient.InvokeInstance(ctx, req)
 	if err != nil {
 		return &pb.InvokeInstanceResponse{}, err
 	}
 
 	return invokeInstanceResponse, nil
 }
+
+// InvokeInstanceSuccess the result of a successful invocation of a smart contract instance with InvokeInstance.
+type InvokeInstanceSuccess struct {
+	// If invoking a V0 contract this is nil. Otherwise it is the return value produced by the contract.
+	ReturnValue []byte
+	// Energy used by the execution.
+	UsedEnergy Energy
+	// Effects produced by contract execution.
+	Effects []ContractTraceElement
+}
+
+// InvokeInstanceFailedError the invocation of a smart contract instance with InvokeInstance failed.
+type InvokeInstanceFailedError struct {
+	// If invoking a V0 contract this is nil, otherwise it is potentially return value produced by the call
+	// unless the call failed with out of energy or runtime error.
+	ReturnValue []byte
+	// Energy used by the execution.
+	UsedEnergy Energy
+	// Contract execution failed for the given reason.
+	Reason RejectReason
+}
+
+func (e *InvokeInstanceFailedError) Error() string {
+	return fmt.Sprintf("invoke instance failed: %v", e.Reason)
+}
+
+// Unwrap returns the RejectReason, so it can be inspected with errors.As.
+func (e *InvokeInstanceFailedError) Unwrap() error {
+	return e.Reason
+}
+
+// ParseInvokeInstanceResponse converts the response of InvokeInstance to InvokeInstanceSuccess. If the invocation
+// failed, the returned error is a *InvokeInstanceFailedError.
+func ParseInvokeInstanceResponse(res *pb.InvokeInstanceResponse) (InvokeInstanceSuccess, error) {
+	switch r := res.GetResult().(type) {
+	case *pb.InvokeInstanceResponse_Success_:
+		success, err := parseInvokeSuccess(r.Success.GetReturnValue(), r.Success.GetUsedEnergy(), r.Success.GetEffects())
+		if err != nil {
+			return InvokeInstanceSuccess{}, errors.New("Error parsing InvokeInstanceResponse: " + err.Error())
+		}
+		return success, nil
+	case *pb.InvokeInstanceResponse_Failure_:
+		failure, err := parseInvokeFailure(r.Failure.GetReturnValue(), r.Failure.GetUsedEnergy(), r.Failure.GetReason())
+		if err != nil {
+			return InvokeInstanceSuccess{}, errors.New("Error parsing InvokeInstanceResponse: " + err.Error())
+		}
+		return InvokeInstanceSuccess{}, &failure
+	}
+	return InvokeInstanceSuccess{}, errors.New("Error parsing InvokeInstanceResponse: " + ErrUnknownVariant.Error())
+}
+
+// parseInvokeSuccess parses the result of a successful contract invocation. It is shared by InvokeInstance and
+// DryRunSession.InvokeInstance, whose result types convert to each other.
+func parseInvokeSuccess(returnValue []byte, usedEnergy *pb.Energy, effects []*pb.ContractTraceElement) (InvokeInstanceSuccess, error) {
+	res := InvokeInstanceSuccess{
+		ReturnValue: returnValue,
+		UsedEnergy:  Energy{Value: usedEnergy.GetValue()},
+		Effects:     make([]ContractTraceElement, 0, len(effects)),
+	}
+	for _, e := range effects {
+		effect, err := parseContractTraceElement(e)
+		if err != nil {
+			return InvokeInstanceSuccess{}, err
+		}
+		res.Effects = append(res.Effects, effect)
+	}
+	return res, nil
+}
+
+// parseInvokeFailure parses the result of a failed contract invocation. It is shared by InvokeInstance and
+// DryRunSession.InvokeInstance, whose error types convert to each other.
+func parseInvokeFailure(returnValue []byte, usedEnergy *pb.Energy, reason *pb.RejectReason) (InvokeInstanceFailedError, error) {
+	rejectReason, err := parseRejectReason(reason)
+	if err != nil {
+		return InvokeInstanceFailedError{}, err
+	}
+	return InvokeInstanceFailedError{
+		ReturnValue: returnValue,
+		UsedEnergy:  Energy{Value: usedEnergy.GetValue()},
+		Reason:      rejectReason,
+	}, nil
+}
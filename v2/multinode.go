@@ -0,0 +1,300 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval between health checks of the nodes of a Client.
+	DefaultHealthCheckInterval = 10 * time.Second
+	// DefaultMaxFinalizedHeightLag is the default number of blocks the last finalized block of a node may be
+	// behind that of the most advanced node for the node to be considered healthy.
+	DefaultMaxFinalizedHeightLag = 5
+)
+
+// NodeStatus the health of one of the nodes of a Client.
+type NodeStatus struct {
+	Address string
+	// Whether requests are routed to the node. Unhealthy nodes are only used when no node is healthy.
+	Healthy bool
+	// The height of the last finalized block of the node at the last health check.
+	LastFinalizedHeight AbsoluteBlockHeight
+	// The reason the node is unhealthy, nil if it is healthy.
+	Err error
+	// The time of the last health check, zero if the node was not checked yet.
+	LastChecked time.Time
+}
+
+// NodeStatuses returns the health of the nodes of the client, or nil if the client is connected to a single node.
+func (c *Client) NodeStatuses() []NodeStatus {
+	if c.nodes == nil {
+		return nil
+	}
+	return c.nodes.statuses()
+}
+
+// CheckNodes checks the health of the nodes of the client immediately instead of waiting for the next periodic
+// check, and returns the result. It returns nil if the client is connected to a single node.
+func (c *Client) CheckNodes(ctx context.Context) []NodeStatus {
+	if c.nodes == nil {
+		return nil
+	}
+	c.nodes.check(ctx)
+	return c.nodes.statuses()
+}
+
+type node struct {
+	conn    *grpc.ClientConn
+	queries pb.QueriesClient
+	health  pb.HealthClient
+	status  NodeStatus
+}
+
+// nodePool routes requests to a set of nodes. It implements grpc.ClientConnInterface, so that every method of
+// pb.QueriesClient is routed to the healthiest node and fails over to the next one if the node is unavailable.
+type nodePool struct {
+	nodes     []*node
+	broadcast bool
+	interval  time.Duration
+	maxLag    uint64
+
+	// the index of the node the next request starts at, so that requests are spread over the healthy nodes.
+	next atomic.Uint32
+	// guards the status of the nodes.
+	mu sync.Mutex
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	stopped  sync.WaitGroup
+}
+
+func newNodePool(config Config, addresses []string, conns []*grpc.ClientConn) *nodePool {
+	p := &nodePool{
+		broadcast: config.BroadcastBlockItems,
+		interval:  config.HealthCheckInterval,
+		maxLag:    config.MaxFinalizedHeightLag,
+		stop:      make(chan struct{}),
+	}
+	if p.interval <= 0 {
+		p.interval = DefaultHealthCheckInterval
+	}
+	if p.maxLag == 0 {
+		p.maxLag = DefaultMaxFinalizedHeightLag
+	}
+	for i, conn := range conns {
+		p.nodes = append(p.nodes, &node{
+			conn:    conn,
+			queries: pb.NewQueriesClient(conn),
+			health:  pb.NewHealthClient(conn),
+			// nodes are assumed to be healthy until checked.
+			status: NodeStatus{Address: addresses[i], Healthy: true},
+		})
+	}
+
+	p.stopped.Add(1)
+	go p.run()
+	return p
+}
+
+// run checks the health of the nodes periodically until the pool is closed.
+func (p *nodePool) run() {
+	defer p.stopped.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-p.stop
+		cancel()
+	}()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.check(ctx)
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check checks the health of every node. A node is healthy if its health service reports it as healthy and its
+// last finalized block is at most maxLag blocks behind that of the most advanced node.
+func (p *nodePool) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	heights := make([]uint64, len(p.nodes))
+	errs := make([]error, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, n := range p.nodes {
+		wg.Add(1)
+		go func(i int, n *node) {
+			defer wg.Done()
+			if _, errs[i] = n.health.Check(ctx, new(pb.NodeHealthRequest)); errs[i] != nil {
+				return
+			}
+			info, err := n.queries.GetConsensusInfo(ctx, new(pb.Empty))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			heights[i] = info.GetLastFinalizedBlockHeight().GetValue()
+		}(i, n)
+	}
+	wg.Wait()
+
+	var maxHeight uint64
+	for i, height := range heights {
+		if errs[i] == nil && height > maxHeight {
+			maxHeight = height
+		}
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, n := range p.nodes {
+		err := errs[i]
+		if err == nil && maxHeight-heights[i] > p.maxLag {
+			err = fmt.Errorf("last finalized block is %d blocks behind", maxHeight-heights[i])
+		}
+		n.status.Healthy = err == nil
+		n.status.Err = err
+		n.status.LastFinalizedHeight = AbsoluteBlockHeight{Value: heights[i]}
+		n.status.LastChecked = now
+	}
+}
+
+func (p *nodePool) statuses() []NodeStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := make([]NodeStatus, len(p.nodes))
+	for i, n := range p.nodes {
+		res[i] = n.status
+	}
+	return res
+}
+
+// markUnhealthy marks a node as unhealthy until the next health check.
+func (p *nodePool) markUnhealthy(n *node, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n.status.Healthy = false
+	n.status.Err = err
+}
+
+// order returns the nodes in the order requests should try them: the healthy nodes, starting at a different
+// node for every request, followed by the unhealthy nodes.
+func (p *nodePool) order() []*node {
+	start := int(p.next.Add(1))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	healthy := make([]*node, 0, len(p.nodes))
+	var unhealthy []*node
+	for i := range p.nodes {
+		n := p.nodes[(start+i)%len(p.nodes)]
+		if n.status.Healthy {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// isFailoverError returns whether a request that failed with err should be retried on another node.
+func isFailoverError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// Invoke sends a unary request to the first node in order that is available. Block items are sent to a single
+// node, or to all nodes if broadcasting is enabled, and are never sent again to another node.
+func (p *nodePool) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	nodes := p.order()
+	if method == pb.Queries_SendBlockItem_FullMethodName {
+		if p.broadcast {
+			return p.broadcastItem(ctx, nodes, args, reply, opts)
+		}
+		return nodes[0].conn.Invoke(ctx, method, args, reply, opts...)
+	}
+
+	var err error
+	for _, n := range nodes {
+		err = n.conn.Invoke(ctx, method, args, reply, opts...)
+		if !isFailoverError(err) || ctx.Err() != nil {
+			return err
+		}
+		p.markUnhealthy(n, err)
+	}
+	return err
+}
+
+// broadcastItem sends a block item to all nodes and succeeds if any node accepts it. Otherwise, it returns the
+// error of the first node in order.
+func (p *nodePool) broadcastItem(ctx context.Context, nodes []*node, args any, reply any, opts []grpc.CallOption) error {
+	replies := make([]proto.Message, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		replies[i] = reply.(proto.Message).ProtoReflect().New().Interface()
+		wg.Add(1)
+		go func(i int, n *node) {
+			defer wg.Done()
+			errs[i] = n.conn.Invoke(ctx, pb.Queries_SendBlockItem_FullMethodName, args, replies[i], opts...)
+		}(i, n)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			proto.Merge(reply.(proto.Message), replies[i])
+			return nil
+		}
+	}
+	return errs[0]
+}
+
+// NewStream opens a stream on the first node in order that is available. Once opened, the stream is not moved
+// to another node if the node fails.
+func (p *nodePool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	var err error
+	for _, n := range p.order() {
+		var stream grpc.ClientStream
+		stream, err = n.conn.NewStream(ctx, desc, method, opts...)
+		if err == nil || !isFailoverError(err) || ctx.Err() != nil {
+			return stream, err
+		}
+		p.markUnhealthy(n, err)
+	}
+	return nil, err
+}
+
+// close stops the health checks and closes the connections to all nodes.
+func (p *nodePool) close() error {
+	p.stopOnce.Do(func() { close(p.stop) })
+	p.stopped.Wait()
+	var errs []error
+	for _, n := range p.nodes {
+		if err := n.conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
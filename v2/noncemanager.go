@@ -0,0 +1,173 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDuplicateNonce indicates that the node rejected a transaction because its sequence number was already used.
+	ErrDuplicateNonce = errors.New("duplicate nonce")
+	// ErrNonceTooLarge indicates that the node rejected a transaction because its sequence number is larger than
+	// the next sequence number of the account, i.e. an earlier transaction never reached the node.
+	ErrNonceTooLarge = errors.New("nonce too large")
+)
+
+// nonceManagerMaxAttempts is the number of times NonceManager.Send tries to send a transaction with a fresh sequence
+// number before giving up.
+const nonceManagerMaxAttempts = 3
+
+// ClassifySendError returns ErrDuplicateNonce or ErrNonceTooLarge, wrapping err, if err is an error returned by
+// SendBlockItem because of the sequence number of the transaction. Other errors are returned unchanged.
+func ClassifySendError(err error) error {
+	if err == nil || errors.Is(err, ErrDuplicateNonce) || errors.Is(err, ErrNonceTooLarge) {
+		return err
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "duplicate nonce"), strings.Contains(msg, "nonce was already used"),
+		strings.Contains(msg, "sequence number was already used"):
+		return errors.Join(ErrDuplicateNonce, err)
+	case strings.Contains(msg, "nonce too large"), strings.Contains(msg, "nonce is too large"),
+		strings.Contains(msg, "sequence number is too large"):
+		return errors.Join(ErrNonceTooLarge, err)
+	}
+	return err
+}
+
+// NonceSyncStatus the result of synchronising a NonceManager with the node.
+type NonceSyncStatus struct {
+	// The next sequence number of the account according to the node, including non-finalized transactions.
+	Next SequenceNumber
+	// Whether all transactions of the account are finalized.
+	AllFinal bool
+	// Hashes of the transactions of the account that are not finalized yet. Nil if AllFinal is true.
+	NonFinalized []*TransactionHash
+}
+
+// NonceManager hands out sequence numbers of one account to concurrent senders. It is synchronised with the node
+// on first use and whenever sending a transaction fails, so that sequence numbers are neither reused nor skipped.
+// It assumes that the account only sends transactions through this NonceManager.
+//
+// A sequence number returned by Next stays reserved until it is released with Done or Invalidate. Reserved sequence
+// numbers are never handed out again, even if the node does not know the transaction using it yet when the
+// NonceManager is synchronised.
+type NonceManager struct {
+	client  *Client
+	address AccountAddress
+
+	mu     sync.Mutex
+	next   SequenceNumber
+	synced bool
+	// sequence numbers handed out by Next that are not released yet.
+	reserved map[uint64]struct{}
+}
+
+// NewNonceManager creates a NonceManager for the given account. The next sequence number is queried on first use.
+func NewNonceManager(client *Client, address AccountAddress) *NonceManager {
+	return &NonceManager{client: client, address: address, reserved: make(map[uint64]struct{})}
+}
+
+// Next reserves and returns the next sequence number that is not reserved. Once the transaction using it is
+// accepted by the node, the sequence number must be released with Done. If it is not used or the node does not
+// accept the transaction, it must be released with Invalidate instead, so that the gap is detected and filled.
+func (m *NonceManager) Next(ctx context.Context) (SequenceNumber, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		if _, err := m.resync(ctx); err != nil {
+			return SequenceNumber{}, err
+		}
+	}
+	for {
+		if _, ok := m.reserved[m.next.Value]; !ok {
+			break
+		}
+		m.next.Value++
+	}
+	nonce := m.next
+	m.reserved[nonce.Value] = struct{}{}
+	m.next.Value++
+	return nonce, nil
+}
+
+// Done releases a sequence number returned by Next after the node accepted the transaction using it.
+func (m *NonceManager) Done(nonce SequenceNumber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reserved, nonce.Value)
+}
+
+// Invalidate releases a sequence number returned by Next that was not used or whose transaction the node did not
+// accept, and marks the NonceManager as out of sync, so that it is synchronised with the node before the next
+// sequence number is handed out.
+func (m *NonceManager) Invalidate(nonce SequenceNumber) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reserved, nonce.Value)
+	m.synced = false
+}
+
+// Resync synchronises the NonceManager with the node. The next sequence number is taken from
+// GetNextAccountSequenceNumber, which includes non-finalized transactions known to the node. If not all
+// transactions of the account are finalized, their hashes are queried with GetAccountNonFinalizedTransactions.
+// Sequence numbers that are still reserved are skipped when they are handed out.
+func (m *NonceManager) Resync(ctx context.Context) (NonceSyncStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resync(ctx)
+}
+
+func (m *NonceManager) resync(ctx context.Context) (NonceSyncStatus, error) {
+	address := m.address
+	res, err := m.client.GetNextAccountSequenceNumber(ctx, &address)
+	if err != nil {
+		return NonceSyncStatus{}, err
+	}
+	status := NonceSyncStatus{
+		Next:     SequenceNumber{Value: res.GetSequenceNumber().GetValue()},
+		AllFinal: res.GetAllFinal(),
+	}
+	if !status.AllFinal {
+		status.NonFinalized, err = m.client.GetAccountNonFinalizedTransactions(ctx, &address)
+		if err != nil {
+			return NonceSyncStatus{}, err
+		}
+	}
+
+	m.next = status.Next
+	m.synced = true
+	return status, nil
+}
+
+// Send reserves a sequence number, builds the transaction with it and sends it. If the node rejects the
+// transaction because of its sequence number, the NonceManager is synchronised and the transaction is rebuilt
+// with a fresh sequence number, up to a few times. On any other failure the NonceManager is invalidated and the
+// error is returned, classified by ClassifySendError.
+func (m *NonceManager) Send(ctx context.Context, build func(nonce SequenceNumber) (*AccountTransaction, error)) (*TransactionHash, error) {
+	for attempt := 1; ; attempt++ {
+		nonce, err := m.Next(ctx)
+		if err != nil {
+			return &TransactionHash{}, err
+		}
+		tx, err := build(nonce)
+		if err != nil {
+			m.Invalidate(nonce)
+			return &TransactionHash{}, err
+		}
+		hash, err := tx.Send(ctx, m.client)
+		if err == nil {
+			m.Done(nonce)
+			return hash, nil
+		}
+
+		m.Invalidate(nonce)
+		err = ClassifySendError(err)
+		if attempt >= nonceManagerMaxAttempts || !(errors.Is(err, ErrDuplicateNonce) || errors.Is(err, ErrNonceTooLarge)) {
+			return &TransactionHash{}, err
+		}
+	}
+}
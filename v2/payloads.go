@@ -1,8 +1,10 @@
 package v2
 
 import (
+	"crypto/ed25519"
 	"encoding/binary"
 	"errors"
+	"sort"
 )
 
 var (
@@ -10,6 +12,12 @@ var (
 	ErrInvalidPayloadType = errors.New("invalid payload type")
 	// ErrInvalidRawPayloadSize indicated that raw payload size is invalid.
 	ErrInvalidRawPayloadSize = errors.New("invalid raw payload size")
+	// ErrInvalidScheduleLength indicates that a release schedule is empty or has more than MaxReleases releases.
+	ErrInvalidScheduleLength = errors.New("release schedule must contain between 1 and 255 releases")
+	// ErrScheduleNotIncreasing indicates that the timestamps of a release schedule are not strictly increasing.
+	ErrScheduleNotIncreasing = errors.New("release timestamps must be strictly increasing")
+	// ErrZeroScheduledAmount indicates that a release schedule contains a release of zero CCD.
+	ErrZeroScheduledAmount = errors.New("released amounts must be nonzero")
 )
 
 const PayloadTypeSize = 1
@@ -26,15 +34,45 @@ const (
 	UpdateContractPayloadType PayloadType = 2
 	// TransferPayloadType defines TransferPayload type byte.
 	TransferPayloadType PayloadType = 3
+	// AddBakerPayloadType defines AddBakerPayload type byte.
+	AddBakerPayloadType PayloadType = 4
+	// RemoveBakerPayloadType defines RemoveBakerPayload type byte.
+	RemoveBakerPayloadType PayloadType = 5
+	// UpdateBakerStakePayloadType defines UpdateBakerStakePayload type byte.
+	UpdateBakerStakePayloadType PayloadType = 6
+	// UpdateBakerRestakeEarningsPayloadType defines UpdateBakerRestakeEarningsPayload type byte.
+	UpdateBakerRestakeEarningsPayloadType PayloadType = 7
+	// UpdateBakerKeysPayloadType defines UpdateBakerKeysPayload type byte.
+	UpdateBakerKeysPayloadType PayloadType = 8
+	// UpdateCredentialKeysPayloadType defines UpdateCredentialKeysPayload type byte.
+	UpdateCredentialKeysPayloadType PayloadType = 13
+	// EncryptedAmountTransferPayloadType defines EncryptedAmountTransferPayload type byte.
+	EncryptedAmountTransferPayloadType PayloadType = 16
+	// TransferToEncryptedPayloadType defines TransferToEncryptedPayload type byte.
+	TransferToEncryptedPayloadType PayloadType = 17
+	// TransferToPublicPayloadType defines TransferToPublicPayload type byte.
+	TransferToPublicPayloadType PayloadType = 18
+	// TransferWithSchedulePayloadType defines TransferWithSchedulePayload type byte.
+	TransferWithSchedulePayloadType PayloadType = 19
+	// UpdateCredentialsPayloadType defines UpdateCredentialsPayload type byte.
+	UpdateCredentialsPayloadType PayloadType = 20
 	// RegisterDataPayloadType defines RegisterDataPayload type byte.
 	RegisterDataPayloadType PayloadType = 21
 	// TransferWithMemoPayloadType defines TransferWithMemoPayload type byte.
 	TransferWithMemoPayloadType PayloadType = 22
+	// EncryptedAmountTransferWithMemoPayloadType defines EncryptedAmountTransferWithMemoPayload type byte.
+	EncryptedAmountTransferWithMemoPayloadType PayloadType = 23
+	// TransferWithScheduleAndMemoPayloadType defines TransferWithScheduleAndMemoPayload type byte.
+	TransferWithScheduleAndMemoPayloadType PayloadType = 24
+	// ConfigureBakerPayloadType defines ConfigureBakerPayload type byte.
+	ConfigureBakerPayloadType PayloadType = 25
+	// ConfigureDelegationPayloadType defines ConfigureDelegationPayload type byte.
+	ConfigureDelegationPayloadType PayloadType = 26
 )
 
 // GetPayloadType returns PayloadType byte from transmitted AccountTransactionPayload.
 func GetPayloadType(payload AccountTransactionPayload) (PayloadType, error) {
-	switch payload.Payload.(type) {
+	switch p := payload.Payload.(type) {
 	case *DeployModule:
 		return DeployModulePayloadType, nil
 	case *InitContract:
@@ -43,17 +81,49 @@ func GetPayloadType(payload AccountTransactionPayload) (PayloadType, error) {
 		return UpdateContractPayloadType, nil
 	case *Transfer:
 		return TransferPayloadType, nil
+	case *AddBaker:
+		return AddBakerPayloadType, nil
+	case *RemoveBaker:
+		return RemoveBakerPayloadType, nil
+	case *UpdateBakerStake:
+		return UpdateBakerStakePayloadType, nil
+	case *UpdateBakerRestakeEarnings:
+		return UpdateBakerRestakeEarningsPayloadType, nil
+	case *UpdateBakerKeys:
+		return UpdateBakerKeysPayloadType, nil
+	case *UpdateCredentialKeys:
+		return UpdateCredentialKeysPayloadType, nil
+	case *EncryptedAmountTransfer:
+		return EncryptedAmountTransferPayloadType, nil
+	case *TransferToEncrypted:
+		return TransferToEncryptedPayloadType, nil
+	case *TransferToPublic:
+		return TransferToPublicPayloadType, nil
+	case *TransferWithSchedule:
+		return TransferWithSchedulePayloadType, nil
+	case *UpdateCredentials:
+		return UpdateCredentialsPayloadType, nil
 	case *RegisterData:
 		return RegisterDataPayloadType, nil
 	case *TransferWithMemo:
 		return TransferWithMemoPayloadType, nil
+	case *EncryptedAmountTransferWithMemo:
+		return EncryptedAmountTransferWithMemoPayloadType, nil
+	case *TransferWithScheduleAndMemo:
+		return TransferWithScheduleAndMemoPayloadType, nil
+	case *ConfigureBaker:
+		return ConfigureBakerPayloadType, nil
+	case *ConfigureDelegation:
+		return ConfigureDelegationPayloadType, nil
+	case *UnknownPayload:
+		return p.Type, nil
 	}
 	return 0xff, ErrInvalidPayloadType
 }
 
 // decode parses specific Payload from bytes.
 func decode(payloadBytes []byte) (payload *AccountTransactionPayload, err error) {
-	if len(payloadBytes) <= 1 {
+	if len(payloadBytes) < PayloadTypeSize {
 		return nil, ErrInvalidRawPayloadSize
 	}
 
@@ -75,6 +145,50 @@ func decode(payloadBytes []byte) (payload *AccountTransactionPayload, err error)
 		transferPayload := new(TransferPayload)
 		err = transferPayload.Decode(payloadBytes[PayloadTypeSize:])
 		payload.Payload = Transfer{Payload: transferPayload}
+	case AddBakerPayloadType:
+		addBakerPayload := new(AddBakerPayload)
+		err = addBakerPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = AddBaker{Payload: addBakerPayload}
+	case RemoveBakerPayloadType:
+		removeBakerPayload := new(RemoveBakerPayload)
+		err = removeBakerPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = RemoveBaker{Payload: removeBakerPayload}
+	case UpdateBakerStakePayloadType:
+		updateBakerStakePayload := new(UpdateBakerStakePayload)
+		err = updateBakerStakePayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = UpdateBakerStake{Payload: updateBakerStakePayload}
+	case UpdateBakerRestakeEarningsPayloadType:
+		updateBakerRestakeEarningsPayload := new(UpdateBakerRestakeEarningsPayload)
+		err = updateBakerRestakeEarningsPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = UpdateBakerRestakeEarnings{Payload: updateBakerRestakeEarningsPayload}
+	case UpdateBakerKeysPayloadType:
+		updateBakerKeysPayload := new(UpdateBakerKeysPayload)
+		err = updateBakerKeysPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = UpdateBakerKeys{Payload: updateBakerKeysPayload}
+	case UpdateCredentialKeysPayloadType:
+		updateCredentialKeysPayload := new(UpdateCredentialKeysPayload)
+		err = updateCredentialKeysPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = UpdateCredentialKeys{Payload: updateCredentialKeysPayload}
+	case EncryptedAmountTransferPayloadType:
+		encryptedAmountTransferPayload := new(EncryptedAmountTransferPayload)
+		err = encryptedAmountTransferPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = EncryptedAmountTransfer{Payload: encryptedAmountTransferPayload}
+	case TransferToEncryptedPayloadType:
+		transferToEncryptedPayload := new(TransferToEncryptedPayload)
+		err = transferToEncryptedPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = TransferToEncrypted{Payload: transferToEncryptedPayload}
+	case TransferToPublicPayloadType:
+		transferToPublicPayload := new(TransferToPublicPayload)
+		err = transferToPublicPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = TransferToPublic{Payload: transferToPublicPayload}
+	case TransferWithSchedulePayloadType:
+		transferWithSchedulePayload := new(TransferWithSchedulePayload)
+		err = transferWithSchedulePayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = TransferWithSchedule{Payload: transferWithSchedulePayload}
+	case UpdateCredentialsPayloadType:
+		updateCredentialsPayload := new(UpdateCredentialsPayload)
+		err = updateCredentialsPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = UpdateCredentials{Payload: updateCredentialsPayload}
 	case RegisterDataPayloadType:
 		registerDataPayload := new(RegisterDataPayload)
 		err = registerDataPayload.Decode(payloadBytes[PayloadTypeSize:])
@@ -83,6 +197,27 @@ func decode(payloadBytes []byte) (payload *AccountTransactionPayload, err error)
 		transferWithMemoPayload := new(TransferWithMemoPayload)
 		err = transferWithMemoPayload.Decode(payloadBytes[PayloadTypeSize:])
 		payload.Payload = TransferWithMemo{Payload: transferWithMemoPayload}
+	case EncryptedAmountTransferWithMemoPayloadType:
+		encryptedAmountTransferWithMemoPayload := new(EncryptedAmountTransferWithMemoPayload)
+		err = encryptedAmountTransferWithMemoPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = EncryptedAmountTransferWithMemo{Payload: encryptedAmountTransferWithMemoPayload}
+	case TransferWithScheduleAndMemoPayloadType:
+		transferWithScheduleAndMemoPayload := new(TransferWithScheduleAndMemoPayload)
+		err = transferWithScheduleAndMemoPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = TransferWithScheduleAndMemo{Payload: transferWithScheduleAndMemoPayload}
+	case ConfigureBakerPayloadType:
+		configureBakerPayload := new(ConfigureBakerPayload)
+		err = configureBakerPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = ConfigureBaker{Payload: configureBakerPayload}
+	case ConfigureDelegationPayloadType:
+		configureDelegationPayload := new(ConfigureDelegationPayload)
+		err = configureDelegationPayload.Decode(payloadBytes[PayloadTypeSize:])
+		payload.Payload = ConfigureDelegation{Payload: configureDelegationPayload}
+	default:
+		payload.Payload = UnknownPayload{
+			Type:  PayloadType(payloadBytes[0]),
+			Bytes: payloadBytes[PayloadTypeSize:],
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -164,7 +299,7 @@ func (payload *DeployModulePayload) Decode(source []byte) error {
 
 	version := moduleVersion(source[:1][0])
 	moduleSize := binary.BigEndian.Uint32(source[1:5])
-	if len(source) != int(moduleSize+5) {
+	if len(source) != int(moduleSize)+5 {
 		return ErrInvalidRawPayloadSize
 	}
 
@@ -230,15 +365,15 @@ func (payload *InitContractPayload) Decode(source []byte) error {
 	}
 	copy(payload.ModuleRef.Value[:], source[8:40])
 
-	initNameSize := binary.BigEndian.Uint16(source[40:42])
-	if len(source) <= int(initNameSize+44) {
+	initNameSize := int(binary.BigEndian.Uint16(source[40:42]))
+	if len(source) < initNameSize+44 {
 		return ErrInvalidRawPayloadSize
 	}
 
 	payload.InitName = &InitName{Value: string(source[42 : 42+initNameSize])}
 
-	parameterSize := binary.BigEndian.Uint16(source[42+initNameSize : initNameSize+44])
-	if len(source) != int(initNameSize+parameterSize+44) {
+	parameterSize := int(binary.BigEndian.Uint16(source[42+initNameSize : initNameSize+44]))
+	if len(source) != initNameSize+parameterSize+44 {
 		return ErrInvalidRawPayloadSize
 	}
 
@@ -381,8 +516,8 @@ func (payload *TransferWithMemoPayload) Decode(source []byte) error {
 	}
 	copy(payload.Receiver.Value[:], source[:32])
 
-	memoSize := binary.BigEndian.Uint16(source[32:34])
-	if len(source) != int(42+memoSize) {
+	memoSize := int(binary.BigEndian.Uint16(source[32:34]))
+	if len(source) != 42+memoSize {
 		return ErrInvalidRawPayloadSize
 	}
 
@@ -408,6 +543,177 @@ func (payload *TransferWithMemoPayload) Size() int {
 	return 42 + len(payload.Memo.Value)
 }
 
+// MaxReleases is the maximum number of releases in the schedule of a scheduled transfer.
+const MaxReleases = 255
+
+// TransferWithSchedulePayload transfers CCD to an account with a release schedule. The amounts are
+// locked on the receiving account until the timestamp of their release.
+type TransferWithSchedulePayload struct {
+	// Address of the receiver account to which the amount will be sent.
+	Receiver *AccountAddress
+	// The release schedule. Timestamps must be strictly increasing and amounts must be nonzero.
+	Schedule []NewRelease
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *TransferWithSchedulePayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(TransferWithSchedulePayloadType))
+	buf = append(buf, payload.Receiver.Value[:]...)
+	buf = appendSchedule(buf, payload.Schedule)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into TransferWithSchedulePayload.
+func (payload *TransferWithSchedulePayload) Decode(source []byte) error {
+	if len(source) <= 33 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	schedule, err := decodeSchedule(source[32:])
+	if err != nil {
+		return err
+	}
+
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	copy(payload.Receiver.Value[:], source[:32])
+	payload.Schedule = schedule
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *TransferWithSchedulePayload) Size() int {
+	// 32 bytes (account address) + 1 byte (number of releases) + 16 bytes per release.
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+
+	return 33 + 16*len(payload.Schedule)
+}
+
+// Validate checks that the release schedule is accepted by the chain.
+func (payload *TransferWithSchedulePayload) Validate() error {
+	return validateSchedule(payload.Schedule)
+}
+
+// TransferWithScheduleAndMemoPayload transfers CCD to an account with a release schedule and a memo.
+type TransferWithScheduleAndMemoPayload struct {
+	// Address of the receiver account to which the amount will be sent.
+	Receiver *AccountAddress
+	// Memo to include in the transfer.
+	Memo *Memo
+	// The release schedule. Timestamps must be strictly increasing and amounts must be nonzero.
+	Schedule []NewRelease
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *TransferWithScheduleAndMemoPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(TransferWithScheduleAndMemoPayloadType))
+	buf = append(buf, payload.Receiver.Value[:]...)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(payload.Memo.Value)))
+	buf = append(buf, payload.Memo.Value...)
+	buf = appendSchedule(buf, payload.Schedule)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into TransferWithScheduleAndMemoPayload.
+func (payload *TransferWithScheduleAndMemoPayload) Decode(source []byte) error {
+	if len(source) <= 34 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	memoSize := int(binary.BigEndian.Uint16(source[32:34]))
+	if len(source) <= 34+memoSize {
+		return ErrInvalidRawPayloadSize
+	}
+
+	schedule, err := decodeSchedule(source[34+memoSize:])
+	if err != nil {
+		return err
+	}
+
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	copy(payload.Receiver.Value[:], source[:32])
+	payload.Memo = &Memo{Value: source[34 : 34+memoSize]}
+	payload.Schedule = schedule
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *TransferWithScheduleAndMemoPayload) Size() int {
+	// 32 bytes (account address) + 2 bytes (memo size) + memo bytes + 1 byte (number of releases) + 16 bytes per release.
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	if payload.Memo == nil {
+		payload.Memo = new(Memo)
+	}
+
+	return 35 + len(payload.Memo.Value) + 16*len(payload.Schedule)
+}
+
+// Validate checks that the release schedule is accepted by the chain.
+func (payload *TransferWithScheduleAndMemoPayload) Validate() error {
+	return validateSchedule(payload.Schedule)
+}
+
+// appendSchedule appends the number of releases followed by the timestamp and amount of each release.
+func appendSchedule(buf []byte, schedule []NewRelease) []byte {
+	buf = append(buf, uint8(len(schedule)))
+	for _, release := range schedule {
+		buf = binary.BigEndian.AppendUint64(buf, release.Timestamp.Value)
+		buf = binary.BigEndian.AppendUint64(buf, release.Amount.Value)
+	}
+	return buf
+}
+
+// decodeSchedule decodes a release schedule serialized by appendSchedule. The source must not contain trailing bytes.
+func decodeSchedule(source []byte) ([]NewRelease, error) {
+	if len(source) < 1 {
+		return nil, ErrInvalidRawPayloadSize
+	}
+	numReleases := int(source[0])
+	if len(source) != 1+16*numReleases {
+		return nil, ErrInvalidRawPayloadSize
+	}
+
+	schedule := make([]NewRelease, numReleases)
+	for i := range schedule {
+		offset := 1 + 16*i
+		schedule[i] = NewRelease{
+			Timestamp: Timestamp{Value: binary.BigEndian.Uint64(source[offset : offset+8])},
+			Amount:    Amount{Value: binary.BigEndian.Uint64(source[offset+8 : offset+16])},
+		}
+	}
+	return schedule, nil
+}
+
+// validateSchedule checks the number of releases, that the timestamps are strictly increasing and
+// that the amounts are nonzero.
+func validateSchedule(schedule []NewRelease) error {
+	if len(schedule) == 0 || len(schedule) > MaxReleases {
+		return ErrInvalidScheduleLength
+	}
+	for i, release := range schedule {
+		if release.Amount.Value == 0 {
+			return ErrZeroScheduledAmount
+		}
+		if i > 0 && release.Timestamp.Value <= schedule[i-1].Timestamp.Value {
+			return ErrScheduleNotIncreasing
+		}
+	}
+	return nil
+}
+
 // UpdateContractPayload updates a smart contract instance by invoking a specific function.
 type UpdateContractPayload struct {
 	// Send the given amount of CCD together with the message to the
@@ -450,15 +756,15 @@ func (payload *UpdateContractPayload) Decode(source []byte) error {
 	payload.Address.Index = binary.BigEndian.Uint64(source[8:16])
 	payload.Address.Subindex = binary.BigEndian.Uint64(source[16:24])
 
-	receiveNameSize := binary.BigEndian.Uint16(source[24:26])
-	if len(source) <= int(receiveNameSize+28) {
+	receiveNameSize := int(binary.BigEndian.Uint16(source[24:26]))
+	if len(source) < receiveNameSize+28 {
 		return ErrInvalidRawPayloadSize
 	}
 
 	payload.ReceiveName = &ReceiveName{Value: string(source[26 : 26+receiveNameSize])}
 
-	parameterSize := binary.BigEndian.Uint16(source[26+receiveNameSize : receiveNameSize+28])
-	if len(source) != int(receiveNameSize+parameterSize+28) {
+	parameterSize := int(binary.BigEndian.Uint16(source[26+receiveNameSize : receiveNameSize+28]))
+	if len(source) != receiveNameSize+parameterSize+28 {
 		return ErrInvalidRawPayloadSize
 	}
 
@@ -485,3 +791,1211 @@ func (payload *UpdateContractPayload) Size() int {
 
 	return 28 + len(payload.ReceiveName.Value) + len(payload.Parameter.Value)
 }
+
+// CredentialRegistrationIdLength is the length of a serialized CredentialRegistrationId.
+const CredentialRegistrationIdLength = 48
+
+// UpdateCredentialKeysPayload replaces the public keys and the signature threshold of a credential on the
+// sender account.
+type UpdateCredentialKeysPayload struct {
+	// Registration ID of the credential whose keys are updated.
+	CredId *CredentialRegistrationId
+	// The new public keys of the credential together with the signature threshold.
+	Keys *CredentialPublicKeys
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *UpdateCredentialKeysPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(UpdateCredentialKeysPayloadType))
+	buf = appendFixedSize(buf, payload.CredId.Value, CredentialRegistrationIdLength)
+	buf = appendCredentialPublicKeys(buf, *payload.Keys)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into UpdateCredentialKeysPayload.
+func (payload *UpdateCredentialKeysPayload) Decode(source []byte) error {
+	if len(source) <= CredentialRegistrationIdLength {
+		return ErrInvalidRawPayloadSize
+	}
+
+	keys, size, err := decodeCredentialPublicKeys(source[CredentialRegistrationIdLength:])
+	if err != nil {
+		return err
+	}
+	if len(source) != CredentialRegistrationIdLength+size {
+		return ErrInvalidRawPayloadSize
+	}
+
+	payload.CredId = &CredentialRegistrationId{Value: source[:CredentialRegistrationIdLength]}
+	payload.Keys = &keys
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *UpdateCredentialKeysPayload) Size() int {
+	// 48 bytes (credential registration ID) + size of credential public keys.
+	if payload.CredId == nil {
+		payload.CredId = new(CredentialRegistrationId)
+	}
+	if payload.Keys == nil {
+		payload.Keys = new(CredentialPublicKeys)
+	}
+
+	return CredentialRegistrationIdLength + credentialPublicKeysSize(*payload.Keys)
+}
+
+// UpdateCredentialsPayload adds and removes credentials of the sender account and updates the account threshold.
+type UpdateCredentialsPayload struct {
+	// New credentials to add to the account together with the indices they are added at.
+	NewCredentials map[CredentialIndex]CredentialDeploymentInfo
+	// Registration IDs of the credentials to remove from the account.
+	RemoveCredentialIds []CredentialRegistrationId
+	// The new account threshold.
+	NewThreshold *AccountThreshold
+}
+
+// CredentialDeploymentInfo a serialized credential including the proofs of its validity. The credential and
+// its proofs have to be generated from the identity object of the account holder, e.g. by a wallet.
+type CredentialDeploymentInfo struct {
+	Value []byte
+}
+
+// NumKeys returns the number of public keys of the credential.
+func (info CredentialDeploymentInfo) NumKeys() uint16 {
+	if len(info.Value) == 0 {
+		return 0
+	}
+	// the credential starts with its public keys, which are prefixed by their number.
+	return uint16(info.Value[0])
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *UpdateCredentialsPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(UpdateCredentialsPayloadType))
+	buf = append(buf, uint8(len(payload.NewCredentials)))
+	indices := make([]CredentialIndex, 0, len(payload.NewCredentials))
+	for index := range payload.NewCredentials {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	for _, index := range indices {
+		buf = append(buf, byte(index))
+		buf = append(buf, payload.NewCredentials[index].Value...)
+	}
+	buf = append(buf, uint8(len(payload.RemoveCredentialIds)))
+	for _, credId := range payload.RemoveCredentialIds {
+		buf = appendFixedSize(buf, credId.Value, CredentialRegistrationIdLength)
+	}
+	buf = append(buf, payload.NewThreshold.Value)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into UpdateCredentialsPayload.
+func (payload *UpdateCredentialsPayload) Decode(source []byte) error {
+	if len(source) < 3 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	numCredentials := int(source[0])
+	newCredentials := make(map[CredentialIndex]CredentialDeploymentInfo, numCredentials)
+	offset := 1
+	for i := 0; i < numCredentials; i++ {
+		if len(source) <= offset {
+			return ErrInvalidRawPayloadSize
+		}
+		index := CredentialIndex(source[offset])
+		offset += 1
+		size, err := credentialDeploymentInfoSize(source[offset:])
+		if err != nil {
+			return err
+		}
+		newCredentials[index] = CredentialDeploymentInfo{Value: source[offset : offset+size]}
+		offset += size
+	}
+
+	if len(source) <= offset {
+		return ErrInvalidRawPayloadSize
+	}
+	numRemoved := int(source[offset])
+	offset += 1
+	if len(source) != offset+numRemoved*CredentialRegistrationIdLength+1 {
+		return ErrInvalidRawPayloadSize
+	}
+	removeCredentialIds := make([]CredentialRegistrationId, numRemoved)
+	for i := range removeCredentialIds {
+		removeCredentialIds[i] = CredentialRegistrationId{Value: source[offset : offset+CredentialRegistrationIdLength]}
+		offset += CredentialRegistrationIdLength
+	}
+
+	payload.NewCredentials = newCredentials
+	payload.RemoveCredentialIds = removeCredentialIds
+	payload.NewThreshold = &AccountThreshold{Value: source[offset]}
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *UpdateCredentialsPayload) Size() int {
+	// 1 byte (number of new credentials) + 1 byte (index) per credential + credentials +
+	// 1 byte (number of removed credentials) + 48 bytes per removed credential + 1 byte (threshold).
+	if payload.NewThreshold == nil {
+		payload.NewThreshold = new(AccountThreshold)
+	}
+
+	size := 3 + len(payload.RemoveCredentialIds)*CredentialRegistrationIdLength
+	for _, info := range payload.NewCredentials {
+		size += 1 + len(info.Value)
+	}
+	return size
+}
+
+// NumKeys returns the number of keys of each new credential, ordered by credential index.
+func (payload *UpdateCredentialsPayload) NumKeys() []uint16 {
+	indices := make([]CredentialIndex, 0, len(payload.NewCredentials))
+	for index := range payload.NewCredentials {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	numKeys := make([]uint16, len(indices))
+	for i, index := range indices {
+		numKeys[i] = payload.NewCredentials[index].NumKeys()
+	}
+	return numKeys
+}
+
+// verifyKeyEd25519Tag is the tag of a serialized ed25519 AccountVerifyKey.
+const verifyKeyEd25519Tag byte = 0
+
+// appendCredentialPublicKeys appends the number of keys, each key prefixed by its index in increasing
+// order of the indices, and the signature threshold.
+func appendCredentialPublicKeys(buf []byte, keys CredentialPublicKeys) []byte {
+	indices := make([]KeyIndex, 0, len(keys.Keys))
+	for index := range keys.Keys {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	buf = append(buf, uint8(len(indices)))
+	for _, index := range indices {
+		buf = append(buf, byte(index), verifyKeyEd25519Tag)
+		buf = appendFixedSize(buf, keys.Keys[index].Ed25519Key, ed25519.PublicKeySize)
+	}
+	return append(buf, keys.Threshold.Value)
+}
+
+// credentialPublicKeysSize returns the size of CredentialPublicKeys serialized by appendCredentialPublicKeys.
+func credentialPublicKeysSize(keys CredentialPublicKeys) int {
+	// 1 byte (number of keys) + 34 bytes (index, tag and key) per key + 1 byte (threshold).
+	return 2 + len(keys.Keys)*(2+ed25519.PublicKeySize)
+}
+
+// decodeCredentialPublicKeys decodes CredentialPublicKeys from the beginning of source and returns
+// them together with the number of bytes read.
+func decodeCredentialPublicKeys(source []byte) (CredentialPublicKeys, int, error) {
+	if len(source) < 1 {
+		return CredentialPublicKeys{}, 0, ErrInvalidRawPayloadSize
+	}
+	numKeys := int(source[0])
+	size := 2 + numKeys*(2+ed25519.PublicKeySize)
+	if len(source) < size {
+		return CredentialPublicKeys{}, 0, ErrInvalidRawPayloadSize
+	}
+
+	keys := make(map[KeyIndex]AccountVerifyKey, numKeys)
+	offset := 1
+	for i := 0; i < numKeys; i++ {
+		index := KeyIndex(source[offset])
+		if source[offset+1] != verifyKeyEd25519Tag {
+			return CredentialPublicKeys{}, 0, errors.New("invalid verify key tag")
+		}
+		if _, ok := keys[index]; ok {
+			return CredentialPublicKeys{}, 0, errors.New("duplicate key index")
+		}
+		keys[index] = AccountVerifyKey{Ed25519Key: source[offset+2 : offset+2+ed25519.PublicKeySize]}
+		offset += 2 + ed25519.PublicKeySize
+	}
+
+	return CredentialPublicKeys{Keys: keys, Threshold: SignatureThreshold{Value: source[offset]}}, size, nil
+}
+
+// credentialDeploymentInfoSize returns the size of the serialized credential at the beginning of source.
+func credentialDeploymentInfoSize(source []byte) (int, error) {
+	_, offset, err := decodeCredentialPublicKeys(source)
+	if err != nil {
+		return 0, err
+	}
+	// credential registration ID + 4 bytes (identity provider) + 1 byte (anonymity revocation threshold).
+	offset += CredentialRegistrationIdLength + 5
+	// anonymity revoker data: 2 bytes (number of revokers) + 4 bytes (revoker ID) and 96 bytes (encrypted share) each.
+	if len(source) < offset+2 {
+		return 0, ErrInvalidRawPayloadSize
+	}
+	offset += 2 + int(binary.BigEndian.Uint16(source[offset:offset+2]))*100
+	// policy: 3 bytes (valid to) + 3 bytes (created at) + 2 bytes (number of revealed attributes).
+	if len(source) < offset+8 {
+		return 0, ErrInvalidRawPayloadSize
+	}
+	numAttributes := int(binary.BigEndian.Uint16(source[offset+6 : offset+8]))
+	offset += 8
+	for i := 0; i < numAttributes; i++ {
+		// 1 byte (attribute tag) + 1 byte (attribute size) + attribute.
+		if len(source) < offset+2 {
+			return 0, ErrInvalidRawPayloadSize
+		}
+		offset += 2 + int(source[offset+1])
+	}
+	// proofs: 4 bytes (size) + proofs.
+	if len(source) < offset+4 {
+		return 0, ErrInvalidRawPayloadSize
+	}
+	offset += 4 + int(binary.BigEndian.Uint32(source[offset:offset+4]))
+	if len(source) < offset {
+		return 0, ErrInvalidRawPayloadSize
+	}
+	return offset, nil
+}
+
+// Bits of the bitmap in ConfigureBakerPayload indicating which optional fields are present.
+const (
+	configureBakerCapitalBit uint16 = 1 << iota
+	configureBakerRestakeEarningsBit
+	configureBakerOpenForDelegationBit
+	configureBakerKeysWithProofsBit
+	configureBakerMetadataUrlBit
+	configureBakerTransactionFeeCommissionBit
+	configureBakerBakingRewardCommissionBit
+	configureBakerFinalizationRewardCommissionBit
+	configureBakerSuspendBit
+)
+
+// ConfigureBakerPayload configures the sender account as a baker. Only the fields that are not nil are
+// updated. When adding a baker, Capital, RestakeEarnings, OpenForDelegation, KeysWithProofs, MetadataUrl
+// and all commissions must be present. Setting Capital to 0 removes the baker.
+type ConfigureBakerPayload struct {
+	// The equity capital of the baker.
+	Capital *Amount
+	// Whether the baker's earnings are restaked.
+	RestakeEarnings *bool
+	// Whether the pool is open for delegators.
+	OpenForDelegation *OpenStatus
+	// The key/proof pairs to verify the baker.
+	KeysWithProofs *BakerKeysWithProofs
+	// The URL referencing the baker's metadata. Max size is 2048 bytes.
+	MetadataUrl *string
+	// The commission the pool owner takes on transaction fees.
+	TransactionFeeCommission *AmountFraction
+	// The commission the pool owner takes on baking rewards.
+	BakingRewardCommission *AmountFraction
+	// The commission the pool owner takes on finalization rewards.
+	FinalizationRewardCommission *AmountFraction
+	// Whether the baker should be suspended or resumed. Only supported from protocol version 8.
+	Suspend *bool
+}
+
+// BakerKeysWithProofs the public keys of a baker together with proofs of knowledge of the corresponding
+// private keys. The proofs are bound to the account address of the baker and have to be generated with
+// the baker's private keys, e.g. by concordium-client or a wallet.
+type BakerKeysWithProofs struct {
+	// Public key used to check whether the baker won the lottery. Must be BakerElectionVerifyKeyLength bytes.
+	ElectionVerifyKey BakerElectionVerifyKey
+	// Proof of knowledge of the secret election key.
+	ProofElection BakerKeyProof
+	// Public key used to check block signatures. Must be BakerSignatureVerifyKeyLength bytes.
+	SignatureVerifyKey BakerSignatureVerifyKey
+	// Proof of knowledge of the secret signature key.
+	ProofSig BakerKeyProof
+	// Public key used to check signatures on finalization records. Must be BakerAggregationVerifyKeyLength bytes.
+	AggregationVerifyKey BakerAggregationVerifyKey
+	// Proof of knowledge of the secret aggregation key.
+	ProofAggregation BakerKeyProof
+}
+
+// BakerKeyProof a proof of knowledge of a baker's private key.
+type BakerKeyProof struct {
+	Value [BakerKeyProofLength]byte
+}
+
+// bakerKeysWithProofsSize is the size of serialized BakerKeysWithProofs.
+const bakerKeysWithProofsSize = BakerElectionVerifyKeyLength + BakerSignatureVerifyKeyLength +
+	BakerAggregationVerifyKeyLength + 3*BakerKeyProofLength
+
+// appendBakerKeysWithProofs appends the keys each followed by its proof, as used by ConfigureBakerPayload.
+func appendBakerKeysWithProofs(buf []byte, keys BakerKeysWithProofs) []byte {
+	buf = appendFixedSize(buf, keys.ElectionVerifyKey.Value, BakerElectionVerifyKeyLength)
+	buf = append(buf, keys.ProofElection.Value[:]...)
+	buf = appendFixedSize(buf, keys.SignatureVerifyKey.Value, BakerSignatureVerifyKeyLength)
+	buf = append(buf, keys.ProofSig.Value[:]...)
+	buf = appendFixedSize(buf, keys.AggregationVerifyKey.Value, BakerAggregationVerifyKeyLength)
+	return append(buf, keys.ProofAggregation.Value[:]...)
+}
+
+// decodeBakerKeysWithProofs decodes keys serialized by appendBakerKeysWithProofs. The source must be
+// exactly bakerKeysWithProofsSize bytes long.
+func decodeBakerKeysWithProofs(source []byte) BakerKeysWithProofs {
+	var keys BakerKeysWithProofs
+	offset := 0
+	keys.ElectionVerifyKey = BakerElectionVerifyKey{Value: source[offset : offset+BakerElectionVerifyKeyLength]}
+	offset += BakerElectionVerifyKeyLength
+	copy(keys.ProofElection.Value[:], source[offset:offset+BakerKeyProofLength])
+	offset += BakerKeyProofLength
+	keys.SignatureVerifyKey = BakerSignatureVerifyKey{Value: source[offset : offset+BakerSignatureVerifyKeyLength]}
+	offset += BakerSignatureVerifyKeyLength
+	copy(keys.ProofSig.Value[:], source[offset:offset+BakerKeyProofLength])
+	offset += BakerKeyProofLength
+	keys.AggregationVerifyKey = BakerAggregationVerifyKey{Value: source[offset : offset+BakerAggregationVerifyKeyLength]}
+	offset += BakerAggregationVerifyKeyLength
+	copy(keys.ProofAggregation.Value[:], source[offset:offset+BakerKeyProofLength])
+	return keys
+}
+
+// appendLegacyBakerKeysWithProofs appends the keys followed by the proofs, as used by AddBakerPayload and
+// UpdateBakerKeysPayload.
+func appendLegacyBakerKeysWithProofs(buf []byte, keys BakerKeysWithProofs) []byte {
+	buf = appendFixedSize(buf, keys.ElectionVerifyKey.Value, BakerElectionVerifyKeyLength)
+	buf = appendFixedSize(buf, keys.SignatureVerifyKey.Value, BakerSignatureVerifyKeyLength)
+	buf = appendFixedSize(buf, keys.AggregationVerifyKey.Value, BakerAggregationVerifyKeyLength)
+	buf = append(buf, keys.ProofSig.Value[:]...)
+	buf = append(buf, keys.ProofElection.Value[:]...)
+	return append(buf, keys.ProofAggregation.Value[:]...)
+}
+
+// decodeLegacyBakerKeysWithProofs decodes keys serialized by appendLegacyBakerKeysWithProofs. The source must be
+// exactly bakerKeysWithProofsSize bytes long.
+func decodeLegacyBakerKeysWithProofs(source []byte) BakerKeysWithProofs {
+	var keys BakerKeysWithProofs
+	offset := 0
+	keys.ElectionVerifyKey = BakerElectionVerifyKey{Value: source[offset : offset+BakerElectionVerifyKeyLength]}
+	offset += BakerElectionVerifyKeyLength
+	keys.SignatureVerifyKey = BakerSignatureVerifyKey{Value: source[offset : offset+BakerSignatureVerifyKeyLength]}
+	offset += BakerSignatureVerifyKeyLength
+	keys.AggregationVerifyKey = BakerAggregationVerifyKey{Value: source[offset : offset+BakerAggregationVerifyKeyLength]}
+	offset += BakerAggregationVerifyKeyLength
+	copy(keys.ProofSig.Value[:], source[offset:offset+BakerKeyProofLength])
+	offset += BakerKeyProofLength
+	copy(keys.ProofElection.Value[:], source[offset:offset+BakerKeyProofLength])
+	offset += BakerKeyProofLength
+	copy(keys.ProofAggregation.Value[:], source[offset:offset+BakerKeyProofLength])
+	return keys
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *ConfigureBakerPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(ConfigureBakerPayloadType))
+	buf = binary.BigEndian.AppendUint16(buf, payload.bitmap())
+	if payload.Capital != nil {
+		buf = binary.BigEndian.AppendUint64(buf, payload.Capital.Value)
+	}
+	if payload.RestakeEarnings != nil {
+		buf = appendBool(buf, *payload.RestakeEarnings)
+	}
+	if payload.OpenForDelegation != nil {
+		buf = append(buf, byte(*payload.OpenForDelegation))
+	}
+	if payload.KeysWithProofs != nil {
+		buf = appendBakerKeysWithProofs(buf, *payload.KeysWithProofs)
+	}
+	if payload.MetadataUrl != nil {
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(*payload.MetadataUrl)))
+		buf = append(buf, *payload.MetadataUrl...)
+	}
+	if payload.TransactionFeeCommission != nil {
+		buf = binary.BigEndian.AppendUint32(buf, payload.TransactionFeeCommission.partsPerHundredThousand)
+	}
+	if payload.BakingRewardCommission != nil {
+		buf = binary.BigEndian.AppendUint32(buf, payload.BakingRewardCommission.partsPerHundredThousand)
+	}
+	if payload.FinalizationRewardCommission != nil {
+		buf = binary.BigEndian.AppendUint32(buf, payload.FinalizationRewardCommission.partsPerHundredThousand)
+	}
+	if payload.Suspend != nil {
+		buf = appendBool(buf, *payload.Suspend)
+	}
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into ConfigureBakerPayload.
+func (payload *ConfigureBakerPayload) Decode(source []byte) error {
+	if len(source) < 2 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	bitmap := binary.BigEndian.Uint16(source[:2])
+	if bitmap >= configureBakerSuspendBit<<1 {
+		return errors.New("invalid configure baker bitmap")
+	}
+
+	// Compute the expected size from the bitmap before reading any fields.
+	*payload = ConfigureBakerPayload{}
+	size := 2
+	if bitmap&configureBakerCapitalBit != 0 {
+		size += 8
+	}
+	if bitmap&configureBakerRestakeEarningsBit != 0 {
+		size += 1
+	}
+	if bitmap&configureBakerOpenForDelegationBit != 0 {
+		size += 1
+	}
+	if bitmap&configureBakerKeysWithProofsBit != 0 {
+		size += bakerKeysWithProofsSize
+	}
+	urlSize := 0
+	if bitmap&configureBakerMetadataUrlBit != 0 {
+		if len(source) < size+2 {
+			return ErrInvalidRawPayloadSize
+		}
+		urlSize = int(binary.BigEndian.Uint16(source[size : size+2]))
+		size += 2 + urlSize
+	}
+	for _, bit := range []uint16{
+		configureBakerTransactionFeeCommissionBit,
+		configureBakerBakingRewardCommissionBit,
+		configureBakerFinalizationRewardCommissionBit,
+	} {
+		if bitmap&bit != 0 {
+			size += 4
+		}
+	}
+	if bitmap&configureBakerSuspendBit != 0 {
+		size += 1
+	}
+	if len(source) != size {
+		return ErrInvalidRawPayloadSize
+	}
+
+	offset := 2
+	if bitmap&configureBakerCapitalBit != 0 {
+		payload.Capital = &Amount{Value: binary.BigEndian.Uint64(source[offset : offset+8])}
+		offset += 8
+	}
+	if bitmap&configureBakerRestakeEarningsBit != 0 {
+		restakeEarnings, err := decodeBool(source[offset])
+		if err != nil {
+			return err
+		}
+		payload.RestakeEarnings = &restakeEarnings
+		offset += 1
+	}
+	if bitmap&configureBakerOpenForDelegationBit != 0 {
+		openStatus := OpenStatus(source[offset])
+		if openStatus > OpenStatusClosedForAll {
+			return errors.New("invalid open status")
+		}
+		payload.OpenForDelegation = &openStatus
+		offset += 1
+	}
+	if bitmap&configureBakerKeysWithProofsBit != 0 {
+		keys := decodeBakerKeysWithProofs(source[offset : offset+bakerKeysWithProofsSize])
+		payload.KeysWithProofs = &keys
+		offset += bakerKeysWithProofsSize
+	}
+	if bitmap&configureBakerMetadataUrlBit != 0 {
+		url := string(source[offset+2 : offset+2+urlSize])
+		payload.MetadataUrl = &url
+		offset += 2 + urlSize
+	}
+	commissions := []struct {
+		bit   uint16
+		field **AmountFraction
+	}{
+		{configureBakerTransactionFeeCommissionBit, &payload.TransactionFeeCommission},
+		{configureBakerBakingRewardCommissionBit, &payload.BakingRewardCommission},
+		{configureBakerFinalizationRewardCommissionBit, &payload.FinalizationRewardCommission},
+	}
+	for _, commission := range commissions {
+		if bitmap&commission.bit == 0 {
+			continue
+		}
+		fraction, err := AmountFractionFromUInt32(binary.BigEndian.Uint32(source[offset : offset+4]))
+		if err != nil {
+			return err
+		}
+		*commission.field = &fraction
+		offset += 4
+	}
+	if bitmap&configureBakerSuspendBit != 0 {
+		suspend, err := decodeBool(source[offset])
+		if err != nil {
+			return err
+		}
+		payload.Suspend = &suspend
+	}
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *ConfigureBakerPayload) Size() int {
+	// 2 bytes (bitmap) + the sizes of the present fields.
+	size := 2
+	if payload.Capital != nil {
+		size += 8
+	}
+	if payload.RestakeEarnings != nil {
+		size += 1
+	}
+	if payload.OpenForDelegation != nil {
+		size += 1
+	}
+	if payload.KeysWithProofs != nil {
+		size += bakerKeysWithProofsSize
+	}
+	if payload.MetadataUrl != nil {
+		size += 2 + len(*payload.MetadataUrl)
+	}
+	if payload.TransactionFeeCommission != nil {
+		size += 4
+	}
+	if payload.BakingRewardCommission != nil {
+		size += 4
+	}
+	if payload.FinalizationRewardCommission != nil {
+		size += 4
+	}
+	if payload.Suspend != nil {
+		size += 1
+	}
+	return size
+}
+
+// bitmap returns the bitmap indicating which optional fields are present.
+func (payload *ConfigureBakerPayload) bitmap() uint16 {
+	var bitmap uint16
+	setIf := func(bit uint16, present bool) {
+		if present {
+			bitmap |= bit
+		}
+	}
+	setIf(configureBakerCapitalBit, payload.Capital != nil)
+	setIf(configureBakerRestakeEarningsBit, payload.RestakeEarnings != nil)
+	setIf(configureBakerOpenForDelegationBit, payload.OpenForDelegation != nil)
+	setIf(configureBakerKeysWithProofsBit, payload.KeysWithProofs != nil)
+	setIf(configureBakerMetadataUrlBit, payload.MetadataUrl != nil)
+	setIf(configureBakerTransactionFeeCommissionBit, payload.TransactionFeeCommission != nil)
+	setIf(configureBakerBakingRewardCommissionBit, payload.BakingRewardCommission != nil)
+	setIf(configureBakerFinalizationRewardCommissionBit, payload.FinalizationRewardCommission != nil)
+	setIf(configureBakerSuspendBit, payload.Suspend != nil)
+	return bitmap
+}
+
+// Bits of the bitmap in ConfigureDelegationPayload indicating which optional fields are present.
+const (
+	configureDelegationCapitalBit uint16 = 1 << iota
+	configureDelegationRestakeEarningsBit
+	configureDelegationTargetBit
+)
+
+// Tags of the serialized DelegationTarget.
+const (
+	delegationTargetPassiveTag byte = 0
+	delegationTargetBakerTag   byte = 1
+)
+
+// ConfigureDelegationPayload configures the sender account as a delegator. Only the fields that are not nil
+// are updated. When adding a delegator, all fields must be present. Setting Capital to 0 removes the delegator.
+type ConfigureDelegationPayload struct {
+	// The capital delegated to the pool.
+	Capital *Amount
+	// Whether the delegator's earnings are restaked.
+	RestakeEarnings *bool
+	// The target of the delegation.
+	DelegationTarget *DelegationTarget
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *ConfigureDelegationPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(ConfigureDelegationPayloadType))
+	var bitmap uint16
+	if payload.Capital != nil {
+		bitmap |= configureDelegationCapitalBit
+	}
+	if payload.RestakeEarnings != nil {
+		bitmap |= configureDelegationRestakeEarningsBit
+	}
+	if payload.DelegationTarget != nil {
+		bitmap |= configureDelegationTargetBit
+	}
+	buf = binary.BigEndian.AppendUint16(buf, bitmap)
+	if payload.Capital != nil {
+		buf = binary.BigEndian.AppendUint64(buf, payload.Capital.Value)
+	}
+	if payload.RestakeEarnings != nil {
+		buf = appendBool(buf, *payload.RestakeEarnings)
+	}
+	if payload.DelegationTarget != nil {
+		switch t := payload.DelegationTarget.Target.(type) {
+		case DelegationTargetBaker:
+			buf = append(buf, delegationTargetBakerTag)
+			buf = binary.BigEndian.AppendUint64(buf, t.BakerId.Value)
+		default:
+			buf = append(buf, delegationTargetPassiveTag)
+		}
+	}
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into ConfigureDelegationPayload.
+func (payload *ConfigureDelegationPayload) Decode(source []byte) error {
+	if len(source) < 2 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	bitmap := binary.BigEndian.Uint16(source[:2])
+	if bitmap >= configureDelegationTargetBit<<1 {
+		return errors.New("invalid configure delegation bitmap")
+	}
+
+	*payload = ConfigureDelegationPayload{}
+	offset := 2
+	if bitmap&configureDelegationCapitalBit != 0 {
+		if len(source) < offset+8 {
+			return ErrInvalidRawPayloadSize
+		}
+		payload.Capital = &Amount{Value: binary.BigEndian.Uint64(source[offset : offset+8])}
+		offset += 8
+	}
+	if bitmap&configureDelegationRestakeEarningsBit != 0 {
+		if len(source) < offset+1 {
+			return ErrInvalidRawPayloadSize
+		}
+		restakeEarnings, err := decodeBool(source[offset])
+		if err != nil {
+			return err
+		}
+		payload.RestakeEarnings = &restakeEarnings
+		offset += 1
+	}
+	if bitmap&configureDelegationTargetBit != 0 {
+		if len(source) < offset+1 {
+			return ErrInvalidRawPayloadSize
+		}
+		switch source[offset] {
+		case delegationTargetPassiveTag:
+			payload.DelegationTarget = &DelegationTarget{Target: DelegationTargetPassive{}}
+			offset += 1
+		case delegationTargetBakerTag:
+			if len(source) < offset+9 {
+				return ErrInvalidRawPayloadSize
+			}
+			payload.DelegationTarget = &DelegationTarget{Target: DelegationTargetBaker{
+				BakerId: BakerId{Value: binary.BigEndian.Uint64(source[offset+1 : offset+9])},
+			}}
+			offset += 9
+		default:
+			return errors.New("invalid delegation target")
+		}
+	}
+	if len(source) != offset {
+		return ErrInvalidRawPayloadSize
+	}
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *ConfigureDelegationPayload) Size() int {
+	// 2 bytes (bitmap) + the sizes of the present fields.
+	size := 2
+	if payload.Capital != nil {
+		size += 8
+	}
+	if payload.RestakeEarnings != nil {
+		size += 1
+	}
+	if payload.DelegationTarget != nil {
+		// 1 byte (tag) + 8 bytes (baker id) if delegating to a baker.
+		size += 1
+		if _, ok := payload.DelegationTarget.Target.(DelegationTargetBaker); ok {
+			size += 8
+		}
+	}
+	return size
+}
+
+// appendBool appends a boolean serialized as a single byte.
+func appendBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// decodeBool decodes a boolean serialized as a single byte.
+func decodeBool(b byte) (bool, error) {
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+	return false, errors.New("invalid bool value")
+}
+
+// appendFixedSize appends value padded or truncated to exactly size bytes.
+func appendFixedSize(buf []byte, value []byte, size int) []byte {
+	fixed := make([]byte, size)
+	copy(fixed, value)
+	return append(buf, fixed...)
+}
+
+// UnknownPayload a payload of a type that is not known to this SDK. It is returned when decoding payloads of
+// transaction types introduced in later protocol versions.
+type UnknownPayload struct {
+	// The payload type byte.
+	Type PayloadType
+	// The serialized payload without the type byte.
+	Bytes []byte
+}
+
+func (UnknownPayload) isAccountTransactionPayload() {}
+
+// Encode encodes the payload by prepending the type byte.
+func (payload UnknownPayload) Encode() *RawPayload {
+	buf := make([]byte, 0, len(payload.Bytes)+1)
+	buf = append(buf, byte(payload.Type))
+	buf = append(buf, payload.Bytes...)
+	return &RawPayload{Value: buf}
+}
+
+// AddBakerPayload registers the sender account as a baker. Only supported before protocol version 4,
+// use ConfigureBakerPayload instead.
+type AddBakerPayload struct {
+	// The keys of the baker together with the proofs of knowledge of the private keys.
+	KeysWithProofs *BakerKeysWithProofs
+	// The initial stake of the baker.
+	BakingStake *Amount
+	// Whether the baker's earnings are restaked.
+	RestakeEarnings *bool
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *AddBakerPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(AddBakerPayloadType))
+	buf = appendLegacyBakerKeysWithProofs(buf, *payload.KeysWithProofs)
+	buf = binary.BigEndian.AppendUint64(buf, payload.BakingStake.Value)
+	buf = appendBool(buf, *payload.RestakeEarnings)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into AddBakerPayload.
+func (payload *AddBakerPayload) Decode(source []byte) error {
+	if len(source) != bakerKeysWithProofsSize+9 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	restakeEarnings, err := decodeBool(source[bakerKeysWithProofsSize+8])
+	if err != nil {
+		return err
+	}
+	keys := decodeLegacyBakerKeysWithProofs(source[:bakerKeysWithProofsSize])
+	payload.KeysWithProofs = &keys
+	payload.BakingStake = &Amount{Value: binary.BigEndian.Uint64(source[bakerKeysWithProofsSize : bakerKeysWithProofsSize+8])}
+	payload.RestakeEarnings = &restakeEarnings
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *AddBakerPayload) Size() int {
+	// keys with proofs + 8 bytes (amount) + 1 byte (restake earnings).
+	if payload.KeysWithProofs == nil {
+		payload.KeysWithProofs = new(BakerKeysWithProofs)
+	}
+	if payload.BakingStake == nil {
+		payload.BakingStake = new(Amount)
+	}
+	if payload.RestakeEarnings == nil {
+		payload.RestakeEarnings = new(bool)
+	}
+
+	return bakerKeysWithProofsSize + 9
+}
+
+// RemoveBakerPayload removes the sender account as a baker. Only supported before protocol version 4,
+// use ConfigureBakerPayload instead.
+type RemoveBakerPayload struct{}
+
+// Encode encodes Payload into RawPayload.
+func (payload *RemoveBakerPayload) Encode() *RawPayload {
+	return &RawPayload{Value: []byte{byte(RemoveBakerPayloadType)}}
+}
+
+// Decode decodes bytes into RemoveBakerPayload.
+func (payload *RemoveBakerPayload) Decode(source []byte) error {
+	if len(source) != 0 {
+		return ErrInvalidRawPayloadSize
+	}
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *RemoveBakerPayload) Size() int {
+	return 0
+}
+
+// UpdateBakerStakePayload updates the stake of the baker. Only supported before protocol version 4,
+// use ConfigureBakerPayload instead.
+type UpdateBakerStakePayload struct {
+	// The new stake of the baker.
+	Stake *Amount
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *UpdateBakerStakePayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(UpdateBakerStakePayloadType))
+	buf = binary.BigEndian.AppendUint64(buf, payload.Stake.Value)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into UpdateBakerStakePayload.
+func (payload *UpdateBakerStakePayload) Decode(source []byte) error {
+	if len(source) != 8 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	payload.Stake = &Amount{Value: binary.BigEndian.Uint64(source)}
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *UpdateBakerStakePayload) Size() int {
+	// 8 bytes (amount).
+	if payload.Stake == nil {
+		payload.Stake = new(Amount)
+	}
+
+	return 8
+}
+
+// UpdateBakerRestakeEarningsPayload updates whether the earnings of the baker are restaked. Only supported
+// before protocol version 4, use ConfigureBakerPayload instead.
+type UpdateBakerRestakeEarningsPayload struct {
+	// Whether the baker's earnings are restaked.
+	RestakeEarnings *bool
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *UpdateBakerRestakeEarningsPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(UpdateBakerRestakeEarningsPayloadType))
+	buf = appendBool(buf, *payload.RestakeEarnings)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into UpdateBakerRestakeEarningsPayload.
+func (payload *UpdateBakerRestakeEarningsPayload) Decode(source []byte) error {
+	if len(source) != 1 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	restakeEarnings, err := decodeBool(source[0])
+	if err != nil {
+		return err
+	}
+	payload.RestakeEarnings = &restakeEarnings
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *UpdateBakerRestakeEarningsPayload) Size() int {
+	// 1 byte (restake earnings).
+	if payload.RestakeEarnings == nil {
+		payload.RestakeEarnings = new(bool)
+	}
+
+	return 1
+}
+
+// UpdateBakerKeysPayload updates the keys of the baker. Only supported before protocol version 4,
+// use ConfigureBakerPayload instead.
+type UpdateBakerKeysPayload struct {
+	// The new keys of the baker together with the proofs of knowledge of the private keys.
+	KeysWithProofs *BakerKeysWithProofs
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *UpdateBakerKeysPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(UpdateBakerKeysPayloadType))
+	buf = appendLegacyBakerKeysWithProofs(buf, *payload.KeysWithProofs)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into UpdateBakerKeysPayload.
+func (payload *UpdateBakerKeysPayload) Decode(source []byte) error {
+	if len(source) != bakerKeysWithProofsSize {
+		return ErrInvalidRawPayloadSize
+	}
+
+	keys := decodeLegacyBakerKeysWithProofs(source)
+	payload.KeysWithProofs = &keys
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *UpdateBakerKeysPayload) Size() int {
+	if payload.KeysWithProofs == nil {
+		payload.KeysWithProofs = new(BakerKeysWithProofs)
+	}
+
+	return bakerKeysWithProofsSize
+}
+
+// EncryptedAmountLength is the length of a serialized EncryptedAmount.
+const EncryptedAmountLength = 192
+
+// EncryptedAmountTransferData the data of a transfer of an encrypted amount between two accounts.
+type EncryptedAmountTransferData struct {
+	// The encrypted amount remaining on the sender account.
+	RemainingAmount EncryptedAmount
+	// The encrypted amount that is transferred.
+	TransferAmount EncryptedAmount
+	// The index up to which the incoming amounts on the sender account were used.
+	Index uint64
+	// Proof that the transfer is valid. It has to be generated with the secret decryption key of the sender.
+	Proof []byte
+}
+
+// appendEncryptedAmountTransferData appends the amounts, the index and the proof. The proof is not length
+// prefixed and must therefore be the last field of a payload.
+func appendEncryptedAmountTransferData(buf []byte, data EncryptedAmountTransferData) []byte {
+	buf = appendFixedSize(buf, data.RemainingAmount.Value, EncryptedAmountLength)
+	buf = appendFixedSize(buf, data.TransferAmount.Value, EncryptedAmountLength)
+	buf = binary.BigEndian.AppendUint64(buf, data.Index)
+	return append(buf, data.Proof...)
+}
+
+// decodeEncryptedAmountTransferData decodes data serialized by appendEncryptedAmountTransferData.
+func decodeEncryptedAmountTransferData(source []byte) (EncryptedAmountTransferData, error) {
+	if len(source) < 2*EncryptedAmountLength+8 {
+		return EncryptedAmountTransferData{}, ErrInvalidRawPayloadSize
+	}
+
+	return EncryptedAmountTransferData{
+		RemainingAmount: EncryptedAmount{Value: source[:EncryptedAmountLength]},
+		TransferAmount:  EncryptedAmount{Value: source[EncryptedAmountLength : 2*EncryptedAmountLength]},
+		Index:           binary.BigEndian.Uint64(source[2*EncryptedAmountLength : 2*EncryptedAmountLength+8]),
+		Proof:           source[2*EncryptedAmountLength+8:],
+	}, nil
+}
+
+// EncryptedAmountTransferPayload transfers an encrypted amount to another account.
+type EncryptedAmountTransferPayload struct {
+	// Address of the receiver account to which the amount will be sent.
+	Receiver *AccountAddress
+	// The encrypted amounts and the proof of the transfer.
+	Data *EncryptedAmountTransferData
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *EncryptedAmountTransferPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(EncryptedAmountTransferPayloadType))
+	buf = append(buf, payload.Receiver.Value[:]...)
+	buf = appendEncryptedAmountTransferData(buf, *payload.Data)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into EncryptedAmountTransferPayload.
+func (payload *EncryptedAmountTransferPayload) Decode(source []byte) error {
+	if len(source) < 32 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	data, err := decodeEncryptedAmountTransferData(source[32:])
+	if err != nil {
+		return err
+	}
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	copy(payload.Receiver.Value[:], source[:32])
+	payload.Data = &data
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *EncryptedAmountTransferPayload) Size() int {
+	// 32 bytes (account address) + 2*192 bytes (encrypted amounts) + 8 bytes (index) + proof bytes.
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	if payload.Data == nil {
+		payload.Data = new(EncryptedAmountTransferData)
+	}
+
+	return 32 + 2*EncryptedAmountLength + 8 + len(payload.Data.Proof)
+}
+
+// EncryptedAmountTransferWithMemoPayload transfers an encrypted amount to another account with a memo.
+type EncryptedAmountTransferWithMemoPayload struct {
+	// Address of the receiver account to which the amount will be sent.
+	Receiver *AccountAddress
+	// Memo to include in the transfer.
+	Memo *Memo
+	// The encrypted amounts and the proof of the transfer.
+	Data *EncryptedAmountTransferData
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *EncryptedAmountTransferWithMemoPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(EncryptedAmountTransferWithMemoPayloadType))
+	buf = append(buf, payload.Receiver.Value[:]...)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(payload.Memo.Value)))
+	buf = append(buf, payload.Memo.Value...)
+	buf = appendEncryptedAmountTransferData(buf, *payload.Data)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into EncryptedAmountTransferWithMemoPayload.
+func (payload *EncryptedAmountTransferWithMemoPayload) Decode(source []byte) error {
+	if len(source) < 34 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	memoSize := int(binary.BigEndian.Uint16(source[32:34]))
+	if len(source) < 34+memoSize {
+		return ErrInvalidRawPayloadSize
+	}
+	data, err := decodeEncryptedAmountTransferData(source[34+memoSize:])
+	if err != nil {
+		return err
+	}
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	copy(payload.Receiver.Value[:], source[:32])
+	payload.Memo = &Memo{Value: source[34 : 34+memoSize]}
+	payload.Data = &data
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *EncryptedAmountTransferWithMemoPayload) Size() int {
+	// 32 bytes (account address) + 2 bytes (memo size) + memo bytes + 2*192 bytes (encrypted amounts) +
+	// 8 bytes (index) + proof bytes.
+	if payload.Receiver == nil {
+		payload.Receiver = new(AccountAddress)
+	}
+	if payload.Memo == nil {
+		payload.Memo = new(Memo)
+	}
+	if payload.Data == nil {
+		payload.Data = new(EncryptedAmountTransferData)
+	}
+
+	return 34 + len(payload.Memo.Value) + 2*EncryptedAmountLength + 8 + len(payload.Data.Proof)
+}
+
+// TransferToEncryptedPayload transfers an amount from the public balance to the encrypted balance of the
+// sender account.
+type TransferToEncryptedPayload struct {
+	// The amount to transfer.
+	Amount *Amount
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *TransferToEncryptedPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(TransferToEncryptedPayloadType))
+	buf = binary.BigEndian.AppendUint64(buf, payload.Amount.Value)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into TransferToEncryptedPayload.
+func (payload *TransferToEncryptedPayload) Decode(source []byte) error {
+	if len(source) != 8 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	payload.Amount = &Amount{Value: binary.BigEndian.Uint64(source)}
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *TransferToEncryptedPayload) Size() int {
+	// 8 bytes (amount).
+	if payload.Amount == nil {
+		payload.Amount = new(Amount)
+	}
+
+	return 8
+}
+
+// TransferToPublicPayload transfers an amount from the encrypted balance to the public balance of the
+// sender account.
+type TransferToPublicPayload struct {
+	// The encrypted amount remaining on the sender account.
+	RemainingAmount *EncryptedAmount
+	// The amount to transfer.
+	TransferAmount *Amount
+	// The index up to which the incoming amounts on the sender account were used.
+	Index uint64
+	// Proof that the transfer is valid. It has to be generated with the secret decryption key of the sender.
+	Proof []byte
+}
+
+// Encode encodes Payload into RawPayload.
+func (payload *TransferToPublicPayload) Encode() *RawPayload {
+	// Payload type byte + payload size.
+	buf := make([]byte, 0, payload.Size()+1)
+	buf = append(buf, byte(TransferToPublicPayloadType))
+	buf = appendFixedSize(buf, payload.RemainingAmount.Value, EncryptedAmountLength)
+	buf = binary.BigEndian.AppendUint64(buf, payload.TransferAmount.Value)
+	buf = binary.BigEndian.AppendUint64(buf, payload.Index)
+	buf = append(buf, payload.Proof...)
+	return &RawPayload{Value: buf}
+}
+
+// Decode decodes bytes into TransferToPublicPayload.
+func (payload *TransferToPublicPayload) Decode(source []byte) error {
+	if len(source) < EncryptedAmountLength+16 {
+		return ErrInvalidRawPayloadSize
+	}
+
+	payload.RemainingAmount = &EncryptedAmount{Value: source[:EncryptedAmountLength]}
+	payload.TransferAmount = &Amount{Value: binary.BigEndian.Uint64(source[EncryptedAmountLength : EncryptedAmountLength+8])}
+	payload.Index = binary.BigEndian.Uint64(source[EncryptedAmountLength+8 : EncryptedAmountLength+16])
+	payload.Proof = source[EncryptedAmountLength+16:]
+
+	return nil
+}
+
+// Size returns the size of the payload in number of bytes.
+func (payload *TransferToPublicPayload) Size() int {
+	// 192 bytes (encrypted amount) + 8 bytes (amount) + 8 bytes (index) + proof bytes.
+	if payload.RemainingAmount == nil {
+		payload.RemainingAmount = new(EncryptedAmount)
+	}
+	if payload.TransferAmount == nil {
+		payload.TransferAmount = new(Amount)
+	}
+
+	return EncryptedAmountLength + 16 + len(payload.Proof)
+}
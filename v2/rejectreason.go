@@ -0,0 +1,644 @@
+package v2
+
+import (
+	"fmt"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// RejectReason the reason why a transaction was rejected. A rejected transaction is still included
+// in a block and the sender is charged for it. Every variant implements error, so a RejectReason
+// can be returned and inspected with errors.As.
+type RejectReason interface {
+	error
+	isRejectReason()
+}
+
+// RejectReasonModuleNotWf the module is not well-formed.
+type RejectReasonModuleNotWf struct{}
+
+// RejectReasonModuleHashAlreadyExists a module with the same hash already exists.
+type RejectReasonModuleHashAlreadyExists struct {
+	ModuleRef ModuleRef
+}
+
+// RejectReasonInvalidAccountReference the account does not exist.
+type RejectReasonInvalidAccountReference struct {
+	Address AccountAddress
+}
+
+// RejectReasonInvalidInitMethod the module does not have an init function with the given name.
+type RejectReasonInvalidInitMethod struct {
+	ModuleRef ModuleRef
+	InitName  InitName
+}
+
+// RejectReasonInvalidReceiveMethod the module does not have a receive function with the given name.
+type RejectReasonInvalidReceiveMethod struct {
+	ModuleRef   ModuleRef
+	ReceiveName ReceiveName
+}
+
+// RejectReasonInvalidModuleReference the module does not exist.
+type RejectReasonInvalidModuleReference struct {
+	ModuleRef ModuleRef
+}
+
+// RejectReasonInvalidContractAddress the contract instance does not exist.
+type RejectReasonInvalidContractAddress struct {
+	Address ContractAddress
+}
+
+// RejectReasonRuntimeFailure runtime exception occurred when running either the init or receive method.
+type RejectReasonRuntimeFailure struct{}
+
+// RejectReasonAmountTooLarge the amount is larger than the balance of the sender. Address is either
+// *AccountAddress or *ContractAddress.
+type RejectReasonAmountTooLarge struct {
+	Address isAddress
+	Amount  Amount
+}
+
+// RejectReasonSerializationFailure serialization of the body failed.
+type RejectReasonSerializationFailure struct{}
+
+// RejectReasonOutOfEnergy we ran out of energy.
+type RejectReasonOutOfEnergy struct{}
+
+// RejectReasonRejectedInit rejected due to contract logic in the init function of a contract.
+type RejectReasonRejectedInit struct {
+	RejectReason int32
+}
+
+// RejectReasonRejectedReceive rejected due to contract logic in the receive function of a contract.
+type RejectReasonRejectedReceive struct {
+	RejectReason    int32
+	ContractAddress ContractAddress
+	ReceiveName     ReceiveName
+	Parameter       Parameter
+}
+
+// RejectReasonInvalidProof proof that the baker owns relevant private keys is not valid.
+type RejectReasonInvalidProof struct{}
+
+// RejectReasonAlreadyABaker tried to add baker for an account that already has a baker.
+type RejectReasonAlreadyABaker struct {
+	BakerId BakerId
+}
+
+// RejectReasonNotABaker tried to remove a baker for an account that has no baker.
+type RejectReasonNotABaker struct {
+	Address AccountAddress
+}
+
+// RejectReasonInsufficientBalanceForBakerStake the amount on the account was insufficient to cover the proposed stake.
+type RejectReasonInsufficientBalanceForBakerStake struct{}
+
+// RejectReasonStakeUnderMinimumThresholdForBaking the amount provided is under the threshold required for becoming a baker.
+type RejectReasonStakeUnderMinimumThresholdForBaking struct{}
+
+// RejectReasonBakerInCooldown the change could not be made because the baker is in cooldown for another change.
+type RejectReasonBakerInCooldown struct{}
+
+// RejectReasonDuplicateAggregationKey a baker with the given aggregation key already exists.
+type RejectReasonDuplicateAggregationKey struct {
+	Key BakerAggregationVerifyKey
+}
+
+// RejectReasonNonExistentCredentialId encountered credential ID that does not exist.
+type RejectReasonNonExistentCredentialId struct{}
+
+// RejectReasonKeyIndexAlreadyInUse attempted to add an account key to a key index already in use.
+type RejectReasonKeyIndexAlreadyInUse struct{}
+
+// RejectReasonInvalidAccountThreshold when the account threshold is updated, it must not exceed the amount of existing keys.
+type RejectReasonInvalidAccountThreshold struct{}
+
+// RejectReasonInvalidCredentialKeySignThreshold when the credential key threshold is updated, it must not exceed the amount of existing keys.
+type RejectReasonInvalidCredentialKeySignThreshold struct{}
+
+// RejectReasonInvalidEncryptedAmountTransferProof proof for an encrypted amount transfer did not validate.
+type RejectReasonInvalidEncryptedAmountTransferProof struct{}
+
+// RejectReasonInvalidTransferToPublicProof proof for a secret to public transfer did not validate.
+type RejectReasonInvalidTransferToPublicProof struct{}
+
+// RejectReasonEncryptedAmountSelfTransfer account tried to transfer an encrypted amount to itself, that's not allowed.
+type RejectReasonEncryptedAmountSelfTransfer struct {
+	Address AccountAddress
+}
+
+// RejectReasonInvalidIndexOnEncryptedTransfer the provided index is below the start index or above `startIndex + length incomingAmounts`.
+type RejectReasonInvalidIndexOnEncryptedTransfer struct{}
+
+// RejectReasonZeroScheduledAmount the transfer with schedule is going to send 0 tokens.
+type RejectReasonZeroScheduledAmount struct{}
+
+// RejectReasonNonIncreasingSchedule the transfer with schedule has a non strictly increasing schedule.
+type RejectReasonNonIncreasingSchedule struct{}
+
+// RejectReasonFirstScheduledReleaseExpired the first scheduled release in a transfer with schedule has already expired.
+type RejectReasonFirstScheduledReleaseExpired struct{}
+
+// RejectReasonScheduledSelfTransfer account tried to transfer with schedule to itself, that's not allowed.
+type RejectReasonScheduledSelfTransfer struct {
+	Address AccountAddress
+}
+
+// RejectReasonInvalidCredentials at least one of the credentials was either malformed or its proof was incorrect.
+type RejectReasonInvalidCredentials struct{}
+
+// RejectReasonDuplicateCredIds some of the credential IDs already exist or are duplicated in the transaction.
+type RejectReasonDuplicateCredIds struct {
+	Ids []CredentialRegistrationId
+}
+
+// RejectReasonNonExistentCredIds a credential id that was to be removed is not part of the account.
+type RejectReasonNonExistentCredIds struct {
+	Ids []CredentialRegistrationId
+}
+
+// RejectReasonRemoveFirstCredential attempt to remove the first credential.
+type RejectReasonRemoveFirstCredential struct{}
+
+// RejectReasonCredentialHolderDidNotSign the credential holder of the keys to be updated did not sign the transaction.
+type RejectReasonCredentialHolderDidNotSign struct{}
+
+// RejectReasonNotAllowedMultipleCredentials account is not allowed to have multiple credentials because it contains a non-zero encrypted transfer.
+type RejectReasonNotAllowedMultipleCredentials struct{}
+
+// RejectReasonNotAllowedToReceiveEncrypted the account is not allowed to receive encrypted transfers because it has multiple credentials.
+type RejectReasonNotAllowedToReceiveEncrypted struct{}
+
+// RejectReasonNotAllowedToHandleEncrypted the account is not allowed to send encrypted transfers (or transfer from/to public to/from encrypted).
+type RejectReasonNotAllowedToHandleEncrypted struct{}
+
+// RejectReasonMissingBakerAddParameters a configure baker transaction is missing one or more arguments in order to add a baker.
+type RejectReasonMissingBakerAddParameters struct{}
+
+// RejectReasonFinalizationRewardCommissionNotInRange finalization reward commission is not in the valid range for a baker.
+type RejectReasonFinalizationRewardCommissionNotInRange struct{}
+
+// RejectReasonBakingRewardCommissionNotInRange baking reward commission is not in the valid range for a baker.
+type RejectReasonBakingRewardCommissionNotInRange struct{}
+
+// RejectReasonTransactionFeeCommissionNotInRange transaction fee commission is not in the valid range for a baker.
+type RejectReasonTransactionFeeCommissionNotInRange struct{}
+
+// RejectReasonAlreadyADelegator tried to add a baker for an account that already has a delegator.
+type RejectReasonAlreadyADelegator struct{}
+
+// RejectReasonInsufficientBalanceForDelegationStake the amount on the account was insufficient to cover the proposed stake.
+type RejectReasonInsufficientBalanceForDelegationStake struct{}
+
+// RejectReasonMissingDelegationAddParameters a configure delegation transaction is missing one or more arguments in order to add a delegator.
+type RejectReasonMissingDelegationAddParameters struct{}
+
+// RejectReasonInsufficientDelegationStake delegation stake when adding a delegator was 0.
+type RejectReasonInsufficientDelegationStake struct{}
+
+// RejectReasonDelegatorInCooldown the change could not be made because the delegator is in cooldown.
+type RejectReasonDelegatorInCooldown struct{}
+
+// RejectReasonNotADelegator account is not a delegation account.
+type RejectReasonNotADelegator struct {
+	Address AccountAddress
+}
+
+// RejectReasonDelegationTargetNotABaker delegation target is not a baker.
+type RejectReasonDelegationTargetNotABaker struct {
+	BakerId BakerId
+}
+
+// RejectReasonStakeOverMaximumThresholdForPool the amount would result in pool capital higher than the maximum threshold.
+type RejectReasonStakeOverMaximumThresholdForPool struct{}
+
+// RejectReasonPoolWouldBecomeOverDelegated the amount would result in pool with a too high fraction of delegated capital.
+type RejectReasonPoolWouldBecomeOverDelegated struct{}
+
+// RejectReasonPoolClosed the pool is not open to delegators.
+type RejectReasonPoolClosed struct{}
+
+func (RejectReasonModuleNotWf) isRejectReason()                            {}
+func (RejectReasonModuleHashAlreadyExists) isRejectReason()                {}
+func (RejectReasonInvalidAccountReference) isRejectReason()                {}
+func (RejectReasonInvalidInitMethod) isRejectReason()                      {}
+func (RejectReasonInvalidReceiveMethod) isRejectReason()                   {}
+func (RejectReasonInvalidModuleReference) isRejectReason()                 {}
+func (RejectReasonInvalidContractAddress) isRejectReason()                 {}
+func (RejectReasonRuntimeFailure) isRejectReason()                         {}
+func (RejectReasonAmountTooLarge) isRejectReason()                         {}
+func (RejectReasonSerializationFailure) isRejectReason()                   {}
+func (RejectReasonOutOfEnergy) isRejectReason()                            {}
+func (RejectReasonRejectedInit) isRejectReason()                           {}
+func (RejectReasonRejectedReceive) isRejectReason()                        {}
+func (RejectReasonInvalidProof) isRejectReason()                           {}
+func (RejectReasonAlreadyABaker) isRejectReason()                          {}
+func (RejectReasonNotABaker) isRejectReason()                              {}
+func (RejectReasonInsufficientBalanceForBakerStake) isRejectReason()       {}
+func (RejectReasonStakeUnderMinimumThresholdForBaking) isRejectReason()    {}
+func (RejectReasonBakerInCooldown) isRejectReason()                        {}
+func (RejectReasonDuplicateAggregationKey) isRejectReason()                {}
+func (RejectReasonNonExistentCredentialId) isRejectReason()                {}
+func (RejectReasonKeyIndexAlreadyInUse) isRejectReason()                   {}
+func (RejectReasonInvalidAccountThreshold) isRejectReason()                {}
+func (RejectReasonInvalidCredentialKeySignThreshold) isRejectReason()      {}
+func (RejectReasonInvalidEncryptedAmountTransferProof) isRejectReason()    {}
+func (RejectReasonInvalidTransferToPublicProof) isRejectReason()           {}
+func (RejectReasonEncryptedAmountSelfTransfer) isRejectReason()            {}
+func (RejectReasonInvalidIndexOnEncryptedTransfer) isRejectReason()        {}
+func (RejectReasonZeroScheduledAmount) isRejectReason()                    {}
+func (RejectReasonNonIncreasingSchedule) isRejectReason()                  {}
+func (RejectReasonFirstScheduledReleaseExpired) isRejectReason()           {}
+func (RejectReasonScheduledSelfTransfer) isRejectReason()                  {}
+func (RejectReasonInvalidCredentials) isRejectReason()                     {}
+func (RejectReasonDuplicateCredIds) isRejectReason()                       {}
+func (RejectReasonNonExistentCredIds) isRejectReason()                     {}
+func (RejectReasonRemoveFirstCredential) isRejectReason()                  {}
+func (RejectReasonCredentialHolderDidNotSign) isRejectReason()             {}
+func (RejectReasonNotAllowedMultipleCredentials) isRejectReason()          {}
+func (RejectReasonNotAllowedToReceiveEncrypted) isRejectReason()           {}
+func (RejectReasonNotAllowedToHandleEncrypted) isRejectReason()            {}
+func (RejectReasonMissingBakerAddParameters) isRejectReason()              {}
+func (RejectReasonFinalizationRewardCommissionNotInRange) isRejectReason() {}
+func (RejectReasonBakingRewardCommissionNotInRange) isRejectReason()       {}
+func (RejectReasonTransactionFeeCommissionNotInRange) isRejectReason()     {}
+func (RejectReasonAlreadyADelegator) isRejectReason()                      {}
+func (RejectReasonInsufficientBalanceForDelegationStake) isRejectReason()  {}
+func (RejectReasonMissingDelegationAddParameters) isRejectReason()         {}
+func (RejectReasonInsufficientDelegationStake) isRejectReason()            {}
+func (RejectReasonDelegatorInCooldown) isRejectReason()                    {}
+func (RejectReasonNotADelegator) isRejectReason()                          {}
+func (RejectReasonDelegationTargetNotABaker) isRejectReason()              {}
+func (RejectReasonStakeOverMaximumThresholdForPool) isRejectReason()       {}
+func (RejectReasonPoolWouldBecomeOverDelegated) isRejectReason()           {}
+func (RejectReasonPoolClosed) isRejectReason()                             {}
+
+func (RejectReasonModuleNotWf) Error() string {
+	return "module is not well-formed"
+}
+
+func (r RejectReasonModuleHashAlreadyExists) Error() string {
+	return fmt.Sprintf("module with hash %s already exists", r.ModuleRef.Hex())
+}
+
+func (r RejectReasonInvalidAccountReference) Error() string {
+	return fmt.Sprintf("account %s does not exist", r.Address.ToBase58())
+}
+
+func (r RejectReasonInvalidInitMethod) Error() string {
+	return fmt.Sprintf("module %s does not have an init function %q", r.ModuleRef.Hex(), r.InitName.Value)
+}
+
+func (r RejectReasonInvalidReceiveMethod) Error() string {
+	return fmt.Sprintf("module %s does not have a receive function %q", r.ModuleRef.Hex(), r.ReceiveName.Value)
+}
+
+func (r RejectReasonInvalidModuleReference) Error() string {
+	return fmt.Sprintf("module %s does not exist", r.ModuleRef.Hex())
+}
+
+func (r RejectReasonInvalidContractAddress) Error() string {
+	return fmt.Sprintf("contract instance <%d,%d> does not exist", r.Address.Index, r.Address.Subindex)
+}
+
+func (RejectReasonRuntimeFailure) Error() string {
+	return "runtime failure while executing smart contract"
+}
+
+func (r RejectReasonAmountTooLarge) Error() string {
+	return fmt.Sprintf("amount %d microCCD is larger than the balance of %s", r.Amount.Value, formatAddress(r.Address))
+}
+
+func (RejectReasonSerializationFailure) Error() string {
+	return "serialization of the transaction body failed"
+}
+
+func (RejectReasonOutOfEnergy) Error() string {
+	return "out of energy"
+}
+
+func (r RejectReasonRejectedInit) Error() string {
+	return fmt.Sprintf("contract init rejected with reason %d", r.RejectReason)
+}
+
+func (r RejectReasonRejectedReceive) Error() string {
+	return fmt.Sprintf("contract <%d,%d> rejected %q with reason %d",
+		r.ContractAddress.Index, r.ContractAddress.Subindex, r.ReceiveName.Value, r.RejectReason)
+}
+
+func (RejectReasonInvalidProof) Error() string {
+	return "proof that the baker owns relevant private keys is not valid"
+}
+
+func (r RejectReasonAlreadyABaker) Error() string {
+	return fmt.Sprintf("account is already baker %d", r.BakerId.Value)
+}
+
+func (r RejectReasonNotABaker) Error() string {
+	return fmt.Sprintf("account %s is not a baker", r.Address.ToBase58())
+}
+
+func (RejectReasonInsufficientBalanceForBakerStake) Error() string {
+	return "insufficient balance for baker stake"
+}
+
+func (RejectReasonStakeUnderMinimumThresholdForBaking) Error() string {
+	return "stake is under the minimum threshold for baking"
+}
+
+func (RejectReasonBakerInCooldown) Error() string {
+	return "baker is in cooldown"
+}
+
+func (RejectReasonDuplicateAggregationKey) Error() string {
+	return "duplicate aggregation key"
+}
+
+func (RejectReasonNonExistentCredentialId) Error() string {
+	return "credential ID does not exist"
+}
+
+func (RejectReasonKeyIndexAlreadyInUse) Error() string {
+	return "key index already in use"
+}
+
+func (RejectReasonInvalidAccountThreshold) Error() string {
+	return "account threshold exceeds the number of credentials"
+}
+
+func (RejectReasonInvalidCredentialKeySignThreshold) Error() string {
+	return "signature threshold exceeds the number of keys of the credential"
+}
+
+func (RejectReasonInvalidEncryptedAmountTransferProof) Error() string {
+	return "invalid encrypted amount transfer proof"
+}
+
+func (RejectReasonInvalidTransferToPublicProof) Error() string {
+	return "invalid transfer to public proof"
+}
+
+func (r RejectReasonEncryptedAmountSelfTransfer) Error() string {
+	return fmt.Sprintf("account %s tried to transfer an encrypted amount to itself", r.Address.ToBase58())
+}
+
+func (RejectReasonInvalidIndexOnEncryptedTransfer) Error() string {
+	return "invalid index on encrypted transfer"
+}
+
+func (RejectReasonZeroScheduledAmount) Error() string {
+	return "scheduled transfer amount is zero"
+}
+
+func (RejectReasonNonIncreasingSchedule) Error() string {
+	return "release schedule is not strictly increasing"
+}
+
+func (RejectReasonFirstScheduledReleaseExpired) Error() string {
+	return "first scheduled release has already expired"
+}
+
+func (r RejectReasonScheduledSelfTransfer) Error() string {
+	return fmt.Sprintf("account %s tried to transfer with schedule to itself", r.Address.ToBase58())
+}
+
+func (RejectReasonInvalidCredentials) Error() string {
+	return "invalid credentials"
+}
+
+func (r RejectReasonDuplicateCredIds) Error() string {
+	return fmt.Sprintf("%d duplicate credential IDs", len(r.Ids))
+}
+
+func (r RejectReasonNonExistentCredIds) Error() string {
+	return fmt.Sprintf("%d credential IDs do not exist on the account", len(r.Ids))
+}
+
+func (RejectReasonRemoveFirstCredential) Error() string {
+	return "the first credential cannot be removed"
+}
+
+func (RejectReasonCredentialHolderDidNotSign) Error() string {
+	return "credential holder did not sign"
+}
+
+func (RejectReasonNotAllowedMultipleCredentials) Error() string {
+	return "account is not allowed to have multiple credentials"
+}
+
+func (RejectReasonNotAllowedToReceiveEncrypted) Error() string {
+	return "account is not allowed to receive encrypted transfers"
+}
+
+func (RejectReasonNotAllowedToHandleEncrypted) Error() string {
+	return "account is not allowed to handle encrypted transfers"
+}
+
+func (RejectReasonMissingBakerAddParameters) Error() string {
+	return "missing parameters to add baker"
+}
+
+func (RejectReasonFinalizationRewardCommissionNotInRange) Error() string {
+	return "finalization reward commission is not in range"
+}
+
+func (RejectReasonBakingRewardCommissionNotInRange) Error() string {
+	return "baking reward commission is not in range"
+}
+
+func (RejectReasonTransactionFeeCommissionNotInRange) Error() string {
+	return "transaction fee commission is not in range"
+}
+
+func (RejectReasonAlreadyADelegator) Error() string {
+	return "account is already a delegator"
+}
+
+func (RejectReasonInsufficientBalanceForDelegationStake) Error() string {
+	return "insufficient balance for delegation stake"
+}
+
+func (RejectReasonMissingDelegationAddParameters) Error() string {
+	return "missing parameters to add delegator"
+}
+
+func (RejectReasonInsufficientDelegationStake) Error() string {
+	return "insufficient delegation stake"
+}
+
+func (RejectReasonDelegatorInCooldown) Error() string {
+	return "delegator is in cooldown"
+}
+
+func (r RejectReasonNotADelegator) Error() string {
+	return fmt.Sprintf("account %s is not a delegator", r.Address.ToBase58())
+}
+
+func (r RejectReasonDelegationTargetNotABaker) Error() string {
+	return fmt.Sprintf("delegation target %d is not a baker", r.BakerId.Value)
+}
+
+func (RejectReasonStakeOverMaximumThresholdForPool) Error() string {
+	return "stake is over the maximum threshold for the pool"
+}
+
+func (RejectReasonPoolWouldBecomeOverDelegated) Error() string {
+	return "pool would become over delegated"
+}
+
+func (RejectReasonPoolClosed) Error() string {
+	return "pool is closed"
+}
+
+// formatAddress formats *AccountAddress as base58 and *ContractAddress as <index,subindex>.
+func formatAddress(address isAddress) string {
+	switch a := address.(type) {
+	case *AccountAddress:
+		return a.ToBase58()
+	case *ContractAddress:
+		return fmt.Sprintf("<%d,%d>", a.Index, a.Subindex)
+	}
+	return "<unknown>"
+}
+
+// Parses []*pb.CredentialRegistrationId to []CredentialRegistrationId.
+func parseCredentialRegistrationIds(ids []*pb.CredentialRegistrationId) []CredentialRegistrationId {
+	res := make([]CredentialRegistrationId, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, CredentialRegistrationId{Value: id.GetValue()})
+	}
+	return res
+}
+
+// Parses *pb.RejectReason to RejectReason.
+func parseRejectReason(r *pb.RejectReason) (RejectReason, error) {
+	switch v := r.GetReason().(type) {
+	case *pb.RejectReason_ModuleNotWf:
+		return RejectReasonModuleNotWf{}, nil
+	case *pb.RejectReason_ModuleHashAlreadyExists:
+		return RejectReasonModuleHashAlreadyExists{ModuleRef: parseModuleRef(v.ModuleHashAlreadyExists)}, nil
+	case *pb.RejectReason_InvalidAccountReference:
+		return RejectReasonInvalidAccountReference{Address: parseAccountAddress(v.InvalidAccountReference)}, nil
+	case *pb.RejectReason_InvalidInitMethod_:
+		return RejectReasonInvalidInitMethod{
+			ModuleRef: parseModuleRef(v.InvalidInitMethod.GetModuleRef()),
+			InitName:  InitName{Value: v.InvalidInitMethod.GetInitName().GetValue()},
+		}, nil
+	case *pb.RejectReason_InvalidReceiveMethod_:
+		return RejectReasonInvalidReceiveMethod{
+			ModuleRef:   parseModuleRef(v.InvalidReceiveMethod.GetModuleRef()),
+			ReceiveName: ReceiveName{Value: v.InvalidReceiveMethod.GetReceiveName().GetValue()},
+		}, nil
+	case *pb.RejectReason_InvalidModuleReference:
+		return RejectReasonInvalidModuleReference{ModuleRef: parseModuleRef(v.InvalidModuleReference)}, nil
+	case *pb.RejectReason_InvalidContractAddress:
+		return RejectReasonInvalidContractAddress{Address: parseContractAddress(v.InvalidContractAddress)}, nil
+	case *pb.RejectReason_RuntimeFailure:
+		return RejectReasonRuntimeFailure{}, nil
+	case *pb.RejectReason_AmountTooLarge_:
+		return RejectReasonAmountTooLarge{
+			Address: parseAddress(v.AmountTooLarge.GetAddress()),
+			Amount:  Amount{Value: v.AmountTooLarge.GetAmount().GetValue()},
+		}, nil
+	case *pb.RejectReason_SerializationFailure:
+		return RejectReasonSerializationFailure{}, nil
+	case *pb.RejectReason_OutOfEnergy:
+		return RejectReasonOutOfEnergy{}, nil
+	case *pb.RejectReason_RejectedInit_:
+		return RejectReasonRejectedInit{RejectReason: v.RejectedInit.GetRejectReason()}, nil
+	case *pb.RejectReason_RejectedReceive_:
+		return RejectReasonRejectedReceive{
+			RejectReason:    v.RejectedReceive.GetRejectReason(),
+			ContractAddress: parseContractAddress(v.RejectedReceive.GetContractAddress()),
+			ReceiveName:     ReceiveName{Value: v.RejectedReceive.GetReceiveName().GetValue()},
+			Parameter:       Parameter{Value: v.RejectedReceive.GetParameter().GetValue()},
+		}, nil
+	case *pb.RejectReason_InvalidProof:
+		return RejectReasonInvalidProof{}, nil
+	case *pb.RejectReason_AlreadyABaker:
+		return RejectReasonAlreadyABaker{BakerId: BakerId{Value: v.AlreadyABaker.GetValue()}}, nil
+	case *pb.RejectReason_NotABaker:
+		return RejectReasonNotABaker{Address: parseAccountAddress(v.NotABaker)}, nil
+	case *pb.RejectReason_InsufficientBalanceForBakerStake:
+		return RejectReasonInsufficientBalanceForBakerStake{}, nil
+	case *pb.RejectReason_StakeUnderMinimumThresholdForBaking:
+		return RejectReasonStakeUnderMinimumThresholdForBaking{}, nil
+	case *pb.RejectReason_BakerInCooldown:
+		return RejectReasonBakerInCooldown{}, nil
+	case *pb.RejectReason_DuplicateAggregationKey:
+		return RejectReasonDuplicateAggregationKey{
+			Key: BakerAggregationVerifyKey{Value: v.DuplicateAggregationKey.GetValue()},
+		}, nil
+	case *pb.RejectReason_NonExistentCredentialId:
+		return RejectReasonNonExistentCredentialId{}, nil
+	case *pb.RejectReason_KeyIndexAlreadyInUse:
+		return RejectReasonKeyIndexAlreadyInUse{}, nil
+	case *pb.RejectReason_InvalidAccountThreshold:
+		return RejectReasonInvalidAccountThreshold{}, nil
+	case *pb.RejectReason_InvalidCredentialKeySignThreshold:
+		return RejectReasonInvalidCredentialKeySignThreshold{}, nil
+	case *pb.RejectReason_InvalidEncryptedAmountTransferProof:
+		return RejectReasonInvalidEncryptedAmountTransferProof{}, nil
+	case *pb.RejectReason_InvalidTransferToPublicProof:
+		return RejectReasonInvalidTransferToPublicProof{}, nil
+	case *pb.RejectReason_EncryptedAmountSelfTransfer:
+		return RejectReasonEncryptedAmountSelfTransfer{Address: parseAccountAddress(v.EncryptedAmountSelfTransfer)}, nil
+	case *pb.RejectReason_InvalidIndexOnEncryptedTransfer:
+		return RejectReasonInvalidIndexOnEncryptedTransfer{}, nil
+	case *pb.RejectReason_ZeroScheduledAmount:
+		return RejectReasonZeroScheduledAmount{}, nil
+	case *pb.RejectReason_NonIncreasingSchedule:
+		return RejectReasonNonIncreasingSchedule{}, nil
+	case *pb.RejectReason_FirstScheduledReleaseExpired:
+		return RejectReasonFirstScheduledReleaseExpired{}, nil
+	case *pb.RejectReason_ScheduledSelfTransfer:
+		return RejectReasonScheduledSelfTransfer{Address: parseAccountAddress(v.ScheduledSelfTransfer)}, nil
+	case *pb.RejectReason_InvalidCredentials:
+		return RejectReasonInvalidCredentials{}, nil
+	case *pb.RejectReason_DuplicateCredIds_:
+		return RejectReasonDuplicateCredIds{Ids: parseCredentialRegistrationIds(v.DuplicateCredIds.GetIds())}, nil
+	case *pb.RejectReason_NonExistentCredIds_:
+		return RejectReasonNonExistentCredIds{Ids: parseCredentialRegistrationIds(v.NonExistentCredIds.GetIds())}, nil
+	case *pb.RejectReason_RemoveFirstCredential:
+		return RejectReasonRemoveFirstCredential{}, nil
+	case *pb.RejectReason_CredentialHolderDidNotSign:
+		return RejectReasonCredentialHolderDidNotSign{}, nil
+	case *pb.RejectReason_NotAllowedMultipleCredentials:
+		return RejectReasonNotAllowedMultipleCredentials{}, nil
+	case *pb.RejectReason_NotAllowedToReceiveEncrypted:
+		return RejectReasonNotAllowedToReceiveEncrypted{}, nil
+	case *pb.RejectReason_NotAllowedToHandleEncrypted:
+		return RejectReasonNotAllowedToHandleEncrypted{}, nil
+	case *pb.RejectReason_MissingBakerAddParameters:
+		return RejectReasonMissingBakerAddParameters{}, nil
+	case *pb.RejectReason_FinalizationRewardCommissionNotInRange:
+		return RejectReasonFinalizationRewardCommissionNotInRange{}, nil
+	case *pb.RejectReason_BakingRewardCommissionNotInRange:
+		return RejectReasonBakingRewardCommissionNotInRange{}, nil
+	case *pb.RejectReason_TransactionFeeCommissionNotInRange:
+		return RejectReasonTransactionFeeCommissionNotInRange{}, nil
+	case *pb.RejectReason_AlreadyADelegator:
+		return RejectReasonAlreadyADelegator{}, nil
+	case *pb.RejectReason_InsufficientBalanceForDelegationStake:
+		return RejectReasonInsufficientBalanceForDelegationStake{}, nil
+	case *pb.RejectReason_MissingDelegationAddParameters:
+		return RejectReasonMissingDelegationAddParameters{}, nil
+	case *pb.RejectReason_InsufficientDelegationStake:
+		return RejectReasonInsufficientDelegationStake{}, nil
+	case *pb.RejectReason_DelegatorInCooldown:
+		return RejectReasonDelegatorInCooldown{}, nil
+	case *pb.RejectReason_NotADelegator:
+		return RejectReasonNotADelegator{Address: parseAccountAddress(v.NotADelegator)}, nil
+	case *pb.RejectReason_DelegationTargetNotABaker:
+		return RejectReasonDelegationTargetNotABaker{BakerId: BakerId{Value: v.DelegationTargetNotABaker.GetValue()}}, nil
+	case *pb.RejectReason_StakeOverMaximumThresholdForPool:
+		return RejectReasonStakeOverMaximumThresholdForPool{}, nil
+	case *pb.RejectReason_PoolWouldBecomeOverDelegated:
+		return RejectReasonPoolWouldBecomeOverDelegated{}, nil
+	case *pb.RejectReason_PoolClosed:
+		return RejectReasonPoolClosed{}, nil
+	}
+
+	return nil, ErrUnknownVariant
+}
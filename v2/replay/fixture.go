@@ -0,0 +1,86 @@
+// Package replay records the requests a v2.Client sends to a node together with the responses, and replays them
+// offline from a fixture file.
+//
+// A Recorder is installed on a Config with Install, after which every unary and streaming request sent through
+// the client is recorded. Save writes the interactions to a fixture file. A Server loaded with the fixture serves
+// the recorded responses over an in-memory listener, matching each request against the recorded requests of the
+// same method, so that tests can use a v2.Client connected to it instead of a live node.
+package replay
+
+import (
+	"encoding/json"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fixture the recorded interactions with a node, in the order they started.
+type Fixture struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction a recorded request and its responses.
+type Interaction struct {
+	// The full gRPC method name, e.g. /concordium.v2.Queries/GetConsensusInfo.
+	Method string `json:"method"`
+	// The requests in protobuf JSON format. Unary and server streaming requests have a single request.
+	Requests []json.RawMessage `json:"requests"`
+	// The responses in protobuf JSON format.
+	Responses []json.RawMessage `json:"responses,omitempty"`
+	// The status the request ended with, nil if a stream was not finished when the fixture was saved.
+	Status *Status `json:"status,omitempty"`
+}
+
+// Status the gRPC status a request ended with.
+type Status struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message,omitempty"`
+}
+
+// Err returns the status as an error, nil if the request succeeded.
+func (s *Status) Err() error {
+	if s == nil {
+		return nil
+	}
+	return status.Error(s.Code, s.Message)
+}
+
+// Load reads a fixture file written by Save.
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture := new(Fixture)
+	if err = json.Unmarshal(data, fixture); err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
+
+// Save writes the fixture to a file.
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// marshalMessage encodes a message in protobuf JSON format.
+func marshalMessage(m any) (json.RawMessage, error) {
+	message, ok := m.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "cannot record message of type %T", m)
+	}
+	return protojson.Marshal(message)
+}
+
+// statusOf converts the error of a request to a Status.
+func statusOf(err error) *Status {
+	s := status.Convert(err)
+	return &Status{Code: s.Code(), Message: s.Message()}
+}
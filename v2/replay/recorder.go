@@ -0,0 +1,140 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// Recorder records the requests sent through a client and their responses.
+type Recorder struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Install adds the interceptors of the recorder to the config, before any other interceptors.
+func (r *Recorder) Install(config *v2.Config) {
+	config.UnaryInterceptors = append([]grpc.UnaryClientInterceptor{r.UnaryInterceptor()}, config.UnaryInterceptors...)
+	config.StreamInterceptors = append([]grpc.StreamClientInterceptor{r.StreamInterceptor()}, config.StreamInterceptors...)
+}
+
+// Fixture returns a copy of the interactions recorded so far.
+func (r *Recorder) Fixture() *Fixture {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fixture := &Fixture{Interactions: make([]*Interaction, len(r.interactions))}
+	for i, interaction := range r.interactions {
+		c := *interaction
+		c.Requests = append([]json.RawMessage(nil), interaction.Requests...)
+		c.Responses = append([]json.RawMessage(nil), interaction.Responses...)
+		if interaction.Status != nil {
+			s := *interaction.Status
+			c.Status = &s
+		}
+		fixture.Interactions[i] = &c
+	}
+	return fixture
+}
+
+// Save writes the interactions recorded so far to a fixture file.
+func (r *Recorder) Save(path string) error {
+	return r.Fixture().Save(path)
+}
+
+// start adds a new interaction.
+func (r *Recorder) start(method string) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interaction := &Interaction{Method: method}
+	r.interactions = append(r.interactions, interaction)
+	return interaction
+}
+
+// record adds a request or response to an interaction.
+func (r *Recorder) record(messages *[]json.RawMessage, m any) error {
+	data, err := marshalMessage(m)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*messages = append(*messages, data)
+	return nil
+}
+
+// finish sets the status of an interaction.
+func (r *Recorder) finish(interaction *Interaction, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	interaction.Status = statusOf(err)
+}
+
+// UnaryInterceptor returns an interceptor that records unary requests.
+func (r *Recorder) UnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		interaction := r.start(method)
+		if err := r.record(&interaction.Requests, req); err != nil {
+			return err
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			if recordErr := r.record(&interaction.Responses, reply); recordErr != nil {
+				return recordErr
+			}
+		}
+		r.finish(interaction, err)
+		return err
+	}
+}
+
+// StreamInterceptor returns an interceptor that records streaming requests.
+func (r *Recorder) StreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		interaction := r.start(method)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			r.finish(interaction, err)
+			return nil, err
+		}
+		return &recordingStream{ClientStream: stream, recorder: r, interaction: interaction}, nil
+	}
+}
+
+// recordingStream records the messages sent and received on a stream.
+type recordingStream struct {
+	grpc.ClientStream
+	recorder    *Recorder
+	interaction *Interaction
+}
+
+func (s *recordingStream) SendMsg(m any) error {
+	if err := s.recorder.record(&s.interaction.Requests, m); err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		return s.recorder.record(&s.interaction.Responses, m)
+	case errors.Is(err, io.EOF):
+		s.recorder.finish(s.interaction, nil)
+	default:
+		s.recorder.finish(s.interaction, err)
+	}
+	return err
+}
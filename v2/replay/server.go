@@ -0,0 +1,337 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/tap"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	// registers the message types of the node API.
+	_ "github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// bufferSize is the size of the in-memory connection buffers.
+const bufferSize = 1024 * 1024
+
+// Server serves the responses of a fixture over an in-memory listener. It must be closed with Close.
+//
+// A request is answered with the first interaction of the same method whose request is equal to it and which has
+// not been used yet. If all matching interactions are used, the last one is used again, so that repeated queries
+// keep working. If no interaction matches, the request fails with codes.FailedPrecondition and a diff against the
+// most similar recorded request. Requests are matched in the order the client started them, even if they are
+// served concurrently. Bidirectional streams such as DryRun cannot be replayed.
+type Server struct {
+	listener *bufconn.Listener
+	server   *grpc.Server
+
+	mu           sync.Mutex
+	turn         *sync.Cond
+	interactions []*Interaction
+	used         []bool
+	mismatches   []error
+	// The number of requests started and matched so far.
+	started uint64
+	matched uint64
+}
+
+// sequenceKey the context key of the position of a request in the order requests were started.
+type sequenceKey struct{}
+
+// NewServer starts a Server replaying the fixture.
+func NewServer(fixture *Fixture) *Server {
+	s := &Server{
+		listener:     bufconn.Listen(bufferSize),
+		interactions: fixture.Interactions,
+		used:         make([]bool, len(fixture.Interactions)),
+	}
+	s.turn = sync.NewCond(&s.mu)
+	s.server = grpc.NewServer(grpc.UnknownServiceHandler(s.handle), grpc.InTapHandle(s.sequence))
+	go s.server.Serve(s.listener)
+	return s
+}
+
+// Close stops the server and closes all connections to it.
+func (s *Server) Close() {
+	s.server.Stop()
+	s.listener.Close()
+}
+
+// Config returns a v2.Config that connects to the server.
+func (s *Server) Config() v2.Config {
+	return v2.Config{
+		NodeAddress:    "passthrough:///replay",
+		TlsCredentials: insecure.NewCredentials(),
+		DialOptions: []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		})},
+	}
+}
+
+// NewClient creates a v2.Client connected to the server.
+func (s *Server) NewClient() (*v2.Client, error) {
+	return v2.NewClient(s.Config())
+}
+
+// Unused returns the interactions that no request has matched.
+func (s *Server) Unused() []*Interaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res []*Interaction
+	for i, interaction := range s.interactions {
+		if !s.used[i] {
+			res = append(res, interaction)
+		}
+	}
+	return res
+}
+
+// Mismatches returns the errors of the requests that no interaction matched.
+func (s *Server) Mismatches() []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]error(nil), s.mismatches...)
+}
+
+// sequence numbers a request in the order it was started. It is called for each request as its headers arrive,
+// before the handlers of the requests run concurrently.
+func (s *Server) sequence(ctx context.Context, _ *tap.Info) (context.Context, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx = context.WithValue(ctx, sequenceKey{}, s.started)
+	s.started++
+	return ctx, nil
+}
+
+// handle serves any request from the fixture.
+func (s *Server) handle(_ any, stream grpc.ServerStream) error {
+	method, descriptor, req, err := receive(stream)
+
+	// match the requests in the order they were started, so that a request cannot take the interaction of an
+	// equal request started before it.
+	seq, _ := stream.Context().Value(sequenceKey{}).(uint64)
+	s.mu.Lock()
+	for s.matched != seq {
+		s.turn.Wait()
+	}
+	var interaction *Interaction
+	if err == nil {
+		interaction, err = s.match(method, descriptor, req)
+	}
+	s.matched++
+	s.turn.Broadcast()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for _, data := range interaction.Responses {
+		res, err := newMessage(descriptor.Output())
+		if err != nil {
+			return err
+		}
+		if err = protojson.Unmarshal(data, res); err != nil {
+			return status.Errorf(codes.Internal, "invalid recorded response to %s: %v", method, err)
+		}
+		if err = stream.SendMsg(res); err != nil {
+			return err
+		}
+	}
+	if interaction.Status == nil || interaction.Status.Code == codes.Canceled {
+		// the stream was abandoned by the client when it was recorded.
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
+	return interaction.Status.Err()
+}
+
+// receive reads the request of a stream.
+func receive(stream grpc.ServerStream) (string, protoreflect.MethodDescriptor, proto.Message, error) {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return "", nil, nil, status.Error(codes.Internal, "unknown method")
+	}
+	descriptor, err := findMethod(method)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if descriptor.IsStreamingClient() {
+		return "", nil, nil, status.Errorf(codes.Unimplemented, "%s is a bidirectional stream, which cannot be replayed", method)
+	}
+
+	req, err := newMessage(descriptor.Input())
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if err = stream.RecvMsg(req); err != nil {
+		return "", nil, nil, err
+	}
+	return method, descriptor, req, nil
+}
+
+// match finds the interaction answering the request. It must be called with mu held.
+func (s *Server) match(method string, descriptor protoreflect.MethodDescriptor, req proto.Message) (*Interaction, error) {
+	var candidates []int
+	last := -1
+	for i, interaction := range s.interactions {
+		if interaction.Method != method || len(interaction.Requests) != 1 {
+			continue
+		}
+		recorded, err := newMessage(descriptor.Input())
+		if err != nil {
+			return nil, err
+		}
+		if err = protojson.Unmarshal(interaction.Requests[0], recorded); err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid recorded request to %s: %v", method, err)
+		}
+		if !proto.Equal(req, recorded) {
+			candidates = append(candidates, i)
+			continue
+		}
+		if !s.used[i] {
+			s.used[i] = true
+			return interaction, nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return s.interactions[last], nil
+	}
+
+	err := status.Error(codes.FailedPrecondition, mismatchMessage(method, req, s.interactions, candidates))
+	s.mismatches = append(s.mismatches, err)
+	return nil, err
+}
+
+// mismatchMessage describes a request that matched no interaction, with a diff against the most similar
+// recorded request of the same method.
+func mismatchMessage(method string, req proto.Message, interactions []*Interaction, candidates []int) string {
+	actual := formatMessage(mustMarshal(req))
+	if len(candidates) == 0 {
+		return fmt.Sprintf("no recorded request to %s matches, and none of that method were recorded:\n%s",
+			method, strings.Join(actual, "\n"))
+	}
+
+	var best []string
+	for _, i := range candidates {
+		diff := diffLines(formatMessage(interactions[i].Requests[0]), actual)
+		if best == nil || changedLines(diff) < changedLines(best) {
+			best = diff
+		}
+	}
+	return fmt.Sprintf("no recorded request to %s matches; diff against the closest recorded request (-recorded +actual):\n%s",
+		method, strings.Join(best, "\n"))
+}
+
+// mustMarshal encodes a message in protobuf JSON format, or the error if that fails.
+func mustMarshal(m proto.Message) json.RawMessage {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return json.RawMessage(fmt.Sprintf("%q", err.Error()))
+	}
+	return data
+}
+
+// formatMessage indents a message in JSON format, so that it can be compared line by line.
+func formatMessage(data json.RawMessage) []string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return []string{string(data)}
+	}
+	return strings.Split(buf.String(), "\n")
+}
+
+// diffLines returns a line diff between a and b, with removed lines prefixed by "-", added lines by "+" and
+// unchanged lines by " ".
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var res []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			res = append(res, " "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			res = append(res, "-"+a[i])
+			i++
+		default:
+			res = append(res, "+"+b[j])
+			j++
+		}
+	}
+	return res
+}
+
+// changedLines returns the number of removed and added lines of a diff.
+func changedLines(diff []string) int {
+	n := 0
+	for _, line := range diff {
+		if !strings.HasPrefix(line, " ") {
+			n++
+		}
+	}
+	return n
+}
+
+// findMethod looks up the descriptor of a full gRPC method name.
+func findMethod(method string) (protoreflect.MethodDescriptor, error) {
+	name := strings.TrimPrefix(method, "/")
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return nil, status.Errorf(codes.Unimplemented, "invalid method %s", method)
+	}
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name[:i]))
+	if err != nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service of method %s", method)
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "unknown service of method %s", method)
+	}
+	descriptor := service.Methods().ByName(protoreflect.Name(name[i+1:]))
+	if descriptor == nil {
+		return nil, status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+	return descriptor, nil
+}
+
+// newMessage creates an empty message of the given type.
+func newMessage(descriptor protoreflect.MessageDescriptor) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(descriptor.FullName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unknown message type %s", descriptor.FullName())
+	}
+	return messageType.New().Interface(), nil
+}
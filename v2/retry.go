@@ -0,0 +1,286 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// RetryPolicy describes how a Client retries failed requests.
+//
+// Unary requests are retried if they fail with one of the RetryableCodes. Requests with side effects on the node,
+// such as PeerConnect or Shutdown, are never retried. SendBlockItem is only retried if the hash of the block item
+// can be computed locally, and only after GetBlockItemStatus shows that the node does not know the block item,
+// so that the block item is not sent twice. See ComputeBlockItemHash.
+//
+// Streaming requests are only retried if they fail before the first response is received, so that no response
+// is delivered twice. Streams that fail later must be restarted by the caller, see also GetFinalizedBlocksResilient.
+// DryRun is never retried, since it is a stateful session.
+type RetryPolicy struct {
+	// Maximum number of attempts, including the first one. Defaults to DefaultRetryMaxAttempts.
+	MaxAttempts int
+	// Delay before the first retry. Defaults to DefaultRetryInitialBackoff.
+	InitialBackoff time.Duration
+	// Maximum delay between attempts. Defaults to DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+	// Factor the delay is multiplied by after each attempt. Defaults to DefaultRetryBackoffMultiplier.
+	BackoffMultiplier float64
+	// Fraction by which each delay is randomly increased or decreased, between 0 and 1.
+	Jitter float64
+	// Status codes for which a request is retried. Defaults to codes.Unavailable and codes.ResourceExhausted.
+	RetryableCodes []codes.Code
+	// Deadline of unary requests whose context has no deadline, including all retries. Zero means no deadline.
+	UnaryTimeout time.Duration
+}
+
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of attempts of a request.
+	DefaultRetryMaxAttempts = 4
+	// DefaultRetryInitialBackoff is the default delay before the first retry.
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default maximum delay between attempts.
+	DefaultRetryMaxBackoff = 5 * time.Second
+	// DefaultRetryBackoffMultiplier is the default factor the delay between attempts grows by.
+	DefaultRetryBackoffMultiplier = 2
+)
+
+// DefaultRetryPolicy returns the recommended RetryPolicy. Unary requests have a default deadline of 30 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:       DefaultRetryMaxAttempts,
+		InitialBackoff:    DefaultRetryInitialBackoff,
+		MaxBackoff:        DefaultRetryMaxBackoff,
+		BackoffMultiplier: DefaultRetryBackoffMultiplier,
+		Jitter:            0.2,
+		RetryableCodes:    []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+		UnaryTimeout:      30 * time.Second,
+	}
+}
+
+// IsRetryable returns whether a request that failed with err may be retried according to the policy.
+func (p RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	retryableCodes := p.RetryableCodes
+	if retryableCodes == nil {
+		retryableCodes = DefaultRetryPolicy().RetryableCodes
+	}
+	code := status.Code(err)
+	for _, c := range retryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the delay before the given retry, where retry 1 is the first retry.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	p = p.withDefaults()
+	backoff := float64(p.InitialBackoff) * math.Pow(p.BackoffMultiplier, float64(retry-1))
+	if backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(backoff)
+}
+
+// withDefaults returns the policy with the defaults filled in.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if p.BackoffMultiplier < 1 {
+		p.BackoffMultiplier = DefaultRetryBackoffMultiplier
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	} else if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	return p
+}
+
+// nonIdempotentMethods are the unary methods that change the state of the node and are never retried.
+var nonIdempotentMethods = map[string]bool{
+	pb.Queries_PeerConnect_FullMethodName:    true,
+	pb.Queries_PeerDisconnect_FullMethodName: true,
+	pb.Queries_BanPeer_FullMethodName:        true,
+	pb.Queries_UnbanPeer_FullMethodName:      true,
+	pb.Queries_DumpStart_FullMethodName:      true,
+	pb.Queries_DumpStop_FullMethodName:       true,
+	pb.Queries_Shutdown_FullMethodName:       true,
+}
+
+// retryConn retries the requests sent through another connection according to a RetryPolicy.
+type retryConn struct {
+	cc     grpc.ClientConnInterface
+	policy RetryPolicy
+}
+
+func newRetryConn(cc grpc.ClientConnInterface, policy RetryPolicy) *retryConn {
+	return &retryConn{cc: cc, policy: policy.withDefaults()}
+}
+
+// wait waits before the given retry. It returns an error if the context is done first.
+func (c *retryConn) wait(ctx context.Context, retry int) error {
+	timer := time.NewTimer(c.policy.Backoff(retry))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Invoke sends a unary request and retries it according to the policy.
+func (c *retryConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && c.policy.UnaryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.policy.UnaryTimeout)
+		defer cancel()
+	}
+	if method == pb.Queries_SendBlockItem_FullMethodName {
+		return c.sendBlockItem(ctx, args, reply, opts)
+	}
+
+	err := c.cc.Invoke(ctx, method, args, reply, opts...)
+	if nonIdempotentMethods[method] {
+		return err
+	}
+	for attempt := 1; attempt < c.policy.MaxAttempts && c.policy.IsRetryable(err); attempt++ {
+		if waitErr := c.wait(ctx, attempt); waitErr != nil {
+			return err
+		}
+		err = c.cc.Invoke(ctx, method, args, reply, opts...)
+	}
+	return err
+}
+
+// sendBlockItem sends a block item. If sending fails with a retryable error, the status of the block item is
+// queried by its hash, and the block item is only sent again if the node does not know it.
+func (c *retryConn) sendBlockItem(ctx context.Context, args any, reply any, opts []grpc.CallOption) error {
+	err := c.cc.Invoke(ctx, pb.Queries_SendBlockItem_FullMethodName, args, reply, opts...)
+	req, ok := args.(*pb.SendBlockItemRequest)
+	if !ok || !c.policy.IsRetryable(err) {
+		return err
+	}
+	hash, hashErr := ComputeBlockItemHash(req)
+	if hashErr != nil {
+		return err
+	}
+
+	for attempt := 1; attempt < c.policy.MaxAttempts; attempt++ {
+		if waitErr := c.wait(ctx, attempt); waitErr != nil {
+			return err
+		}
+		statusErr := c.cc.Invoke(ctx, pb.Queries_GetBlockItemStatus_FullMethodName,
+			&pb.TransactionHash{Value: hash.Value[:]}, new(pb.BlockItemStatus), opts...)
+		switch {
+		case statusErr == nil:
+			// the node received the block item before the failure.
+			if res, ok := reply.(*pb.TransactionHash); ok {
+				res.Value = hash.Value[:]
+			}
+			return nil
+		case status.Code(statusErr) == codes.NotFound:
+			err = c.cc.Invoke(ctx, pb.Queries_SendBlockItem_FullMethodName, args, reply, opts...)
+		case c.policy.IsRetryable(statusErr):
+			// the status is unknown, try again after waiting.
+		default:
+			return err
+		}
+		if !c.policy.IsRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// NewStream opens a stream. Read-only streams are retried according to the policy if they fail before the
+// first response is received.
+func (c *retryConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := c.cc.NewStream(ctx, desc, method, opts...)
+	if desc.ClientStreams {
+		return stream, err
+	}
+	for attempt := 1; attempt < c.policy.MaxAttempts && c.policy.IsRetryable(err); attempt++ {
+		if waitErr := c.wait(ctx, attempt); waitErr != nil {
+			return nil, err
+		}
+		stream, err = c.cc.NewStream(ctx, desc, method, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &retryStream{ClientStream: stream, ctx: ctx, conn: c, desc: desc, method: method, opts: opts}, nil
+}
+
+// retryStream a server stream that is reopened if it fails before the first response is received.
+type retryStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	conn   *retryConn
+	desc   *grpc.StreamDesc
+	method string
+	opts   []grpc.CallOption
+
+	// the request sent on the stream.
+	req      any
+	received bool
+	attempts int
+}
+
+func (s *retryStream) SendMsg(m any) error {
+	s.req = m
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *retryStream) RecvMsg(m any) error {
+	for {
+		err := s.ClientStream.RecvMsg(m)
+		if err == nil {
+			s.received = true
+			return nil
+		}
+		if s.received || s.req == nil || errors.Is(err, io.EOF) || !s.conn.policy.IsRetryable(err) {
+			return err
+		}
+		s.attempts++
+		if s.attempts >= s.conn.policy.MaxAttempts {
+			return err
+		}
+		if waitErr := s.conn.wait(s.ctx, s.attempts); waitErr != nil {
+			return err
+		}
+
+		stream, openErr := s.conn.cc.NewStream(s.ctx, s.desc, s.method, s.opts...)
+		if openErr == nil {
+			if openErr = stream.SendMsg(s.req); openErr == nil {
+				openErr = stream.CloseSend()
+			}
+		}
+		if openErr != nil {
+			// keep the failed stream, so that the next attempt sees its error again.
+			continue
+		}
+		s.ClientStream = stream
+	}
+}
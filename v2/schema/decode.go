@@ -0,0 +1,363 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// ToJSON deserializes a value according to the type and returns its JSON representation. All of the data
+// must be consumed by the value.
+func (t *Type) ToJSON(data []byte) (json.RawMessage, error) {
+	r := &reader{data: data}
+	var out bytes.Buffer
+	if err := t.toJSON(r, &out); err != nil {
+		return nil, err
+	}
+	if r.remaining() != 0 {
+		return nil, fmt.Errorf("%d bytes left after deserializing the value", r.remaining())
+	}
+	return out.Bytes(), nil
+}
+
+// Deserialize deserializes a value according to the type and stores the result in the value pointed to by v,
+// as json.Unmarshal does with the JSON representation of the value.
+func (t *Type) Deserialize(data []byte, v any) error {
+	value, err := t.ToJSON(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(value, v)
+}
+
+// toJSON reads a value from r and writes its JSON representation to out.
+func (t *Type) toJSON(r *reader, out *bytes.Buffer) error {
+	switch t.Kind {
+	case KindUnit:
+		out.WriteString("[]")
+	case KindBool:
+		b, err := r.u8()
+		if err != nil {
+			return err
+		}
+		switch b {
+		case 0:
+			out.WriteString("false")
+		case 1:
+			out.WriteString("true")
+		default:
+			return fmt.Errorf("invalid bool value %d", b)
+		}
+	case KindU8, KindU16, KindU32, KindU64:
+		n, err := readUint(r, map[Kind]int{KindU8: 8, KindU16: 16, KindU32: 32, KindU64: 64}[t.Kind])
+		if err != nil {
+			return err
+		}
+		out.WriteString(strconv.FormatUint(n, 10))
+	case KindI8, KindI16, KindI32, KindI64:
+		bits := map[Kind]int{KindI8: 8, KindI16: 16, KindI32: 32, KindI64: 64}[t.Kind]
+		n, err := readUint(r, bits)
+		if err != nil {
+			return err
+		}
+		// sign extend to 64 bits.
+		shift := 64 - bits
+		out.WriteString(strconv.FormatInt(int64(n<<shift)>>shift, 10))
+	case KindAmount:
+		n, err := r.u64()
+		if err != nil {
+			return err
+		}
+		writeString(out, strconv.FormatUint(n, 10))
+	case KindU128, KindI128:
+		b, err := r.bytes(16)
+		if err != nil {
+			return err
+		}
+		writeString(out, int128FromLittleEndian(b, t.Kind == KindI128).String())
+	case KindAccountAddress:
+		b, err := r.bytes(32)
+		if err != nil {
+			return err
+		}
+		var address v2.AccountAddress
+		copy(address.Value[:], b)
+		writeString(out, address.ToBase58())
+	case KindContractAddress:
+		index, err := r.u64()
+		if err != nil {
+			return err
+		}
+		subindex, err := r.u64()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, `{"index":%d,"subindex":%d}`, index, subindex)
+	case KindTimestamp:
+		millis, err := r.u64()
+		if err != nil {
+			return err
+		}
+		if millis > 1<<62 {
+			return fmt.Errorf("timestamp %d out of range", millis)
+		}
+		writeString(out, time.UnixMilli(int64(millis)).UTC().Format(time.RFC3339Nano))
+	case KindDuration:
+		millis, err := r.u64()
+		if err != nil {
+			return err
+		}
+		writeString(out, formatDuration(millis))
+	case KindPair:
+		out.WriteByte('[')
+		if err := t.Elements[0].toJSON(r, out); err != nil {
+			return err
+		}
+		out.WriteByte(',')
+		if err := t.Elements[1].toJSON(r, out); err != nil {
+			return err
+		}
+		out.WriteByte(']')
+	case KindList, KindSet:
+		n, err := r.length(t.SizeLength)
+		if err != nil {
+			return err
+		}
+		return elementsToJSON(r, out, t.Elements[0], n)
+	case KindArray:
+		return elementsToJSON(r, out, t.Elements[0], uint64(t.Length))
+	case KindMap:
+		n, err := r.length(t.SizeLength)
+		if err != nil {
+			return err
+		}
+		out.WriteByte('[')
+		for i := uint64(0); i < n; i++ {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			out.WriteByte('[')
+			if err := t.Elements[0].toJSON(r, out); err != nil {
+				return err
+			}
+			out.WriteByte(',')
+			if err := t.Elements[1].toJSON(r, out); err != nil {
+				return err
+			}
+			out.WriteByte(']')
+		}
+		out.WriteByte(']')
+	case KindStruct:
+		return t.Fields.toJSON(r, out)
+	case KindEnum:
+		var index uint64
+		var err error
+		if len(t.Variants) <= 256 {
+			var tag uint8
+			tag, err = r.u8()
+			index = uint64(tag)
+		} else {
+			var tag uint32
+			tag, err = r.u32()
+			index = uint64(tag)
+		}
+		if err != nil {
+			return err
+		}
+		if index >= uint64(len(t.Variants)) {
+			return fmt.Errorf("invalid enum variant %d", index)
+		}
+		return t.Variants[index].toJSON(r, out)
+	case KindTaggedEnum:
+		tag, err := r.u8()
+		if err != nil {
+			return err
+		}
+		for _, variant := range t.Variants {
+			if variant.Tag == tag {
+				return variant.toJSON(r, out)
+			}
+		}
+		return fmt.Errorf("invalid enum tag %d", tag)
+	case KindString:
+		s, err := readString(r, t.SizeLength)
+		if err != nil {
+			return err
+		}
+		writeString(out, s)
+	case KindContractName:
+		s, err := readString(r, t.SizeLength)
+		if err != nil {
+			return err
+		}
+		contract, ok := strings.CutPrefix(s, "init_")
+		if !ok {
+			return fmt.Errorf("invalid contract name %q", s)
+		}
+		out.WriteString(`{"contract":`)
+		writeString(out, contract)
+		out.WriteByte('}')
+	case KindReceiveName:
+		s, err := readString(r, t.SizeLength)
+		if err != nil {
+			return err
+		}
+		contract, function, ok := strings.Cut(s, ".")
+		if !ok {
+			return fmt.Errorf("invalid receive name %q", s)
+		}
+		out.WriteString(`{"contract":`)
+		writeString(out, contract)
+		out.WriteString(`,"func":`)
+		writeString(out, function)
+		out.WriteByte('}')
+	case KindULeb128:
+		n, err := readULeb128Big(r, t.Length)
+		if err != nil {
+			return err
+		}
+		writeString(out, n.String())
+	case KindILeb128:
+		n, err := readILeb128Big(r, t.Length)
+		if err != nil {
+			return err
+		}
+		writeString(out, n.String())
+	case KindByteList:
+		n, err := r.length(t.SizeLength)
+		if err != nil {
+			return err
+		}
+		b, err := r.bytes(n)
+		if err != nil {
+			return err
+		}
+		writeString(out, hex.EncodeToString(b))
+	case KindByteArray:
+		b, err := r.bytes(uint64(t.Length))
+		if err != nil {
+			return err
+		}
+		writeString(out, hex.EncodeToString(b))
+	default:
+		return fmt.Errorf("invalid schema type kind %d", t.Kind)
+	}
+	return nil
+}
+
+// toJSON reads the fields of the variant from r and writes the variant as a JSON object with a single key.
+func (v *Variant) toJSON(r *reader, out *bytes.Buffer) error {
+	out.WriteByte('{')
+	writeString(out, v.Name)
+	out.WriteByte(':')
+	if err := v.Fields.toJSON(r, out); err != nil {
+		return err
+	}
+	out.WriteByte('}')
+	return nil
+}
+
+// toJSON reads the fields from r and writes them as a JSON object if they are named and an array otherwise.
+func (f *Fields) toJSON(r *reader, out *bytes.Buffer) error {
+	switch f.Kind {
+	case FieldsNamed:
+		out.WriteByte('{')
+		for i, field := range f.Fields {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			writeString(out, field.Name)
+			out.WriteByte(':')
+			if err := field.Type.toJSON(r, out); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+	case FieldsUnnamed:
+		out.WriteByte('[')
+		for i, field := range f.Fields {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := field.Type.toJSON(r, out); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+	default:
+		out.WriteString("[]")
+	}
+	return nil
+}
+
+// elementsToJSON reads n elements of the type from r and writes them as a JSON array.
+func elementsToJSON(r *reader, out *bytes.Buffer, t *Type, n uint64) error {
+	out.WriteByte('[')
+	for i := uint64(0); i < n; i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if err := t.toJSON(r, out); err != nil {
+			return err
+		}
+	}
+	out.WriteByte(']')
+	return nil
+}
+
+// readUint reads an unsigned little-endian integer of the given number of bits.
+func readUint(r *reader, bits int) (uint64, error) {
+	b, err := r.bytes(uint64(bits / 8))
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		n = n<<8 | uint64(b[i])
+	}
+	return n, nil
+}
+
+// readString reads a UTF-8 string prefixed by its length.
+func readString(r *reader, sizeLength SizeLength) (string, error) {
+	n, err := r.length(sizeLength)
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(n)
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("invalid UTF-8 string")
+	}
+	return string(b), nil
+}
+
+// int128FromLittleEndian converts 16 little-endian bytes to an integer, using two's complement if signed.
+func int128FromLittleEndian(b []byte, signed bool) *big.Int {
+	be := make([]byte, 16)
+	for i := range b {
+		be[15-i] = b[i]
+	}
+	n := new(big.Int).SetBytes(be)
+	if signed && be[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return n
+}
+
+// writeString writes s as a JSON string.
+func writeString(out *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s)
+	out.Write(b)
+}
@@ -0,0 +1,463 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// Serialize serializes a Go value according to the type. The value is first converted to JSON with
+// json.Marshal, so it can be anything that marshals to the JSON representation of the type, e.g. a
+// json.RawMessage, a map[string]any or a struct with json tags.
+func (t *Type) Serialize(value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return t.SerializeJSON(data)
+}
+
+// SerializeJSON serializes the JSON representation of a value according to the type.
+func (t *Type) SerializeJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// numbers are kept as strings to not lose precision of large integers.
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return t.serialize(nil, value)
+}
+
+// serialize appends the serialization of the JSON value to buf.
+func (t *Type) serialize(buf []byte, value any) ([]byte, error) {
+	switch t.Kind {
+	case KindUnit:
+		return buf, nil
+	case KindBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, typeError("bool", value)
+		}
+		if b {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case KindU8, KindU16, KindU32, KindU64, KindAmount:
+		bits := map[Kind]int{KindU8: 8, KindU16: 16, KindU32: 32, KindU64: 64, KindAmount: 64}[t.Kind]
+		n, err := parseUint(value, bits)
+		if err != nil {
+			return nil, err
+		}
+		return appendUint(buf, n, bits), nil
+	case KindI8, KindI16, KindI32, KindI64:
+		bits := map[Kind]int{KindI8: 8, KindI16: 16, KindI32: 32, KindI64: 64}[t.Kind]
+		n, err := parseInt(value, bits)
+		if err != nil {
+			return nil, err
+		}
+		return appendUint(buf, uint64(n), bits), nil
+	case KindU128, KindI128:
+		n, err := parseBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		return appendInt128(buf, n, t.Kind == KindI128)
+	case KindAccountAddress:
+		s, ok := value.(string)
+		if !ok {
+			return nil, typeError("account address", value)
+		}
+		address, err := v2.AccountAddressFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account address %q: %w", s, err)
+		}
+		return append(buf, address.Value[:]...), nil
+	case KindContractAddress:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, typeError("contract address", value)
+		}
+		index, err := parseUint(object["index"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract index: %w", err)
+		}
+		var subindex uint64
+		if s, ok := object["subindex"]; ok {
+			if subindex, err = parseUint(s, 64); err != nil {
+				return nil, fmt.Errorf("invalid contract subindex: %w", err)
+			}
+		}
+		buf = binary.LittleEndian.AppendUint64(buf, index)
+		return binary.LittleEndian.AppendUint64(buf, subindex), nil
+	case KindTimestamp:
+		s, ok := value.(string)
+		if !ok {
+			return nil, typeError("timestamp", value)
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, err
+		}
+		if timestamp.UnixMilli() < 0 {
+			return nil, errors.New("timestamp before unix epoch")
+		}
+		return binary.LittleEndian.AppendUint64(buf, uint64(timestamp.UnixMilli())), nil
+	case KindDuration:
+		s, ok := value.(string)
+		if !ok {
+			return nil, typeError("duration", value)
+		}
+		millis, err := parseDuration(s)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(buf, millis), nil
+	case KindPair:
+		array, ok := value.([]any)
+		if !ok || len(array) != 2 {
+			return nil, typeError("pair", value)
+		}
+		buf, err := t.Elements[0].serialize(buf, array[0])
+		if err != nil {
+			return nil, err
+		}
+		return t.Elements[1].serialize(buf, array[1])
+	case KindList, KindSet:
+		array, ok := value.([]any)
+		if !ok {
+			return nil, typeError("list", value)
+		}
+		buf, err := appendLength(buf, t.SizeLength, len(array))
+		if err != nil {
+			return nil, err
+		}
+		return serializeElements(buf, t.Elements[0], array)
+	case KindArray:
+		array, ok := value.([]any)
+		if !ok {
+			return nil, typeError("array", value)
+		}
+		if len(array) != int(t.Length) {
+			return nil, fmt.Errorf("expected array of length %d, got %d", t.Length, len(array))
+		}
+		return serializeElements(buf, t.Elements[0], array)
+	case KindMap:
+		array, ok := value.([]any)
+		if !ok {
+			return nil, typeError("map as array of key-value pairs", value)
+		}
+		buf, err := appendLength(buf, t.SizeLength, len(array))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range array {
+			pair, ok := entry.([]any)
+			if !ok || len(pair) != 2 {
+				return nil, typeError("key-value pair", entry)
+			}
+			if buf, err = t.Elements[0].serialize(buf, pair[0]); err != nil {
+				return nil, err
+			}
+			if buf, err = t.Elements[1].serialize(buf, pair[1]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case KindStruct:
+		return t.Fields.serialize(buf, value)
+	case KindEnum, KindTaggedEnum:
+		object, ok := value.(map[string]any)
+		if !ok || len(object) != 1 {
+			return nil, typeError("enum as object with a single variant", value)
+		}
+		for name, fields := range object {
+			for i, variant := range t.Variants {
+				if variant.Name != name {
+					continue
+				}
+				switch {
+				case t.Kind == KindTaggedEnum:
+					buf = append(buf, variant.Tag)
+				case len(t.Variants) <= 256:
+					buf = append(buf, uint8(i))
+				default:
+					buf = binary.LittleEndian.AppendUint32(buf, uint32(i))
+				}
+				buf, err := variant.Fields.serialize(buf, fields)
+				if err != nil {
+					return nil, fmt.Errorf("invalid variant %q: %w", name, err)
+				}
+				return buf, nil
+			}
+			return nil, fmt.Errorf("unknown enum variant %q", name)
+		}
+	case KindString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, typeError("string", value)
+		}
+		return appendString(buf, t.SizeLength, s)
+	case KindContractName:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, typeError("contract name", value)
+		}
+		contract, ok := object["contract"].(string)
+		if !ok {
+			return nil, typeError("contract name", value)
+		}
+		return appendString(buf, t.SizeLength, "init_"+contract)
+	case KindReceiveName:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, typeError("receive name", value)
+		}
+		contract, ok1 := object["contract"].(string)
+		function, ok2 := object["func"].(string)
+		if !ok1 || !ok2 {
+			return nil, typeError("receive name", value)
+		}
+		return appendString(buf, t.SizeLength, contract+"."+function)
+	case KindULeb128, KindILeb128:
+		n, err := parseBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == KindULeb128 {
+			return appendULeb128(buf, n, t.Length)
+		}
+		return appendILeb128(buf, n, t.Length)
+	case KindByteList, KindByteArray:
+		s, ok := value.(string)
+		if !ok {
+			return nil, typeError("hex string", value)
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == KindByteArray {
+			if len(b) != int(t.Length) {
+				return nil, fmt.Errorf("expected %d bytes, got %d", t.Length, len(b))
+			}
+			return append(buf, b...), nil
+		}
+		buf, err = appendLength(buf, t.SizeLength, len(b))
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, b...), nil
+	}
+	return nil, fmt.Errorf("invalid schema type kind %d", t.Kind)
+}
+
+// serialize appends the serialization of the fields in the JSON value to buf.
+func (f *Fields) serialize(buf []byte, value any) ([]byte, error) {
+	var err error
+	switch f.Kind {
+	case FieldsNamed:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, typeError("object", value)
+		}
+		if len(object) != len(f.Fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(f.Fields), len(object))
+		}
+		for _, field := range f.Fields {
+			fieldValue, ok := object[field.Name]
+			if !ok {
+				return nil, fmt.Errorf("missing field %q", field.Name)
+			}
+			if buf, err = field.Type.serialize(buf, fieldValue); err != nil {
+				return nil, fmt.Errorf("invalid field %q: %w", field.Name, err)
+			}
+		}
+		return buf, nil
+	case FieldsUnnamed:
+		array, ok := value.([]any)
+		if !ok {
+			return nil, typeError("array", value)
+		}
+		if len(array) != len(f.Fields) {
+			return nil, fmt.Errorf("expected %d fields, got %d", len(f.Fields), len(array))
+		}
+		for i, field := range f.Fields {
+			if buf, err = field.Type.serialize(buf, array[i]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	return buf, nil
+}
+
+// serializeElements appends the serialization of each element of the JSON array to buf.
+func serializeElements(buf []byte, t *Type, array []any) ([]byte, error) {
+	var err error
+	for _, element := range array {
+		if buf, err = t.serialize(buf, element); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// typeError returns an error describing that the JSON value does not match the expected type.
+func typeError(expected string, value any) error {
+	return fmt.Errorf("expected %s, got %T", expected, value)
+}
+
+// appendString appends a string prefixed by its length.
+func appendString(buf []byte, sizeLength SizeLength, s string) ([]byte, error) {
+	buf, err := appendLength(buf, sizeLength, len(s))
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, s...), nil
+}
+
+// appendUint appends the lowest bits of n in little-endian byte order.
+func appendUint(buf []byte, n uint64, bits int) []byte {
+	for i := 0; i < bits/8; i++ {
+		buf = append(buf, byte(n>>(8*i)))
+	}
+	return buf
+}
+
+// appendInt128 appends a 128-bit integer in little-endian byte order, using two's complement if signed.
+func appendInt128(buf []byte, n *big.Int, signed bool) ([]byte, error) {
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), 128)
+	if signed {
+		min.Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+		max.Lsh(big.NewInt(1), 127)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("value %s out of range", n)
+	}
+	v := new(big.Int).Set(n)
+	if v.Sign() < 0 {
+		v.Add(v, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	b := make([]byte, 16)
+	v.FillBytes(b)
+	for i := 15; i >= 0; i-- {
+		buf = append(buf, b[i])
+	}
+	return buf, nil
+}
+
+// numberString returns the string representation of a JSON number, which may also be given as a string.
+func numberString(value any) (string, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case string:
+		return v, nil
+	}
+	return "", typeError("number", value)
+}
+
+// parseUint parses an unsigned integer of the given number of bits.
+func parseUint(value any, bits int) (uint64, error) {
+	s, err := numberString(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, bits)
+}
+
+// parseInt parses a signed integer of the given number of bits.
+func parseInt(value any, bits int) (int64, error) {
+	s, err := numberString(value)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, bits)
+}
+
+// parseBigInt parses an integer of arbitrary size.
+func parseBigInt(value any) (*big.Int, error) {
+	s, err := numberString(value)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
+
+// durationUnits are the units of a duration in milliseconds, in the order they are formatted.
+var durationUnits = []struct {
+	suffix string
+	millis uint64
+}{
+	{"d", 24 * 60 * 60 * 1000},
+	{"h", 60 * 60 * 1000},
+	{"m", 60 * 1000},
+	{"s", 1000},
+	{"ms", 1},
+}
+
+// parseDuration parses a duration such as "1d 2h 3m 4s 5ms" to milliseconds.
+func parseDuration(s string) (uint64, error) {
+	parts := strings.Fields(s)
+	if len(parts) == 0 {
+		return 0, errors.New("empty duration")
+	}
+	var total uint64
+	for _, part := range parts {
+		i := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		n, err := strconv.ParseUint(part[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		var millis uint64
+		for _, unit := range durationUnits {
+			if unit.suffix == part[i:] {
+				millis = unit.millis
+			}
+		}
+		if millis == 0 {
+			return 0, fmt.Errorf("invalid duration unit in %q", part)
+		}
+		if n > (math.MaxUint64-total)/millis {
+			return 0, fmt.Errorf("duration %q out of range", s)
+		}
+		total += n * millis
+	}
+	return total, nil
+}
+
+// formatDuration formats milliseconds as a duration such as "1d 2h 3m 4s 5ms".
+func formatDuration(millis uint64) string {
+	var parts []string
+	for _, unit := range durationUnits {
+		if n := millis / unit.millis; n > 0 {
+			parts = append(parts, strconv.FormatUint(n, 10)+unit.suffix)
+			millis %= unit.millis
+		}
+	}
+	if len(parts) == 0 {
+		return "0ms"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"errors"
+	"math/big"
+)
+
+// errLeb128TooLong indicates that a LEB128 encoded value uses more bytes than allowed.
+var errLeb128TooLong = errors.New("LEB128 value exceeds the maximum number of bytes")
+
+// readULeb128 reads an unsigned LEB128 encoded value of at most maxBytes bytes that fits in 64 bits.
+func readULeb128(r *reader, maxBytes int) (uint64, error) {
+	value, err := readULeb128Big(r, uint32(maxBytes))
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() {
+		return 0, errLeb128TooLong
+	}
+	return value.Uint64(), nil
+}
+
+// readULeb128Big reads an unsigned LEB128 encoded value of at most maxBytes bytes.
+func readULeb128Big(r *reader, maxBytes uint32) (*big.Int, error) {
+	value := new(big.Int)
+	for i := uint32(0); ; i++ {
+		if i >= maxBytes {
+			return nil, errLeb128TooLong
+		}
+		b, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		value.Or(value, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), uint(7*i)))
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+}
+
+// readILeb128Big reads a signed LEB128 encoded value of at most maxBytes bytes.
+func readILeb128Big(r *reader, maxBytes uint32) (*big.Int, error) {
+	value := new(big.Int)
+	for i := uint32(0); ; i++ {
+		if i >= maxBytes {
+			return nil, errLeb128TooLong
+		}
+		b, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		value.Or(value, new(big.Int).Lsh(big.NewInt(int64(b&0x7f)), uint(7*i)))
+		if b&0x80 == 0 {
+			// sign extend if the sign bit of the last byte is set.
+			if b&0x40 != 0 {
+				value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(7*(i+1))))
+			}
+			return value, nil
+		}
+	}
+}
+
+// appendULeb128 appends an unsigned LEB128 encoding of value, which must not be negative.
+func appendULeb128(buf []byte, value *big.Int, maxBytes uint32) ([]byte, error) {
+	if value.Sign() < 0 {
+		return nil, errors.New("negative value for unsigned LEB128")
+	}
+	v := new(big.Int).Set(value)
+	mask := big.NewInt(0x7f)
+	for i := uint32(0); ; i++ {
+		if i >= maxBytes {
+			return nil, errLeb128TooLong
+		}
+		b := byte(new(big.Int).And(v, mask).Uint64())
+		v.Rsh(v, 7)
+		if v.Sign() == 0 {
+			return append(buf, b), nil
+		}
+		buf = append(buf, b|0x80)
+	}
+}
+
+// appendILeb128 appends a signed LEB128 encoding of value.
+func appendILeb128(buf []byte, value *big.Int, maxBytes uint32) ([]byte, error) {
+	v := new(big.Int).Set(value)
+	mask := big.NewInt(0x7f)
+	for i := uint32(0); ; i++ {
+		if i >= maxBytes {
+			return nil, errLeb128TooLong
+		}
+		// And on a negative big.Int uses two's complement, and Rsh rounds towards negative infinity.
+		b := byte(new(big.Int).And(v, mask).Uint64())
+		v.Rsh(v, 7)
+		if (v.Sign() == 0 && b&0x40 == 0) || (v.Cmp(big.NewInt(-1)) == 0 && b&0x40 != 0) {
+			return append(buf, b), nil
+		}
+		buf = append(buf, b|0x80)
+	}
+}
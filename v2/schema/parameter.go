@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+// InitParameterFromJSON serializes the JSON representation of the parameter of the init function of the contract.
+func (s *Schema) InitParameterFromJSON(contractName string, value []byte) (*v2.Parameter, error) {
+	t, err := s.InitParameter(contractName)
+	if err != nil {
+		return nil, err
+	}
+	return parameterFromJSON(t, value)
+}
+
+// ReceiveParameterFromJSON serializes the JSON representation of the parameter of the entrypoint of the contract.
+func (s *Schema) ReceiveParameterFromJSON(contractName, entrypoint string, value []byte) (*v2.Parameter, error) {
+	t, err := s.ReceiveParameter(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	return parameterFromJSON(t, value)
+}
+
+// parameterFromJSON serializes the JSON value according to the type and wraps the result in a Parameter.
+func parameterFromJSON(t *Type, value []byte) (*v2.Parameter, error) {
+	parameter, err := t.SerializeJSON(value)
+	if err != nil {
+		return nil, err
+	}
+	return &v2.Parameter{Value: parameter}, nil
+}
+
+// InitParameterFromValue serializes a Go value as the parameter of the init function of the contract. See Type.Serialize.
+func (s *Schema) InitParameterFromValue(contractName string, value any) (*v2.Parameter, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return s.InitParameterFromJSON(contractName, data)
+}
+
+// ReceiveParameterFromValue serializes a Go value as the parameter of the entrypoint of the contract.
+// See Type.Serialize.
+func (s *Schema) ReceiveParameterFromValue(contractName, entrypoint string, value any) (*v2.Parameter, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return s.ReceiveParameterFromJSON(contractName, entrypoint, data)
+}
+
+// ReceiveParameterToJSON returns the JSON representation of the parameter of the entrypoint of the contract.
+func (s *Schema) ReceiveParameterToJSON(contractName, entrypoint string, parameter v2.Parameter) (json.RawMessage, error) {
+	t, err := s.ReceiveParameter(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	return t.ToJSON(parameter.Value)
+}
+
+// ReturnValueToJSON returns the JSON representation of the value returned by the entrypoint of the contract.
+func (s *Schema) ReturnValueToJSON(contractName, entrypoint string, returnValue []byte) (json.RawMessage, error) {
+	t, err := s.ReceiveReturnValue(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	return t.ToJSON(returnValue)
+}
+
+// ErrorToJSON returns the JSON representation of the error returned by the entrypoint of the contract when it
+// rejects. The error is the return value of the rejected invocation.
+func (s *Schema) ErrorToJSON(contractName, entrypoint string, errorValue []byte) (json.RawMessage, error) {
+	t, err := s.ReceiveError(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	return t.ToJSON(errorValue)
+}
+
+// EventToJSON returns the JSON representation of an event logged by the contract.
+func (s *Schema) EventToJSON(contractName string, event v2.ContractEvent) (json.RawMessage, error) {
+	t, err := s.Event(contractName)
+	if err != nil {
+		return nil, err
+	}
+	return t.ToJSON(event.Value)
+}
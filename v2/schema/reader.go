@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// errUnexpectedEnd indicates that the input ended before the value was complete.
+var errUnexpectedEnd = errors.New("unexpected end of input")
+
+// reader reads little-endian encoded values, as used by smart contracts, from a byte slice.
+type reader struct {
+	data   []byte
+	offset int
+}
+
+// remaining returns the number of bytes that have not been read yet.
+func (r *reader) remaining() int {
+	return len(r.data) - r.offset
+}
+
+// bytes reads the next n bytes.
+func (r *reader) bytes(n uint64) ([]byte, error) {
+	if n > uint64(r.remaining()) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.data[r.offset : r.offset+int(n)]
+	r.offset += int(n)
+	return b, nil
+}
+
+func (r *reader) u8() (uint8, error) {
+	b, err := r.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (r *reader) u16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *reader) u32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *reader) u64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// option reads the tag of an optional value.
+func (r *reader) option() (bool, error) {
+	tag, err := r.u8()
+	if err != nil {
+		return false, err
+	}
+	switch tag {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid option tag %d", tag)
+}
+
+// string reads a string prefixed by its length as u32.
+func (r *reader) string() (string, error) {
+	n, err := r.u32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.bytes(uint64(n))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// length reads a length prefix of the given size.
+func (r *reader) length(sizeLength SizeLength) (uint64, error) {
+	switch sizeLength {
+	case SizeLengthU8:
+		n, err := r.u8()
+		return uint64(n), err
+	case SizeLengthU16:
+		n, err := r.u16()
+		return uint64(n), err
+	case SizeLengthU32:
+		n, err := r.u32()
+		return uint64(n), err
+	case SizeLengthU64:
+		return r.u64()
+	}
+	return 0, fmt.Errorf("invalid size length %d", sizeLength)
+}
+
+// appendLength appends a length prefix of the given size.
+func appendLength(buf []byte, sizeLength SizeLength, n int) ([]byte, error) {
+	var max uint64
+	switch sizeLength {
+	case SizeLengthU8:
+		max = math.MaxUint8
+	case SizeLengthU16:
+		max = math.MaxUint16
+	case SizeLengthU32:
+		max = math.MaxUint32
+	default:
+		max = math.MaxUint64
+	}
+	if uint64(n) > max {
+		return nil, fmt.Errorf("length %d does not fit the length prefix", n)
+	}
+	switch sizeLength {
+	case SizeLengthU8:
+		return append(buf, uint8(n)), nil
+	case SizeLengthU16:
+		return binary.LittleEndian.AppendUint16(buf, uint16(n)), nil
+	case SizeLengthU32:
+		return binary.LittleEndian.AppendUint32(buf, uint32(n)), nil
+	}
+	return binary.LittleEndian.AppendUint64(buf, uint64(n)), nil
+}
@@ -0,0 +1,313 @@
+// Package schema parses smart contract schemas and uses them to convert between JSON values and the
+// binary serialization of parameters, return values, errors and events of smart contracts.
+//
+// A schema is usually embedded in a custom section of the Wasm module of the contract, from where it can be
+// extracted with FromModuleSource. Schemas of version V0 to V3 are supported.
+package schema
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoSchema indicates that the module does not contain an embedded schema.
+	ErrNoSchema = errors.New("module does not contain a schema")
+	// ErrContractNotFound indicates that the schema does not contain the requested contract.
+	ErrContractNotFound = errors.New("contract not found in schema")
+	// ErrEntrypointNotFound indicates that the schema does not contain the requested entrypoint.
+	ErrEntrypointNotFound = errors.New("entrypoint not found in schema")
+	// ErrNoType indicates that the schema does not contain a type for the requested value.
+	ErrNoType = errors.New("schema does not contain a type for the value")
+)
+
+// Version the version of a module schema.
+type Version uint8
+
+const (
+	// VersionV0 schema of a V0 contract module, which includes the type of the contract state.
+	VersionV0 Version = 0
+	// VersionV1 schema of a V1 contract module with parameter and return value types.
+	VersionV1 Version = 1
+	// VersionV2 schema of a V1 contract module, which adds error types.
+	VersionV2 Version = 2
+	// VersionV3 schema of a V1 contract module, which adds event types.
+	VersionV3 Version = 3
+)
+
+// versionedSchemaPrefix is the prefix that distinguishes versioned schemas from legacy unversioned schemas.
+var versionedSchemaPrefix = [2]byte{0xff, 0xff}
+
+// Schema the schema of a smart contract module.
+type Schema struct {
+	// The version of the schema.
+	Version Version
+	// The schemas of the contracts in the module by contract name.
+	Contracts map[string]*Contract
+}
+
+// Contract the schema of a single contract.
+type Contract struct {
+	// The type of the contract state. Only present in V0 schemas.
+	State *Type
+	// The schema of the init function.
+	Init *Function
+	// The schemas of the receive functions by entrypoint name.
+	Receive map[string]*Function
+	// The type of the events logged by the contract. Only present in V3 schemas.
+	Event *Type
+}
+
+// Function the schema of an init or receive function.
+type Function struct {
+	// The type of the parameter.
+	Parameter *Type
+	// The type of the return value. Only present in V1 to V3 schemas.
+	ReturnValue *Type
+	// The type of the error. Only present in V2 and V3 schemas.
+	Error *Type
+}
+
+// Parse parses a versioned schema, i.e. one that starts with the versioned schema prefix as produced
+// by `cargo concordium build --schema-out`.
+func Parse(source []byte) (*Schema, error) {
+	if len(source) < 3 || source[0] != versionedSchemaPrefix[0] || source[1] != versionedSchemaPrefix[1] {
+		return nil, errors.New("schema is not versioned")
+	}
+	return ParseWithVersion(source[3:], Version(source[2]))
+}
+
+// ParseWithVersion parses an unversioned schema of the given version.
+func ParseWithVersion(source []byte, version Version) (*Schema, error) {
+	if version > VersionV3 {
+		return nil, fmt.Errorf("unsupported schema version %d", version)
+	}
+
+	r := &reader{data: source}
+	numContracts, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{Version: version, Contracts: make(map[string]*Contract)}
+	for i := uint32(0); i < numContracts; i++ {
+		name, err := r.string()
+		if err != nil {
+			return nil, err
+		}
+		contract, err := parseContract(r, version)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing schema of contract %q: %w", name, err)
+		}
+		schema.Contracts[name] = contract
+	}
+	if r.remaining() != 0 {
+		return nil, errors.New("unexpected trailing bytes in schema")
+	}
+
+	return schema, nil
+}
+
+// parseContract parses the schema of a single contract.
+func parseContract(r *reader, version Version) (*Contract, error) {
+	contract := &Contract{Receive: make(map[string]*Function)}
+
+	if version == VersionV0 {
+		state, err := parseOptionalType(r)
+		if err != nil {
+			return nil, err
+		}
+		contract.State = state
+	}
+
+	init, err := parseOptionalFunction(r, version)
+	if err != nil {
+		return nil, err
+	}
+	contract.Init = init
+
+	numReceive, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < numReceive; i++ {
+		name, err := r.string()
+		if err != nil {
+			return nil, err
+		}
+		function, err := parseFunction(r, version)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing schema of entrypoint %q: %w", name, err)
+		}
+		contract.Receive[name] = function
+	}
+
+	if version == VersionV3 {
+		event, err := parseOptionalType(r)
+		if err != nil {
+			return nil, err
+		}
+		contract.Event = event
+	}
+
+	return contract, nil
+}
+
+// parseOptionalFunction parses a function schema prefixed by an option tag.
+func parseOptionalFunction(r *reader, version Version) (*Function, error) {
+	present, err := r.option()
+	if err != nil || !present {
+		return nil, err
+	}
+	return parseFunction(r, version)
+}
+
+// parseFunction parses the schema of a function, the format of which depends on the version.
+func parseFunction(r *reader, version Version) (*Function, error) {
+	switch version {
+	case VersionV0:
+		parameter, err := parseType(r)
+		if err != nil {
+			return nil, err
+		}
+		return &Function{Parameter: parameter}, nil
+	case VersionV1:
+		tag, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		// 0: parameter, 1: return value, 2: both.
+		if tag > 2 {
+			return nil, fmt.Errorf("invalid function schema tag %d", tag)
+		}
+		return parseFunctionTypes(r, tag != 1, tag != 0, false)
+	default:
+		tag, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		// the tag is a combination of which of parameter, return value and error are present.
+		switch tag {
+		case 0:
+			return parseFunctionTypes(r, true, false, false)
+		case 1:
+			return parseFunctionTypes(r, false, true, false)
+		case 2:
+			return parseFunctionTypes(r, true, true, false)
+		case 3:
+			return parseFunctionTypes(r, false, false, true)
+		case 4:
+			return parseFunctionTypes(r, true, false, true)
+		case 5:
+			return parseFunctionTypes(r, false, true, true)
+		case 6:
+			return parseFunctionTypes(r, true, true, true)
+		case 7:
+			return &Function{}, nil
+		}
+		return nil, fmt.Errorf("invalid function schema tag %d", tag)
+	}
+}
+
+// parseFunctionTypes parses the types of a function that are present in the order parameter, return value, error.
+func parseFunctionTypes(r *reader, parameter, returnValue, errorType bool) (*Function, error) {
+	var function Function
+	var err error
+	if parameter {
+		if function.Parameter, err = parseType(r); err != nil {
+			return nil, err
+		}
+	}
+	if returnValue {
+		if function.ReturnValue, err = parseType(r); err != nil {
+			return nil, err
+		}
+	}
+	if errorType {
+		if function.Error, err = parseType(r); err != nil {
+			return nil, err
+		}
+	}
+	return &function, nil
+}
+
+// Contract returns the schema of the contract with the given name.
+func (s *Schema) Contract(contractName string) (*Contract, error) {
+	contract, ok := s.Contracts[contractName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrContractNotFound, contractName)
+	}
+	return contract, nil
+}
+
+// InitParameter returns the type of the parameter of the init function of the contract.
+func (s *Schema) InitParameter(contractName string) (*Type, error) {
+	contract, err := s.Contract(contractName)
+	if err != nil {
+		return nil, err
+	}
+	if contract.Init == nil || contract.Init.Parameter == nil {
+		return nil, ErrNoType
+	}
+	return contract.Init.Parameter, nil
+}
+
+// receiveFunction returns the schema of the receive function of the contract.
+func (s *Schema) receiveFunction(contractName, entrypoint string) (*Function, error) {
+	contract, err := s.Contract(contractName)
+	if err != nil {
+		return nil, err
+	}
+	function, ok := contract.Receive[entrypoint]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrEntrypointNotFound, entrypoint)
+	}
+	return function, nil
+}
+
+// ReceiveParameter returns the type of the parameter of the entrypoint of the contract.
+func (s *Schema) ReceiveParameter(contractName, entrypoint string) (*Type, error) {
+	function, err := s.receiveFunction(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	if function.Parameter == nil {
+		return nil, ErrNoType
+	}
+	return function.Parameter, nil
+}
+
+// ReceiveReturnValue returns the type of the return value of the entrypoint of the contract.
+func (s *Schema) ReceiveReturnValue(contractName, entrypoint string) (*Type, error) {
+	function, err := s.receiveFunction(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	if function.ReturnValue == nil {
+		return nil, ErrNoType
+	}
+	return function.ReturnValue, nil
+}
+
+// ReceiveError returns the type of the error of the entrypoint of the contract.
+func (s *Schema) ReceiveError(contractName, entrypoint string) (*Type, error) {
+	function, err := s.receiveFunction(contractName, entrypoint)
+	if err != nil {
+		return nil, err
+	}
+	if function.Error == nil {
+		return nil, ErrNoType
+	}
+	return function.Error, nil
+}
+
+// Event returns the type of the events logged by the contract.
+func (s *Schema) Event(contractName string) (*Type, error) {
+	contract, err := s.Contract(contractName)
+	if err != nil {
+		return nil, err
+	}
+	if contract.Event == nil {
+		return nil, ErrNoType
+	}
+	return contract.Event, nil
+}
@@ -0,0 +1,250 @@
+package schema
+
+import (
+	"fmt"
+)
+
+// Kind the kind of a schema type.
+type Kind uint8
+
+const (
+	KindUnit Kind = iota
+	KindBool
+	KindU8
+	KindU16
+	KindU32
+	KindU64
+	KindI8
+	KindI16
+	KindI32
+	KindI64
+	KindAmount
+	KindAccountAddress
+	KindContractAddress
+	KindTimestamp
+	KindDuration
+	KindPair
+	KindList
+	KindSet
+	KindMap
+	KindArray
+	KindStruct
+	KindEnum
+	KindString
+	KindU128
+	KindI128
+	KindContractName
+	KindReceiveName
+	KindULeb128
+	KindILeb128
+	KindByteList
+	KindByteArray
+	KindTaggedEnum
+)
+
+// SizeLength the number of bytes used to serialize the length of a collection.
+type SizeLength uint8
+
+const (
+	SizeLengthU8 SizeLength = iota
+	SizeLengthU16
+	SizeLengthU32
+	SizeLengthU64
+)
+
+// FieldsKind the kind of the fields of a struct or an enum variant.
+type FieldsKind uint8
+
+const (
+	// FieldsNamed the fields have names and are represented as a JSON object.
+	FieldsNamed FieldsKind = iota
+	// FieldsUnnamed the fields have no names and are represented as a JSON array.
+	FieldsUnnamed
+	// FieldsNone there are no fields. This is represented as an empty JSON array.
+	FieldsNone
+)
+
+// Type a type in a contract schema. Which of the fields are used depends on the Kind.
+type Type struct {
+	Kind Kind
+	// The size of the length prefix of KindList, KindSet, KindMap, KindString, KindContractName,
+	// KindReceiveName and KindByteList.
+	SizeLength SizeLength
+	// The number of elements of KindArray and KindByteArray, or the maximum number of bytes of
+	// KindULeb128 and KindILeb128.
+	Length uint32
+	// The element types of KindPair (two), KindList, KindSet and KindArray (one) and KindMap (key and value).
+	Elements []*Type
+	// The fields of KindStruct.
+	Fields *Fields
+	// The variants of KindEnum and KindTaggedEnum.
+	Variants []Variant
+}
+
+// Fields the fields of a struct or an enum variant.
+type Fields struct {
+	Kind FieldsKind
+	// The fields in serialization order. Names are empty for unnamed fields.
+	Fields []Field
+}
+
+// Field a single field of a struct or an enum variant.
+type Field struct {
+	Name string
+	Type *Type
+}
+
+// Variant a variant of an enum.
+type Variant struct {
+	// The tag of the variant. Only used by KindTaggedEnum, the variants of KindEnum are identified by their index.
+	Tag    uint8
+	Name   string
+	Fields *Fields
+}
+
+// maxTypeDepth limits the nesting of types to protect against malicious schemas.
+const maxTypeDepth = 128
+
+// parseOptionalType parses a type prefixed by an option tag.
+func parseOptionalType(r *reader) (*Type, error) {
+	present, err := r.option()
+	if err != nil || !present {
+		return nil, err
+	}
+	return parseType(r)
+}
+
+// parseType parses a schema type.
+func parseType(r *reader) (*Type, error) {
+	return parseTypeWithDepth(r, 0)
+}
+
+func parseTypeWithDepth(r *reader, depth int) (*Type, error) {
+	if depth > maxTypeDepth {
+		return nil, fmt.Errorf("schema type is nested deeper than %d levels", maxTypeDepth)
+	}
+	tag, err := r.u8()
+	if err != nil {
+		return nil, err
+	}
+	t := &Type{Kind: Kind(tag)}
+	elements := func(n int) error {
+		for i := 0; i < n; i++ {
+			element, err := parseTypeWithDepth(r, depth+1)
+			if err != nil {
+				return err
+			}
+			t.Elements = append(t.Elements, element)
+		}
+		return nil
+	}
+
+	switch t.Kind {
+	case KindUnit, KindBool, KindU8, KindU16, KindU32, KindU64, KindI8, KindI16, KindI32, KindI64, KindAmount,
+		KindAccountAddress, KindContractAddress, KindTimestamp, KindDuration, KindU128, KindI128:
+	case KindPair:
+		err = elements(2)
+	case KindList, KindSet:
+		if t.SizeLength, err = parseSizeLength(r); err == nil {
+			err = elements(1)
+		}
+	case KindMap:
+		if t.SizeLength, err = parseSizeLength(r); err == nil {
+			err = elements(2)
+		}
+	case KindArray:
+		if t.Length, err = r.u32(); err == nil {
+			err = elements(1)
+		}
+	case KindStruct:
+		t.Fields, err = parseFields(r, depth)
+	case KindEnum:
+		var numVariants uint32
+		if numVariants, err = r.u32(); err != nil {
+			break
+		}
+		for i := uint32(0); i < numVariants; i++ {
+			var variant Variant
+			if variant.Name, err = r.string(); err != nil {
+				break
+			}
+			if variant.Fields, err = parseFields(r, depth); err != nil {
+				break
+			}
+			t.Variants = append(t.Variants, variant)
+		}
+	case KindString, KindContractName, KindReceiveName, KindByteList:
+		t.SizeLength, err = parseSizeLength(r)
+	case KindULeb128, KindILeb128, KindByteArray:
+		t.Length, err = r.u32()
+	case KindTaggedEnum:
+		var numVariants uint32
+		if numVariants, err = r.u32(); err != nil {
+			break
+		}
+		for i := uint32(0); i < numVariants; i++ {
+			var variant Variant
+			if variant.Tag, err = r.u8(); err != nil {
+				break
+			}
+			if variant.Name, err = r.string(); err != nil {
+				break
+			}
+			if variant.Fields, err = parseFields(r, depth); err != nil {
+				break
+			}
+			t.Variants = append(t.Variants, variant)
+		}
+	default:
+		return nil, fmt.Errorf("invalid schema type tag %d", tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// parseSizeLength parses the size of a length prefix.
+func parseSizeLength(r *reader) (SizeLength, error) {
+	tag, err := r.u8()
+	if err != nil {
+		return 0, err
+	}
+	if SizeLength(tag) > SizeLengthU64 {
+		return 0, fmt.Errorf("invalid size length %d", tag)
+	}
+	return SizeLength(tag), nil
+}
+
+// parseFields parses the fields of a struct or an enum variant.
+func parseFields(r *reader, depth int) (*Fields, error) {
+	tag, err := r.u8()
+	if err != nil {
+		return nil, err
+	}
+	fields := &Fields{Kind: FieldsKind(tag)}
+	switch fields.Kind {
+	case FieldsNamed, FieldsUnnamed:
+		numFields, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint32(0); i < numFields; i++ {
+			var field Field
+			if fields.Kind == FieldsNamed {
+				if field.Name, err = r.string(); err != nil {
+					return nil, err
+				}
+			}
+			if field.Type, err = parseTypeWithDepth(r, depth+1); err != nil {
+				return nil, err
+			}
+			fields.Fields = append(fields.Fields, field)
+		}
+	case FieldsNone:
+	default:
+		return nil, fmt.Errorf("invalid fields tag %d", tag)
+	}
+	return fields, nil
+}
@@ -0,0 +1,115 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+const (
+	// sectionName is the name of the custom section containing a versioned schema.
+	sectionName = "concordium-schema"
+	// sectionNameV1 is the name of the custom section containing an unversioned V0 schema in V0 modules.
+	sectionNameV1 = "concordium-schema-v1"
+	// sectionNameV2 is the name of the custom section containing an unversioned V1 schema in V1 modules.
+	sectionNameV2 = "concordium-schema-v2"
+)
+
+// wasmMagic is the magic number and version a Wasm module starts with.
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// FromModuleSource extracts the schema embedded in the Wasm module. It returns ErrNoSchema if the module
+// does not contain a schema.
+func FromModuleSource(source *v2.VersionedModuleSource) (*Schema, error) {
+	switch m := source.Module.(type) {
+	case v2.ModuleSourceV0:
+		return fromWasm(m.Value, false)
+	case *v2.ModuleSourceV0:
+		return fromWasm(m.Value, false)
+	case v2.ModuleSourceV1:
+		return fromWasm(m.Value, true)
+	case *v2.ModuleSourceV1:
+		return fromWasm(m.Value, true)
+	}
+	return nil, errors.New("unknown module source version")
+}
+
+// FromPbModuleSource extracts the schema embedded in the Wasm module as returned by Client.GetModuleSource.
+// It returns ErrNoSchema if the module does not contain a schema.
+func FromPbModuleSource(source *pb.VersionedModuleSource) (*Schema, error) {
+	switch m := source.GetModule().(type) {
+	case *pb.VersionedModuleSource_V0:
+		return fromWasm(m.V0.GetValue(), false)
+	case *pb.VersionedModuleSource_V1:
+		return fromWasm(m.V1.GetValue(), true)
+	}
+	return nil, errors.New("unknown module source version")
+}
+
+// fromWasm extracts the schema from the custom sections of a Wasm module.
+func fromWasm(wasm []byte, isV1 bool) (*Schema, error) {
+	sections, err := customSections(wasm)
+	if err != nil {
+		return nil, err
+	}
+
+	if section, ok := sections[sectionName]; ok {
+		return Parse(section)
+	}
+	if section, ok := sections[sectionNameV1]; ok && !isV1 {
+		return ParseWithVersion(section, VersionV0)
+	}
+	if section, ok := sections[sectionNameV2]; ok && isV1 {
+		return ParseWithVersion(section, VersionV1)
+	}
+	return nil, ErrNoSchema
+}
+
+// customSections returns the contents of the schema custom sections of a Wasm module by name.
+func customSections(wasm []byte) (map[string][]byte, error) {
+	if !bytes.HasPrefix(wasm, wasmMagic) {
+		return nil, errors.New("invalid Wasm module header")
+	}
+
+	sections := make(map[string][]byte)
+	r := &reader{data: wasm, offset: len(wasmMagic)}
+	for r.remaining() > 0 {
+		id, err := r.u8()
+		if err != nil {
+			return nil, err
+		}
+		size, err := readULeb128(r, 5)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Wasm section size: %w", err)
+		}
+		content, err := r.bytes(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Wasm section: %w", err)
+		}
+		// only custom sections, which have ID 0, are of interest.
+		if id != 0 {
+			continue
+		}
+
+		sr := &reader{data: content}
+		nameSize, err := readULeb128(sr, 5)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Wasm custom section name: %w", err)
+		}
+		name, err := sr.bytes(nameSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Wasm custom section name: %w", err)
+		}
+		switch string(name) {
+		case sectionName, sectionNameV1, sectionNameV2:
+			if _, ok := sections[string(name)]; ok {
+				return nil, fmt.Errorf("duplicate Wasm custom section %q", name)
+			}
+			sections[string(name)] = content[sr.offset:]
+		}
+	}
+	return sections, nil
+}
@@ -0,0 +1,363 @@
+package testnode
+
+import (
+	"bytes"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// SendBlockItem queues a transfer or transfer with memo. It fails like a real node if the sender does not exist
+// or the sequence number is not the next one of the sender.
+func (n *Node) SendBlockItem(_ context.Context, req *pb.SendBlockItemRequest) (*pb.TransactionHash, error) {
+	tx := req.GetAccountTransaction()
+	if tx == nil {
+		return nil, status.Error(codes.Unimplemented, "only account transactions are supported")
+	}
+	hash, err := v2.ComputeBlockItemHash(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(tx.GetSignature().GetSignatures()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the transaction is not signed")
+	}
+
+	payload, err := v2.RawPayload{Value: tx.GetPayload().GetRawPayload()}.Decode()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid payload: "+err.Error())
+	}
+	it := &item{hash: *hash, nonce: tx.GetHeader().GetSequenceNumber().GetValue(), energy: tx.GetHeader().GetEnergyAmount().GetValue()}
+	switch p := payload.Payload.(type) {
+	case v2.Transfer:
+		it.transfer = p.Payload
+	case v2.TransferWithMemo:
+		it.transfer = &v2.TransferPayload{Receiver: p.Payload.Receiver, Amount: p.Payload.Amount}
+		it.memo = p.Payload.Memo
+	default:
+		return nil, status.Error(codes.Unimplemented, "only transfers are supported")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	sender, err := v2.AccountAddressFromBytes(tx.GetHeader().GetSender().GetValue())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	index, ok := n.indices[sender]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "the sender account does not exist")
+	}
+	it.sender = index
+	next := n.nextNonce(index)
+	switch {
+	case it.nonce < next:
+		return nil, status.Error(codes.InvalidArgument, "duplicate nonce")
+	case it.nonce > next:
+		return nil, status.Error(codes.InvalidArgument, "nonce too large")
+	}
+
+	n.pending = append(n.pending, it)
+	n.items[it.hash] = it
+	if n.autoFinalize {
+		n.bake()
+		n.finalize()
+	}
+	return &pb.TransactionHash{Value: it.hash.Value[:]}, nil
+}
+
+// nextNonce returns the next sequence number of the account, including pending block items.
+func (n *Node) nextNonce(index uint64) uint64 {
+	next := n.best().nonces[index]
+	for _, it := range n.pending {
+		if it.sender == index {
+			next = it.nonce + 1
+		}
+	}
+	return next
+}
+
+// nonFinalized returns the block items of the account that are pending or in non-finalized blocks.
+func (n *Node) nonFinalized(index uint64) []*item {
+	var res []*item
+	for _, b := range n.blocks[n.lastFinal+1:] {
+		for _, it := range b.items {
+			if it.sender == index {
+				res = append(res, it)
+			}
+		}
+	}
+	for _, it := range n.pending {
+		if it.sender == index {
+			res = append(res, it)
+		}
+	}
+	return res
+}
+
+// resolveBlock returns the block identified by the input.
+func (n *Node) resolveBlock(input *pb.BlockHashInput) (*block, error) {
+	switch input.GetBlockHashInput().(type) {
+	case *pb.BlockHashInput_Best:
+		return n.best(), nil
+	case *pb.BlockHashInput_LastFinal:
+		return n.blocks[n.lastFinal], nil
+	case *pb.BlockHashInput_Given:
+		for _, b := range n.blocks {
+			if string(b.hash.Value[:]) == string(input.GetGiven().GetValue()) {
+				return b, nil
+			}
+		}
+	case *pb.BlockHashInput_AbsoluteHeight:
+		if height := input.GetAbsoluteHeight().GetValue(); height < uint64(len(n.blocks)) {
+			return n.blocks[height], nil
+		}
+	default:
+		return nil, status.Error(codes.Unimplemented, "unsupported block input")
+	}
+	return nil, status.Error(codes.NotFound, "block not found")
+}
+
+// GetAccountInfo returns the balance and sequence number of an account identified by its address or index.
+func (n *Node) GetAccountInfo(_ context.Context, req *pb.AccountInfoRequest) (*pb.AccountInfo, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	b, err := n.resolveBlock(req.GetBlockHash())
+	if err != nil {
+		return nil, err
+	}
+	var index uint64
+	switch id := req.GetAccountIdentifier().GetAccountIdentifierInput().(type) {
+	case *pb.AccountIdentifierInput_Address:
+		address, err := v2.AccountAddressFromBytes(id.Address.GetValue())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		var ok bool
+		if index, ok = n.indices[address]; !ok {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+	case *pb.AccountIdentifierInput_CredId:
+		index = uint64(len(n.addresses))
+		for i, address := range n.addresses {
+			if bytes.Equal(credentialRegistrationId(address).Value, id.CredId.GetValue()) {
+				index = uint64(i)
+				break
+			}
+		}
+	case *pb.AccountIdentifierInput_AccountIndex:
+		index = id.AccountIndex.GetValue()
+	default:
+		return nil, status.Error(codes.Unimplemented, "unsupported account identifier")
+	}
+	if index >= uint64(len(b.balances)) {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+
+	return &pb.AccountInfo{
+		SequenceNumber:   &pb.SequenceNumber{Value: b.nonces[index]},
+		Amount:           &pb.Amount{Value: b.balances[index]},
+		Schedule:         &pb.ReleaseSchedule{Total: &pb.Amount{}},
+		Threshold:        &pb.AccountThreshold{Value: 1},
+		EncryptedBalance: &pb.EncryptedBalance{},
+		Index:            &pb.AccountIndex{Value: index},
+		Address:          &pb.AccountAddress{Value: n.addresses[index].Value[:]},
+		AvailableBalance: &pb.Amount{Value: b.balances[index]},
+	}, nil
+}
+
+// GetNextAccountSequenceNumber returns the next sequence number of the account, including pending block items.
+func (n *Node) GetNextAccountSequenceNumber(_ context.Context, req *pb.AccountAddress) (*pb.NextAccountSequenceNumber, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	address, err := v2.AccountAddressFromBytes(req.GetValue())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	index, ok := n.indices[address]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+	return &pb.NextAccountSequenceNumber{
+		SequenceNumber: &pb.SequenceNumber{Value: n.nextNonce(index)},
+		AllFinal:       len(n.nonFinalized(index)) == 0,
+	}, nil
+}
+
+// GetAccountNonFinalizedTransactions streams the hashes of the pending and non-finalized block items of the account.
+func (n *Node) GetAccountNonFinalizedTransactions(req *pb.AccountAddress, stream pb.Queries_GetAccountNonFinalizedTransactionsServer) error {
+	n.mu.Lock()
+	address, err := v2.AccountAddressFromBytes(req.GetValue())
+	if err != nil {
+		n.mu.Unlock()
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	var hashes []v2.TransactionHash
+	if index, ok := n.indices[address]; ok {
+		for _, it := range n.nonFinalized(index) {
+			hashes = append(hashes, it.hash)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, hash := range hashes {
+		if err := stream.Send(&pb.TransactionHash{Value: hash.Value[:]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBlockItemStatus returns whether the block item is received, committed to a block or finalized.
+func (n *Node) GetBlockItemStatus(_ context.Context, req *pb.TransactionHash) (*pb.BlockItemStatus, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var hash v2.TransactionHash
+	copy(hash.Value[:], req.GetValue())
+	it, ok := n.items[hash]
+	switch {
+	case !ok:
+		return nil, status.Error(codes.NotFound, "block item not found")
+	case it.block == nil:
+		return &pb.BlockItemStatus{Status: &pb.BlockItemStatus_Received{Received: &pb.Empty{}}}, nil
+	}
+
+	outcome := &pb.BlockItemSummaryInBlock{BlockHash: &pb.BlockHash{Value: it.block.hash.Value[:]}, Outcome: it.summary}
+	if it.block.height <= n.lastFinal {
+		return &pb.BlockItemStatus{Status: &pb.BlockItemStatus_Finalized_{Finalized: &pb.BlockItemStatus_Finalized{
+			Outcome: outcome,
+		}}}, nil
+	}
+	return &pb.BlockItemStatus{Status: &pb.BlockItemStatus_Committed_{Committed: &pb.BlockItemStatus_Committed{
+		Outcomes: []*pb.BlockItemSummaryInBlock{outcome},
+	}}}, nil
+}
+
+// GetFinalizedBlocks streams the blocks that are finalized from the time of the request onward. The response
+// headers are sent as soon as the stream is registered, so tests can wait for that with Header on the client stream.
+func (n *Node) GetFinalizedBlocks(_ *pb.Empty, stream pb.Queries_GetFinalizedBlocksServer) error {
+	notify := make(chan struct{}, 1)
+	n.mu.Lock()
+	n.subscribers[notify] = struct{}{}
+	sent := n.lastFinal
+	n.mu.Unlock()
+	defer func() {
+		n.mu.Lock()
+		delete(n.subscribers, notify)
+		n.mu.Unlock()
+	}()
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-notify:
+		}
+
+		n.mu.Lock()
+		blocks := n.blocks[sent+1 : n.lastFinal+1]
+		sent = n.lastFinal
+		n.mu.Unlock()
+
+		for _, b := range blocks {
+			if err := stream.Send(&pb.FinalizedBlockInfo{
+				Hash:   &pb.BlockHash{Value: b.hash.Value[:]},
+				Height: &pb.AbsoluteBlockHeight{Value: b.height},
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetConsensusInfo returns the best and last finalized blocks.
+func (n *Node) GetConsensusInfo(context.Context, *pb.Empty) (*pb.ConsensusInfo, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	best, lastFinal := n.best(), n.blocks[n.lastFinal]
+	return &pb.ConsensusInfo{
+		BestBlock:                &pb.BlockHash{Value: best.hash.Value[:]},
+		GenesisBlock:             &pb.BlockHash{Value: n.blocks[0].hash.Value[:]},
+		GenesisTime:              &pb.Timestamp{Value: uint64(GenesisTime.UnixMilli())},
+		LastFinalizedBlock:       &pb.BlockHash{Value: lastFinal.hash.Value[:]},
+		BestBlockHeight:          &pb.AbsoluteBlockHeight{Value: best.height},
+		LastFinalizedBlockHeight: &pb.AbsoluteBlockHeight{Value: lastFinal.height},
+		LastFinalizedTime:        &pb.Timestamp{Value: uint64(blockTime(lastFinal.height).UnixMilli())},
+	}, nil
+}
+
+// GetBlocksAtHeight returns the block at the given absolute height.
+func (n *Node) GetBlocksAtHeight(_ context.Context, req *pb.BlocksAtHeightRequest) (*pb.BlocksAtHeightResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	absolute := req.GetAbsolute()
+	if absolute == nil {
+		return nil, status.Error(codes.Unimplemented, "only absolute heights are supported")
+	}
+	res := &pb.BlocksAtHeightResponse{}
+	if height := absolute.GetHeight().GetValue(); height < uint64(len(n.blocks)) {
+		res.Blocks = append(res.Blocks, &pb.BlockHash{Value: n.blocks[height].hash.Value[:]})
+	}
+	return res, nil
+}
+
+// GetBlockInfo returns information about a block.
+func (n *Node) GetBlockInfo(_ context.Context, req *pb.BlockHashInput) (*pb.BlockInfo, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	b, err := n.resolveBlock(req)
+	if err != nil {
+		return nil, err
+	}
+	parent := n.blocks[0]
+	if b.height > 0 {
+		parent = n.blocks[b.height-1]
+	}
+	lastFinal := n.blocks[n.lastFinal]
+	if b.height < lastFinal.height {
+		lastFinal = b
+	}
+	at := &pb.Timestamp{Value: uint64(blockTime(b.height).UnixMilli())}
+	return &pb.BlockInfo{
+		Hash:               &pb.BlockHash{Value: b.hash.Value[:]},
+		Height:             &pb.AbsoluteBlockHeight{Value: b.height},
+		ParentBlock:        &pb.BlockHash{Value: parent.hash.Value[:]},
+		LastFinalizedBlock: &pb.BlockHash{Value: lastFinal.hash.Value[:]},
+		GenesisIndex:       &pb.GenesisIndex{},
+		EraBlockHeight:     &pb.BlockHeight{Value: b.height},
+		ReceiveTime:        at,
+		ArriveTime:         at,
+		SlotTime:           at,
+		Finalized:          b.height <= n.lastFinal,
+		TransactionCount:   uint32(len(b.items)),
+	}, nil
+}
+
+// GetBlockTransactionEvents streams the outcomes of the block items in a block.
+func (n *Node) GetBlockTransactionEvents(req *pb.BlockHashInput, stream pb.Queries_GetBlockTransactionEventsServer) error {
+	n.mu.Lock()
+	b, err := n.resolveBlock(req)
+	n.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, it := range b.items {
+		if err := stream.Send(it.summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
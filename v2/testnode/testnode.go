@@ -0,0 +1,293 @@
+// Package testnode provides an in-process fake Concordium node for tests of code built on v2.Client.
+//
+// A Node serves pb.QueriesServer over an in-memory listener, so that v2.NewClient can connect to it without any
+// network. It holds a scriptable ledger of accounts with balances and sequence numbers. Transfers sent with
+// SendBlockItem are queued until the test calls BakeBlock, which applies them in a new block, and Finalize, which
+// finalizes all blocks. Block hashes and times only depend on the contents of the blocks, so tests are
+// deterministic. Signatures are not verified and transactions are free.
+package testnode
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+const (
+	// bufferSize is the size of the in-memory connection buffers.
+	bufferSize = 1024 * 1024
+	// BlockTime is the time between the blocks of a Node.
+	BlockTime = 2 * time.Second
+)
+
+// GenesisTime is the time of the genesis block of a Node.
+var GenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// block a block together with the state of the accounts after it.
+type block struct {
+	hash   v2.BlockHash
+	height uint64
+	items  []*item
+	// balances and next sequence numbers of the accounts, by account index.
+	balances []uint64
+	nonces   []uint64
+}
+
+// item a block item sent to the node.
+type item struct {
+	hash   v2.TransactionHash
+	sender uint64
+	nonce  uint64
+	energy uint64
+	// the receiver and amount of the transfer, and its memo if it has one.
+	transfer *v2.TransferPayload
+	memo     *v2.Memo
+	// the block the item is in, nil if it is pending.
+	block   *block
+	summary *pb.BlockItemSummary
+}
+
+// Node an in-process fake Concordium node. It must be closed with Close.
+type Node struct {
+	pb.UnimplementedQueriesServer
+
+	listener *bufconn.Listener
+	server   *grpc.Server
+
+	mu sync.Mutex
+	// addresses of the accounts, by account index.
+	addresses []v2.AccountAddress
+	indices   map[v2.AccountAddress]uint64
+	blocks    []*block
+	lastFinal uint64
+	pending   []*item
+	items     map[v2.TransactionHash]*item
+	// notified whenever blocks are finalized.
+	subscribers  map[chan struct{}]struct{}
+	autoFinalize bool
+}
+
+// New starts a Node with only a genesis block and no accounts.
+func New() *Node {
+	n := &Node{
+		listener:    bufconn.Listen(bufferSize),
+		server:      grpc.NewServer(),
+		indices:     make(map[v2.AccountAddress]uint64),
+		items:       make(map[v2.TransactionHash]*item),
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+	n.blocks = []*block{{hash: blockHash(v2.BlockHash{}, 0, nil)}}
+	pb.RegisterQueriesServer(n.server, n)
+	go n.server.Serve(n.listener)
+	return n
+}
+
+// Close stops the node and closes all connections to it.
+func (n *Node) Close() {
+	n.server.Stop()
+	n.listener.Close()
+}
+
+// Config returns a v2.Config that connects to the node.
+func (n *Node) Config() v2.Config {
+	return v2.Config{
+		NodeAddress:    "passthrough:///testnode",
+		TlsCredentials: insecure.NewCredentials(),
+		DialOptions: []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return n.listener.DialContext(ctx)
+		})},
+	}
+}
+
+// NewClient creates a v2.Client connected to the node.
+func (n *Node) NewClient() (*v2.Client, error) {
+	return v2.NewClient(n.Config())
+}
+
+// SetAutoFinalize sets whether every block item is put in its own block and finalized as soon as it is received.
+func (n *Node) SetAutoFinalize(autoFinalize bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.autoFinalize = autoFinalize
+}
+
+// AddAccount creates an account with the given balance in the state of the best block and returns its index.
+func (n *Node) AddAccount(address v2.AccountAddress, balance v2.Amount) (v2.AccountIndex, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.indices[address]; ok {
+		return v2.AccountIndex{}, errors.New("account already exists")
+	}
+	index := uint64(len(n.addresses))
+	n.addresses = append(n.addresses, address)
+	n.indices[address] = index
+	best := n.best()
+	best.balances = append(best.balances, balance.Value)
+	best.nonces = append(best.nonces, 1)
+	return v2.AccountIndex{Value: index}, nil
+}
+
+// Balance returns the balance of the account in the best block.
+func (n *Node) Balance(address v2.AccountAddress) (v2.Amount, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	index, ok := n.indices[address]
+	if !ok {
+		return v2.Amount{}, false
+	}
+	return v2.Amount{Value: n.best().balances[index]}, true
+}
+
+// CredentialRegistrationId returns the registration ID of the only credential of the account. Since credentials
+// are not modelled, it is derived from the address of the account.
+func (n *Node) CredentialRegistrationId(address v2.AccountAddress) (v2.CredentialRegistrationId, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, ok := n.indices[address]; !ok {
+		return v2.CredentialRegistrationId{}, false
+	}
+	return credentialRegistrationId(address), true
+}
+
+// BakeBlock applies the pending block items, in the order they were received, in a new block and returns its hash.
+// Transfers that exceed the balance of the sender are rejected, but still use their sequence number.
+func (n *Node) BakeBlock() v2.BlockHash {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.bake()
+}
+
+// Finalize finalizes all blocks and notifies the streams of finalized blocks.
+func (n *Node) Finalize() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.finalize()
+}
+
+func (n *Node) best() *block {
+	return n.blocks[len(n.blocks)-1]
+}
+
+func (n *Node) bake() v2.BlockHash {
+	parent := n.best()
+	b := &block{
+		height:   parent.height + 1,
+		items:    n.pending,
+		balances: append([]uint64(nil), parent.balances...),
+		nonces:   append([]uint64(nil), parent.nonces...),
+	}
+	n.pending = nil
+
+	hashes := make([]v2.TransactionHash, len(b.items))
+	for i, it := range b.items {
+		hashes[i] = it.hash
+	}
+	b.hash = blockHash(parent.hash, b.height, hashes)
+
+	for i, it := range b.items {
+		it.block = b
+		it.summary = n.execute(b, uint64(i), it)
+	}
+	n.blocks = append(n.blocks, b)
+	return b.hash
+}
+
+func (n *Node) finalize() {
+	if n.lastFinal == n.best().height {
+		return
+	}
+	n.lastFinal = n.best().height
+	for subscriber := range n.subscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// execute applies the transfer to the state of the block and returns its outcome.
+func (n *Node) execute(b *block, index uint64, it *item) *pb.BlockItemSummary {
+	b.nonces[it.sender] = it.nonce + 1
+	sender := n.addresses[it.sender]
+	amount := it.transfer.Amount.Value
+	transactionType := pb.TransactionType_TRANSFER
+	if it.memo != nil {
+		transactionType = pb.TransactionType_TRANSFER_WITH_MEMO
+	}
+
+	var effects *pb.AccountTransactionEffects
+	receiver, ok := n.indices[*it.transfer.Receiver]
+	switch {
+	case !ok:
+		effects = &pb.AccountTransactionEffects{Effect: &pb.AccountTransactionEffects_None_{None: &pb.AccountTransactionEffects_None{
+			TransactionType: &transactionType,
+			RejectReason: &pb.RejectReason{Reason: &pb.RejectReason_InvalidAccountReference{
+				InvalidAccountReference: &pb.AccountAddress{Value: it.transfer.Receiver.Value[:]},
+			}},
+		}}}
+	case b.balances[it.sender] < amount:
+		effects = &pb.AccountTransactionEffects{Effect: &pb.AccountTransactionEffects_None_{None: &pb.AccountTransactionEffects_None{
+			TransactionType: &transactionType,
+			RejectReason: &pb.RejectReason{Reason: &pb.RejectReason_AmountTooLarge_{AmountTooLarge: &pb.RejectReason_AmountTooLarge{
+				Address: &pb.Address{Type: &pb.Address_Account{Account: &pb.AccountAddress{Value: sender.Value[:]}}},
+				Amount:  &pb.Amount{Value: amount},
+			}}},
+		}}}
+	default:
+		b.balances[it.sender] -= amount
+		b.balances[receiver] += amount
+		transfer := &pb.AccountTransactionEffects_AccountTransfer{
+			Amount:   &pb.Amount{Value: amount},
+			Receiver: &pb.AccountAddress{Value: it.transfer.Receiver.Value[:]},
+		}
+		if it.memo != nil {
+			transfer.Memo = &pb.Memo{Value: it.memo.Value}
+		}
+		effects = &pb.AccountTransactionEffects{Effect: &pb.AccountTransactionEffects_AccountTransfer_{AccountTransfer: transfer}}
+	}
+
+	return &pb.BlockItemSummary{
+		Index:      &pb.BlockItemSummary_TransactionIndex{Value: index},
+		EnergyCost: &pb.Energy{Value: it.energy},
+		Hash:       &pb.TransactionHash{Value: it.hash.Value[:]},
+		Details: &pb.BlockItemSummary_AccountTransaction{AccountTransaction: &pb.AccountTransactionDetails{
+			Cost:    &pb.Amount{},
+			Sender:  &pb.AccountAddress{Value: sender.Value[:]},
+			Effects: effects,
+		}},
+	}
+}
+
+// credentialRegistrationId derives the 48 byte registration ID of the credential of an account from its address.
+func credentialRegistrationId(address v2.AccountAddress) v2.CredentialRegistrationId {
+	return v2.CredentialRegistrationId{Value: append(make([]byte, 16), address.Value[:]...)}
+}
+
+// blockHash computes the hash of a block from its parent, height and block items.
+func blockHash(parent v2.BlockHash, height uint64, items []v2.TransactionHash) v2.BlockHash {
+	buf := append([]byte("testnode"), parent.Value[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, height)
+	for _, hash := range items {
+		buf = append(buf, hash.Value[:]...)
+	}
+	return v2.BlockHash{Value: sha256.Sum256(buf)}
+}
+
+// blockTime returns the time of the block at the given height.
+func blockTime(height uint64) time.Time {
+	return GenesisTime.Add(time.Duration(height) * BlockTime)
+}
@@ -0,0 +1,65 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/testnode"
+)
+
+func TestGetAccountInfoIdentifiers(t *testing.T) {
+	node := testnode.New()
+	defer node.Close()
+
+	other, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	address, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(other, v2.Amount{Value: 1})
+	require.NoError(t, err)
+	index, err := node.AddAccount(address, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	credId, ok := node.CredentialRegistrationId(address)
+	require.True(t, ok)
+
+	client, err := node.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var nilAddress *v2.AccountAddress
+	var nilIndex *v2.AccountIndex
+	best := v2.BlockHashInputBest{}
+	for _, test := range []struct {
+		name string
+		get  func() (v2.AccountInfo, error)
+	}{
+		{name: "address", get: func() (v2.AccountInfo, error) { return client.GetAccountInfo(ctx, address, best) }},
+		{name: "address pointer", get: func() (v2.AccountInfo, error) { return client.GetAccountInfo(ctx, &address, best) }},
+		{name: "credential registration id", get: func() (v2.AccountInfo, error) { return client.GetAccountInfo(ctx, credId, best) }},
+		{name: "credential registration id pointer", get: func() (v2.AccountInfo, error) { return client.GetAccountInfo(ctx, &credId, best) }},
+		{name: "index", get: func() (v2.AccountInfo, error) { return client.GetAccountInfo(ctx, index, best) }},
+		{name: "index pointer", get: func() (v2.AccountInfo, error) { return client.GetAccountInfo(ctx, &index, best) }},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			info, err := test.get()
+			require.NoError(t, err)
+			require.Equal(t, address, info.Address)
+			require.Equal(t, index, info.Index)
+			require.Equal(t, v2.Amount{Value: 1000}, info.Amount)
+		})
+	}
+
+	_, err = client.GetAccountInfo(ctx, nilAddress, best)
+	require.ErrorContains(t, err, "unsupported account identifier")
+	_, err = client.GetAccountInfo(ctx, nilIndex, best)
+	require.ErrorContains(t, err, "unsupported account identifier")
+	_, err = client.GetAccountInfo(ctx, nil, best)
+	require.ErrorContains(t, err, "unsupported account identifier")
+}
@@ -2,18 +2,55 @@ package tests_test
 
 import (
 	"context"
+	"flag"
+	"path/filepath"
 	"testing"
 
 	v2 "github.com/Concordium/concordium-go-sdk/v2"
 	"github.com/Concordium/concordium-go-sdk/v2/pb"
+	"github.com/Concordium/concordium-go-sdk/v2/replay"
 	"github.com/stretchr/testify/require"
 )
 
-func TestExamples(t *testing.T) {
-	client, err := v2.NewClient(v2.Config{
-		NodeAddress: "node.testnet.concordium.com:20000",
+var (
+	record     = flag.Bool("record", false, "record the fixture of TestExamples against -node instead of replaying it")
+	recordNode = flag.String("node", "node.testnet.concordium.com:20000", "the node to record the fixture of TestExamples against")
+)
+
+// examplesFixture the responses of the node TestExamples was recorded against.
+var examplesFixture = filepath.Join("testdata", "examples.json")
+
+// newExamplesClient creates a client replaying examplesFixture, or recording it with -record.
+func newExamplesClient(t *testing.T) *v2.Client {
+	if *record {
+		recorder := replay.NewRecorder()
+		config := v2.Config{NodeAddress: *recordNode}
+		recorder.Install(&config)
+		client, err := v2.NewClient(config)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			client.Close()
+			require.NoError(t, recorder.Save(examplesFixture))
+		})
+		return client
+	}
+
+	fixture, err := replay.Load(examplesFixture)
+	require.NoError(t, err)
+	server := replay.NewServer(fixture)
+	t.Cleanup(func() {
+		// every request must have been answered from the fixture rather than failed as a mismatch.
+		require.Empty(t, server.Mismatches())
+		server.Close()
 	})
+	client, err := server.NewClient()
 	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestExamples(t *testing.T) {
+	client := newExamplesClient(t)
 	require.NotNil(t, client)
 
 	t.Run("GetBlocks", func(t *testing.T) {
@@ -47,15 +84,13 @@ func TestExamples(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, accList)
 
-		accInfo, err := client.GetAccountInfo(context.Background(),
-			&pb.AccountIdentifierInput{
-				AccountIdentifierInput: &pb.AccountIdentifierInput_Address{
-					Address: &pb.AccountAddress{
-						Value: accList[0].Value[:],
-					}}},
-			v2.BlockHashInputBest{})
+		accInfo, err := client.GetAccountInfo(context.Background(), *accList[0], v2.BlockHashInputBest{})
+		require.NoError(t, err)
+		require.Equal(t, *accList[0], accInfo.Address)
+
+		accInfoByIndex, err := client.GetAccountInfo(context.Background(), accInfo.Index, v2.BlockHashInputBest{})
 		require.NoError(t, err)
-		require.NotNil(t, accInfo)
+		require.Equal(t, accInfo.Address, accInfoByIndex.Address)
 	})
 
 	t.Run("GetModuleList", func(t *testing.T) {
@@ -0,0 +1,211 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+	"github.com/Concordium/concordium-go-sdk/v2/testnode"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// capturingNode a testnode.Node that records the block items sent to it.
+type capturingNode struct {
+	*testnode.Node
+
+	mu   sync.Mutex
+	sent []*pb.SendBlockItemRequest
+}
+
+func (n *capturingNode) SendBlockItem(ctx context.Context, req *pb.SendBlockItemRequest) (*pb.TransactionHash, error) {
+	n.mu.Lock()
+	n.sent = append(n.sent, req)
+	n.mu.Unlock()
+	return n.Node.SendBlockItem(ctx, req)
+}
+
+func (n *capturingNode) received() []*pb.SendBlockItemRequest {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*pb.SendBlockItemRequest(nil), n.sent...)
+}
+
+func TestAccountTransactionBinary(t *testing.T) {
+	sender, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	receiver, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	tx, err := construct.Transfer(1, sender, v2.SequenceNumber{Value: 1}, v2.TransactionTime{Value: 1 << 40}, receiver, v2.Amount{Value: 300}).
+		Sign(v2.NewWalletAccount(sender, *keyPair))
+	require.NoError(t, err)
+
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	// version, tag, one credential with one key, then the header and payload.
+	signature := tx.Signature.Signatures[0].Signatures[0].Value
+	payload := tx.Payload.Payload.Encode().Value
+	expected := []byte{0, 0, 1, 0, 1, 0}
+	expected = binary.BigEndian.AppendUint16(expected, uint16(len(signature)))
+	expected = append(expected, signature...)
+	expected = append(expected, sender.Value[:]...)
+	expected = binary.BigEndian.AppendUint64(expected, 1)
+	expected = binary.BigEndian.AppendUint64(expected, tx.Header.EnergyAmount.Value)
+	expected = binary.BigEndian.AppendUint32(expected, uint32(len(payload)))
+	expected = binary.BigEndian.AppendUint64(expected, 1<<40)
+	expected = append(expected, payload...)
+	require.Equal(t, expected, data)
+
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	require.Equal(t, sha256.Sum256(expected[1:]), hash.Value)
+
+	var parsed v2.AccountTransaction
+	require.NoError(t, parsed.UnmarshalBinary(data))
+	require.Equal(t, sender, *parsed.Header.Sender)
+	require.Equal(t, uint32(len(payload)), parsed.Header.PayloadSize.Value)
+	decoded, err := parsed.Payload.Payload.Encode().Decode()
+	require.NoError(t, err)
+	require.Equal(t, uint64(300), decoded.Payload.(v2.Transfer).Payload.Amount.Value)
+	parsedHash, err := parsed.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, parsedHash)
+
+	require.Error(t, parsed.UnmarshalBinary(append(data, 0)))
+	require.Error(t, parsed.UnmarshalBinary(data[:len(data)-1]))
+	require.Error(t, parsed.UnmarshalBinary(append([]byte{1}, data[1:]...)))
+}
+
+func TestSendBlockItemBinary(t *testing.T) {
+	node := &capturingNode{Node: testnode.New()}
+	defer node.Close()
+	sender, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	receiver, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(sender, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	_, err = node.AddAccount(receiver, v2.Amount{})
+	require.NoError(t, err)
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	tx, err := construct.Transfer(1, sender, v2.SequenceNumber{Value: 1}, v2.TransactionTime{Value: 1 << 40}, receiver, v2.Amount{Value: 300}).
+		Sign(v2.NewWalletAccount(sender, *keyPair))
+	require.NoError(t, err)
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	hash, err := client.SendBlockItemBinary(ctx, data)
+	require.NoError(t, err)
+	expectedHash, err := tx.Hash()
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+
+	// the node receives the account transaction with its signature, header and raw payload.
+	expected := &pb.SendBlockItemRequest{BlockItem: &pb.SendBlockItemRequest_AccountTransaction{
+		AccountTransaction: &pb.AccountTransaction{
+			Signature: &pb.AccountTransactionSignature{Signatures: map[uint32]*pb.AccountSignatureMap{
+				0: {Signatures: map[uint32]*pb.Signature{0: {Value: tx.Signature.Signatures[0].Signatures[0].Value}}},
+			}},
+			Header: &pb.AccountTransactionHeader{
+				Sender:         &pb.AccountAddress{Value: sender.Value[:]},
+				SequenceNumber: &pb.SequenceNumber{Value: 1},
+				EnergyAmount:   &pb.Energy{Value: tx.Header.EnergyAmount.Value},
+				Expiry:         &pb.TransactionTime{Value: 1 << 40},
+			},
+			Payload: &pb.AccountTransactionPayload{Payload: &pb.AccountTransactionPayload_RawPayload{
+				RawPayload: tx.Payload.Payload.Encode().Value,
+			}},
+		},
+	}}
+	received := node.received()
+	require.Len(t, received, 1)
+	require.True(t, proto.Equal(expected, received[0]), "got %v", received[0])
+
+	node.BakeBlock()
+	balance, ok := node.Balance(receiver)
+	require.True(t, ok)
+	require.Equal(t, v2.Amount{Value: 300}, balance)
+
+	_, err = client.SendBlockItemBinary(ctx, data[:len(data)-1])
+	require.Error(t, err)
+	require.Len(t, node.received(), 1)
+}
+
+func TestBlockItemBinary(t *testing.T) {
+	items := []v2.BlockItem{
+		{BlockItem: &v2.CredentialDeployment{
+			MessageExpiry: &v2.TransactionTime{Value: 100},
+			Payload:       &v2.RawPayload{Value: []byte{1, 2, 3}},
+		}},
+		{BlockItem: &v2.UpdateInstruction{
+			Signatures: &v2.SignatureMap{Signatures: map[uint32]*v2.Signature{
+				7: {Value: []byte{7, 7}},
+				2: {Value: []byte{2}},
+			}},
+			Header: &v2.UpdateInstructionHeader{
+				SequenceNumber: &v2.UpdateSequenceNumber{Value: 4},
+				EffectiveTime:  &v2.TransactionTime{Value: 5},
+				Timeout:        &v2.TransactionTime{Value: 6},
+			},
+			Payload: &v2.UpdateInstructionPayload{Payload: &v2.RawPayload{Value: []byte{9, 9, 9}}},
+		}},
+	}
+	for _, item := range items {
+		data, err := item.MarshalBinary()
+		require.NoError(t, err)
+		hash, err := item.ComputeHash()
+		require.NoError(t, err)
+
+		var parsed v2.BlockItem
+		require.NoError(t, parsed.UnmarshalBinary(data))
+		require.Equal(t, item.BlockItem, parsed.BlockItem)
+		require.Equal(t, hash, parsed.Hash)
+
+		req, err := parsed.SendBlockItemRequest()
+		require.NoError(t, err)
+		reqHash, err := v2.ComputeBlockItemHash(req)
+		require.NoError(t, err)
+		require.Equal(t, hash, reqHash)
+	}
+
+	data, err := items[1].MarshalBinary()
+	require.NoError(t, err)
+	// update instruction header, payload, and the signatures ordered by key index.
+	expected := []byte{0, 2}
+	for _, v := range []uint64{4, 5, 6} {
+		expected = binary.BigEndian.AppendUint64(expected, v)
+	}
+	expected = append(expected, 0, 0, 0, 3, 9, 9, 9, 0, 2, 0, 2, 0, 1, 2, 0, 7, 0, 2, 7, 7)
+	require.Equal(t, expected, data)
+
+	// block items with an incomplete header are rejected instead of dereferencing it.
+	for _, item := range []v2.BlockItem{
+		{BlockItem: &v2.AccountTransaction{
+			Signature: &v2.AccountTransactionSignature{},
+			Header:    &v2.AccountTransactionHeader{SequenceNumber: &v2.SequenceNumber{Value: 1}},
+			Payload:   &v2.AccountTransactionPayload{Payload: &v2.RawPayload{Value: []byte{3}}},
+		}},
+		{BlockItem: &v2.UpdateInstruction{Payload: items[1].BlockItem.(*v2.UpdateInstruction).Payload}},
+	} {
+		_, err := item.SendBlockItemRequest()
+		require.Error(t, err)
+	}
+}
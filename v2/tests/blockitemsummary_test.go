@@ -0,0 +1,72 @@
+package tests_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+)
+
+func TestBlockItemSummary(t *testing.T) {
+	sender, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	receiver, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	contract := v2.ContractAddress{Index: 10, Subindex: 0}
+	otherContract := v2.ContractAddress{Index: 11, Subindex: 0}
+
+	t.Run("account transfer", func(t *testing.T) {
+		summary := v2.BlockItemSummary{Details: v2.AccountTransactionDetails{
+			Sender:  sender,
+			Effects: v2.AccountTransfer{Amount: v2.Amount{Value: 10}, Receiver: receiver},
+		}}
+		require.True(t, summary.IsSuccess())
+		require.Nil(t, summary.RejectReason())
+		require.Equal(t, []v2.AccountAddress{sender, receiver}, summary.AffectedAccounts())
+		require.Empty(t, summary.AffectedContracts())
+	})
+
+	t.Run("contract update", func(t *testing.T) {
+		summary := v2.BlockItemSummary{Details: v2.AccountTransactionDetails{
+			Sender: sender,
+			Effects: v2.ContractUpdateIssued{Effects: []v2.ContractTraceElement{
+				{Element: v2.ContractTraceElementInterrupted{Address: contract}},
+				{Element: v2.ContractTraceElementUpdated{Address: otherContract, Instigator: &contract}},
+				{Element: v2.ContractTraceElementResumed{Address: contract, Success: true}},
+				{Element: v2.ContractTraceElementTransferred{Sender: contract, Receiver: receiver}},
+				{Element: v2.ContractTraceElementTransferred{Sender: contract, Receiver: sender}},
+			}},
+		}}
+		require.True(t, summary.IsSuccess())
+		require.Equal(t, []v2.AccountAddress{sender, receiver}, summary.AffectedAccounts())
+		require.Equal(t, []v2.ContractAddress{contract, otherContract}, summary.AffectedContracts())
+	})
+
+	t.Run("rejected transaction", func(t *testing.T) {
+		transactionType := v2.TransactionTypeUpdate
+		summary := v2.BlockItemSummary{Details: v2.AccountTransactionDetails{
+			Sender: sender,
+			Effects: v2.TransactionRejected{
+				TransactionType: &transactionType,
+				Reason:          v2.RejectReasonRejectedReceive{RejectReason: -1, ContractAddress: contract},
+			},
+		}}
+		require.False(t, summary.IsSuccess())
+		require.Equal(t, []v2.AccountAddress{sender}, summary.AffectedAccounts())
+
+		var err error = &v2.DryRunInvokeFailedError{Reason: summary.RejectReason()}
+		var rejectedReceive v2.RejectReasonRejectedReceive
+		require.True(t, errors.As(err, &rejectedReceive))
+		require.Equal(t, int32(-1), rejectedReceive.RejectReason)
+	})
+
+	t.Run("account creation", func(t *testing.T) {
+		summary := v2.BlockItemSummary{Details: v2.AccountCreationDetails{Address: receiver}}
+		require.True(t, summary.IsSuccess())
+		require.Equal(t, []v2.AccountAddress{receiver}, summary.AffectedAccounts())
+		require.Nil(t, summary.AffectedContracts())
+	})
+}
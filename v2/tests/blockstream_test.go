@@ -0,0 +1,166 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+func TestGetFinalizedBlocksResilient(t *testing.T) {
+	chain := &fakeChain{connections: []fakeConnection{
+		{lastFinal: 4, announced: []uint64{5, 9}, fail: true},
+		{lastFinal: 12, announced: []uint64{11, 12, 13}},
+	}}
+	client := &v2.Client{GrpcClient: chain}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var reconnects int
+	stream := client.GetFinalizedBlocksResilient(ctx, v2.BlockStreamConfig{
+		MinBackoff: time.Millisecond,
+		OnError:    func(error) { reconnects++ },
+	})
+
+	var heights []uint64
+	for len(heights) < 9 {
+		block, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, fakeBlockHash(block.Height.Value), block.Hash.Value[:])
+		heights = append(heights, block.Height.Value)
+	}
+	require.Equal(t, []uint64{5, 6, 7, 8, 9, 10, 11, 12, 13}, heights)
+	require.Equal(t, 1, reconnects)
+
+	cancel()
+	_, err := stream.Recv()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// fakeBlockTree serves arrived blocks, where the blocks at each height are given by blocks. Each connection to the
+// stream of arrived blocks sees the next entry of connections.
+type fakeBlockTree struct {
+	pb.QueriesClient
+
+	mu          sync.Mutex
+	blocks      map[uint64][][]byte
+	connections []fakeArrivals
+	current     fakeArrivals
+}
+
+type fakeArrivals struct {
+	// height of the best block when connecting.
+	best uint64
+	// blocks announced on the stream before it fails. If fail is false the stream blocks after them instead.
+	announced []*pb.ArrivedBlockInfo
+	fail      bool
+}
+
+// fakeSiblingHash the hash of the i-th block at the given height.
+func fakeSiblingHash(height uint64, i byte) []byte {
+	hash := fakeBlockHash(height)
+	hash[0] = i
+	return hash
+}
+
+func fakeArrived(height uint64, i byte) *pb.ArrivedBlockInfo {
+	return &pb.ArrivedBlockInfo{
+		Hash:   &pb.BlockHash{Value: fakeSiblingHash(height, i)},
+		Height: &pb.AbsoluteBlockHeight{Value: height},
+	}
+}
+
+func (f *fakeBlockTree) GetBlocks(ctx context.Context, _ *pb.Empty, _ ...grpc.CallOption) (pb.Queries_GetBlocksClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = f.connections[0]
+	if len(f.connections) > 1 {
+		f.connections = f.connections[1:]
+	}
+	return &fakeArrivedStream{ctx: ctx, conn: f.current}, nil
+}
+
+func (f *fakeBlockTree) GetConsensusInfo(context.Context, *pb.Empty, ...grpc.CallOption) (*pb.ConsensusInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &pb.ConsensusInfo{BestBlockHeight: &pb.AbsoluteBlockHeight{Value: f.current.best}}, nil
+}
+
+func (f *fakeBlockTree) GetBlocksAtHeight(_ context.Context, req *pb.BlocksAtHeightRequest, _ ...grpc.CallOption) (*pb.BlocksAtHeightResponse, error) {
+	var res pb.BlocksAtHeightResponse
+	for _, hash := range f.blocks[req.GetAbsolute().GetHeight().GetValue()] {
+		res.Blocks = append(res.Blocks, &pb.BlockHash{Value: hash})
+	}
+	return &res, nil
+}
+
+type fakeArrivedStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	conn fakeArrivals
+}
+
+func (s *fakeArrivedStream) Recv() (*pb.ArrivedBlockInfo, error) {
+	if len(s.conn.announced) > 0 {
+		block := s.conn.announced[0]
+		s.conn.announced = s.conn.announced[1:]
+		return block, nil
+	}
+	if s.conn.fail {
+		return nil, errors.New("connection reset")
+	}
+	<-s.ctx.Done()
+	return nil, s.ctx.Err()
+}
+
+func TestGetBlocksResilient(t *testing.T) {
+	tree := &fakeBlockTree{
+		blocks: map[uint64][][]byte{
+			// the second block at height 5 arrived before the stream started and is not emitted.
+			5: {fakeSiblingHash(5, 0), fakeSiblingHash(5, 1)},
+			// the third block at height 6 arrived during the outage.
+			6: {fakeSiblingHash(6, 0), fakeSiblingHash(6, 1), fakeSiblingHash(6, 2)},
+			7: {fakeSiblingHash(7, 0)},
+		},
+		connections: []fakeArrivals{
+			{announced: []*pb.ArrivedBlockInfo{fakeArrived(5, 0), fakeArrived(6, 0), fakeArrived(6, 1)}, fail: true},
+			// the first block at height 7 is both backfilled and announced after reconnecting.
+			{best: 7, announced: []*pb.ArrivedBlockInfo{fakeArrived(7, 0), fakeArrived(8, 0)}},
+		},
+	}
+	client := &v2.Client{GrpcClient: tree}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var reconnects int
+	stream := client.GetBlocksResilient(ctx, v2.BlockStreamConfig{
+		MinBackoff: time.Millisecond,
+		OnError:    func(error) { reconnects++ },
+	})
+
+	var hashes [][]byte
+	for len(hashes) < 6 {
+		block, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, uint64(block.Hash.Value[1]), block.Height.Value)
+		hashes = append(hashes, block.Hash.Value[:])
+	}
+	require.Equal(t, [][]byte{
+		fakeSiblingHash(5, 0), fakeSiblingHash(6, 0), fakeSiblingHash(6, 1),
+		fakeSiblingHash(6, 2), fakeSiblingHash(7, 0), fakeSiblingHash(8, 0),
+	}, hashes)
+	require.Equal(t, 1, reconnects)
+
+	cancel()
+	_, err := stream.Recv()
+	require.ErrorIs(t, err, context.Canceled)
+}
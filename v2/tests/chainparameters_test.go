@@ -0,0 +1,128 @@
+package tests_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+func TestGetBlockChainParameters(t *testing.T) {
+	pool := &pb.PoolParametersCpv1{
+		MinimumEquityCapital: &pb.Amount{Value: 14000},
+		LeverageBound:        &pb.LeverageFactor{Value: &pb.Ratio{Numerator: 3, Denominator: 1}},
+	}
+	cooldown := &pb.CooldownParametersCpv1{
+		PoolOwnerCooldown: &pb.DurationSeconds{Value: 3600},
+		DelegatorCooldown: &pb.DurationSeconds{Value: 60},
+	}
+	consensus := &pb.ConsensusParametersV1{
+		TimeoutParameters: &pb.TimeoutParameters{
+			TimeoutBase:     &pb.Duration{Value: 10000},
+			TimeoutIncrease: &pb.Ratio{Numerator: 6, Denominator: 5},
+			TimeoutDecrease: &pb.Ratio{Numerator: 3, Denominator: 4},
+		},
+		MinBlockTime:     &pb.Duration{Value: 2000},
+		BlockEnergyLimit: &pb.Energy{Value: 3_000_000},
+	}
+	expectedConsensus := v2.ConsensusParameters{
+		TimeoutBase:      10 * time.Second,
+		TimeoutIncrease:  big.NewRat(6, 5),
+		TimeoutDecrease:  big.NewRat(3, 4),
+		MinBlockTime:     2 * time.Second,
+		BlockEnergyLimit: v2.Energy{Value: 3_000_000},
+	}
+	expectedCooldown := v2.CooldownParameters{PoolOwnerCooldown: time.Hour, DelegatorCooldown: time.Minute}
+
+	getChainParameters := func(params *pb.ChainParameters) (v2.ChainParameters, error) {
+		client := &v2.Client{GrpcClient: &fakeChainParameters{params: params}}
+		return client.GetBlockChainParameters(context.Background(), v2.BlockHashInputLastFinal{})
+	}
+
+	t.Run("v0", func(t *testing.T) {
+		params, err := getChainParameters(&pb.ChainParameters{Parameters: &pb.ChainParameters_V0{V0: &pb.ChainParametersV0{
+			EuroPerEnergy:             exchangeRate(1, 50000),
+			MicroCcdPerEuro:           exchangeRate(100, 1),
+			BakerCooldownEpochs:       &pb.Epoch{Value: 166},
+			MinimumThresholdForBaking: &pb.Amount{Value: 15000},
+		}}})
+		require.NoError(t, err)
+		require.IsType(t, v2.ChainParametersV0{}, params)
+		require.Equal(t, big.NewRat(1, 50000), params.EuroPerEnergy())
+		require.Equal(t, big.NewRat(100, 1), params.MicroCcdPerEuro())
+		require.Equal(t, v2.Amount{Value: 15000}, params.MinimumEquityCapital())
+		require.Equal(t, v2.CooldownParameters{BakerCooldownEpochs: v2.Epoch{Value: 166}}, params.CooldownParameters())
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		params, err := getChainParameters(&pb.ChainParameters{Parameters: &pb.ChainParameters_V1{V1: &pb.ChainParametersV1{
+			EuroPerEnergy:      exchangeRate(1, 50000),
+			MicroCcdPerEuro:    exchangeRate(100, 1),
+			CooldownParameters: cooldown,
+			PoolParameters:     pool,
+		}}})
+		require.NoError(t, err)
+		v1, ok := params.(v2.ChainParametersV1)
+		require.True(t, ok)
+		require.Equal(t, expectedCooldown, params.CooldownParameters())
+		require.Equal(t, v2.Amount{Value: 14000}, params.MinimumEquityCapital())
+		require.Equal(t, big.NewRat(3, 1), v1.PoolParameters.LeverageBound)
+	})
+
+	t.Run("v2", func(t *testing.T) {
+		params, err := getChainParameters(&pb.ChainParameters{Parameters: &pb.ChainParameters_V2{V2: &pb.ChainParametersV2{
+			EuroPerEnergy:       exchangeRate(1, 50000),
+			MicroCcdPerEuro:     exchangeRate(100, 1),
+			CooldownParameters:  cooldown,
+			PoolParameters:      pool,
+			ConsensusParameters: consensus,
+		}}})
+		require.NoError(t, err)
+		v2Params, ok := params.(v2.ChainParametersV2)
+		require.True(t, ok)
+		require.Equal(t, expectedCooldown, params.CooldownParameters())
+		require.Equal(t, expectedConsensus, v2Params.ConsensusParameters)
+	})
+
+	t.Run("v3", func(t *testing.T) {
+		params, err := getChainParameters(&pb.ChainParameters{Parameters: &pb.ChainParameters_V3{V3: &pb.ChainParametersV3{
+			EuroPerEnergy:            exchangeRate(1, 50000),
+			MicroCcdPerEuro:          exchangeRate(100, 1),
+			CooldownParameters:       cooldown,
+			PoolParameters:           pool,
+			ConsensusParameters:      consensus,
+			ValidatorScoreParameters: &pb.ValidatorScoreParameters{MaximumMissedRounds: 5},
+		}}})
+		require.NoError(t, err)
+		v3, ok := params.(v2.ChainParametersV3)
+		require.True(t, ok)
+		require.Equal(t, expectedCooldown, params.CooldownParameters())
+		require.Equal(t, expectedConsensus, v3.ConsensusParameters)
+		require.Equal(t, uint64(5), v3.ValidatorScoreParameters.MaximumMissedRounds)
+	})
+
+	t.Run("zero denominator", func(t *testing.T) {
+		_, err := getChainParameters(&pb.ChainParameters{Parameters: &pb.ChainParameters_V0{V0: &pb.ChainParametersV0{
+			EuroPerEnergy:   exchangeRate(1, 0),
+			MicroCcdPerEuro: exchangeRate(100, 1),
+		}}})
+		require.ErrorContains(t, err, "denominator is zero")
+
+		// the leverage bound of the pool parameters.
+		_, err = getChainParameters(&pb.ChainParameters{Parameters: &pb.ChainParameters_V1{V1: &pb.ChainParametersV1{
+			EuroPerEnergy:   exchangeRate(1, 50000),
+			MicroCcdPerEuro: exchangeRate(100, 1),
+		}}})
+		require.ErrorContains(t, err, "denominator is zero")
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := getChainParameters(&pb.ChainParameters{})
+		require.ErrorContains(t, err, v2.ErrUnknownVariant.Error())
+	})
+}
@@ -0,0 +1,113 @@
+package tests_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/cis0"
+	"github.com/Concordium/concordium-go-sdk/v2/cis2"
+)
+
+func TestCis2(t *testing.T) {
+	account, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	contract := v2.ContractAddress{Index: 5, Subindex: 0}
+	tokenId := cis2.TokenId{Value: []byte{0xab}}
+
+	t.Run("transfer params", func(t *testing.T) {
+		params, err := cis2.SerializeTransferParams([]cis2.Transfer{{
+			TokenId: tokenId,
+			Amount:  cis2.NewTokenAmount(300),
+			From:    cis2.Address{Address: cis2.AddressAccount{Address: account}},
+			To:      cis2.Receiver{Receiver: cis2.ReceiverContract{Address: contract, EntrypointName: "hook"}},
+			Data:    cis2.AdditionalData{Value: []byte{7}},
+		}})
+		require.NoError(t, err)
+
+		expected := []byte{1, 0, 1, 0xab, 0xac, 0x02, 0}
+		expected = append(expected, account.Value[:]...)
+		expected = append(expected, 1, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4, 0)
+		expected = append(expected, "hook"...)
+		expected = append(expected, 1, 0, 7)
+		require.Equal(t, expected, params)
+
+		_, err = cis2.SerializeTransferParams([]cis2.Transfer{{
+			TokenId: tokenId,
+			Amount:  cis2.TokenAmount{Value: new(big.Int).Lsh(big.NewInt(1), 256)},
+			From:    cis2.Address{Address: cis2.AddressAccount{Address: account}},
+			To:      cis2.Receiver{Receiver: cis2.ReceiverAccount{Address: account}},
+		}})
+		require.ErrorIs(t, err, cis2.ErrInvalidTokenAmount)
+	})
+
+	t.Run("balance of response", func(t *testing.T) {
+		amounts, err := cis2.DeserializeBalanceOfResponse([]byte{2, 0, 0xac, 0x02, 0})
+		require.NoError(t, err)
+		require.Len(t, amounts, 2)
+		require.Equal(t, int64(300), amounts[0].Value.Int64())
+		require.Equal(t, int64(0), amounts[1].Value.Int64())
+
+		_, err = cis2.DeserializeBalanceOfResponse([]byte{1, 0, 0x80})
+		require.ErrorIs(t, err, cis2.ErrInvalidData)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		transfer := []byte{255, 1, 0xab, 10, 0}
+		transfer = append(transfer, account.Value[:]...)
+		transfer = append(transfer, 1, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+		metadata := []byte{251, 1, 0xab, 3, 0, 'u', 'r', 'l', 0}
+		custom := []byte{1, 2, 3}
+
+		summary := v2.BlockItemSummary{Details: v2.AccountTransactionDetails{
+			Sender: account,
+			Effects: v2.ContractUpdateIssued{Effects: []v2.ContractTraceElement{
+				{Element: v2.ContractTraceElementUpdated{Address: contract, Events: []v2.ContractEvent{
+					{Value: transfer}, {Value: custom}, {Value: metadata},
+				}}},
+				{Element: v2.ContractTraceElementUpdated{Address: v2.ContractAddress{Index: 6}, Events: []v2.ContractEvent{
+					{Value: metadata},
+				}}},
+			}},
+		}}
+		events, err := cis2.EventsFromSummary(&summary, contract)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		require.Equal(t, cis2.EventTransfer{
+			TokenId: tokenId,
+			Amount:  cis2.TokenAmount{Value: big.NewInt(10)},
+			From:    cis2.Address{Address: cis2.AddressAccount{Address: account}},
+			To:      cis2.Address{Address: cis2.AddressContract{Address: contract}},
+		}, events[0].Event)
+		require.Equal(t, cis2.EventTokenMetadata{
+			TokenId:     tokenId,
+			MetadataUrl: cis2.MetadataUrl{Url: "url"},
+		}, events[1].Event)
+
+		_, err = cis2.ParseEvent(v2.ContractEvent{Value: custom})
+		require.ErrorIs(t, err, cis2.ErrNotCis2Event)
+		_, err = cis2.ParseEvent(v2.ContractEvent{Value: append(metadata, 0)})
+		require.ErrorIs(t, err, cis2.ErrInvalidData)
+	})
+
+	t.Run("supports", func(t *testing.T) {
+		params, err := cis0.SerializeSupportsParameter([]cis0.StandardIdentifier{cis0.CIS2})
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 0, 5, 'C', 'I', 'S', '-', '2'}, params)
+
+		results, err := cis0.DeserializeSupportsResponse([]byte{
+			3, 0, 0, 1, 2, 1, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []cis0.SupportResult{
+			{Result: cis0.SupportResultNoSupport{}},
+			{Result: cis0.SupportResultSupport{}},
+			{Result: cis0.SupportResultSupportBy{Contracts: []v2.ContractAddress{contract}}},
+		}, results)
+		require.False(t, results[0].IsSupported())
+		require.True(t, results[2].IsSupported())
+	})
+}
@@ -0,0 +1,78 @@
+package tests_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/cis3"
+)
+
+func TestCis3(t *testing.T) {
+	address, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	account := v2.NewWalletAccount(address, *keyPair)
+
+	message := cis3.PermitMessage{
+		ContractAddress: v2.ContractAddress{Index: 7, Subindex: 0},
+		Nonce:           2,
+		Timestamp:       v2.Timestamp{Value: 1000},
+		EntryPoint:      "transfer",
+		Payload:         []byte{9, 9},
+	}
+
+	t.Run("message", func(t *testing.T) {
+		serialized, err := message.Serialize()
+		require.NoError(t, err)
+		expected := []byte{7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0xe8, 3, 0, 0, 0, 0, 0, 0, 8, 0}
+		expected = append(expected, "transfer"...)
+		expected = append(expected, 2, 0, 9, 9)
+		require.Equal(t, expected, serialized)
+
+		hash, err := message.Hash(address)
+		require.NoError(t, err)
+		require.Equal(t, sha256.Sum256(append(append(append([]byte{}, address.Value[:]...), make([]byte, 8)...), serialized...)), hash)
+	})
+
+	t.Run("sign and permit", func(t *testing.T) {
+		param, err := cis3.SignPermitMessage(account, message)
+		require.NoError(t, err)
+		hash, err := message.Hash(address)
+		require.NoError(t, err)
+		signature := param.Signature.Signatures[0].Signatures[0].Value
+		require.True(t, ed25519.Verify(keyPair.Public(), hash[:], signature))
+
+		serialized, err := param.Serialize()
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 0, 1, 0, 0}, serialized[:5])
+		require.Equal(t, signature, serialized[5:69])
+		require.Equal(t, address.Value[:], serialized[69:101])
+
+		contract := cis3.New(nil, v2.ContractAddress{Index: 7}, "token")
+		tx, err := contract.SponsorPermit(account, address, v2.SequenceNumber{Value: 1}, v2.TransactionTime{Value: 100},
+			v2.Energy{Value: 5000}, param)
+		require.NoError(t, err)
+		update, ok := tx.Payload.Payload.(*v2.UpdateContract)
+		require.True(t, ok)
+		require.Equal(t, "token.permit", update.Payload.ReceiveName.Value)
+		require.Equal(t, serialized, update.Payload.Parameter.Value)
+	})
+
+	t.Run("nonce event", func(t *testing.T) {
+		value := append([]byte{250, 5, 0, 0, 0, 0, 0, 0, 0}, address.Value[:]...)
+		event, ok, err := cis3.ParseNonceEvent(v2.ContractEvent{Value: value})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, cis3.NonceEvent{Nonce: 5, Account: address}, event)
+
+		_, ok, err = cis3.ParseNonceEvent(v2.ContractEvent{Value: []byte{255}})
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,88 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+type fakeConsensusStatus struct {
+	pb.QueriesClient
+	status *pb.ConsensusDetailedStatus
+}
+
+func (f *fakeConsensusStatus) GetConsensusDetailedStatus(context.Context, *pb.ConsensusDetailedStatusQuery, ...grpc.CallOption) (*pb.ConsensusDetailedStatus, error) {
+	return f.status, nil
+}
+
+func TestConsensusDetailedStatus(t *testing.T) {
+	hash := func(b byte) *pb.BlockHash { return &pb.BlockHash{Value: bytes.Repeat([]byte{b}, 32)} }
+	qc := func(block byte, round, epoch uint64, signatories ...uint32) *pb.RawQuorumCertificate {
+		res := &pb.RawQuorumCertificate{BlockHash: hash(block), Round: &pb.Round{Value: round}, Epoch: &pb.Epoch{Value: epoch}}
+		for _, s := range signatories {
+			res.Signatories = append(res.Signatories, &pb.FinalizerIndex{Value: s})
+		}
+		return res
+	}
+	committee := &pb.BakersAndFinalizers{
+		Bakers: []*pb.FullBakerInfo{
+			{BakerIdentity: &pb.BakerId{Value: 3}, Stake: &pb.Amount{Value: 30}},
+			{BakerIdentity: &pb.BakerId{Value: 5}, Stake: &pb.Amount{Value: 50}},
+			{BakerIdentity: &pb.BakerId{Value: 7}, Stake: &pb.Amount{Value: 70}},
+		},
+		Finalizers: []*pb.BakerId{{Value: 3}, {Value: 5}, {Value: 7}},
+	}
+	client := &v2.Client{GrpcClient: &fakeConsensusStatus{status: &pb.ConsensusDetailedStatus{
+		GenesisBlock:          hash(0),
+		LastFinalizedBlock:    hash(8),
+		PersistentRoundStatus: &pb.PersistentRoundStatus{LastBakedRound: &pb.Round{Value: 9}},
+		RoundStatus: &pb.RoundStatus{
+			CurrentRound:          &pb.Round{Value: 12},
+			CurrentEpoch:          &pb.Epoch{Value: 2},
+			HighestCertifiedBlock: qc(10, 10, 2, 0, 2),
+			CurrentTimeout:        &pb.Duration{Value: 20000},
+		},
+		BlockTable:              &pb.BlockTableSummary{LiveBlocks: []*pb.BlockHash{hash(9), hash(10)}},
+		LatestFinalizationEntry: &pb.RawFinalizationEntry{FinalizedQc: qc(8, 8, 2, 0, 1, 2), SuccessorQc: qc(9, 9, 2, 0, 1, 2)},
+		EpochBakers:             &pb.EpochBakers{PreviousEpochBakers: committee},
+		TimeoutMessages: &pb.TimeoutMessages{
+			FirstEpoch: &pb.Epoch{Value: 2},
+			FirstEpochTimeouts: []*pb.TimeoutMessage{
+				{Finalizer: &pb.FinalizerIndex{Value: 1}, Round: &pb.Round{Value: 12}, Epoch: &pb.Epoch{Value: 2}, QuorumCertificate: qc(10, 10, 2)},
+				{Finalizer: &pb.FinalizerIndex{Value: 2}, Round: &pb.Round{Value: 11}, Epoch: &pb.Epoch{Value: 2}, QuorumCertificate: qc(10, 10, 2)},
+			},
+		},
+	}}}
+
+	status, err := client.GetConsensusDetailedStatus(context.Background(), nil)
+	require.NoError(t, err)
+
+	progress := status.Progress()
+	require.Equal(t, uint64(12), progress.CurrentRound.Value)
+	require.Equal(t, uint64(2), progress.RoundsSinceCertified)
+	require.Equal(t, uint64(4), progress.RoundsSinceFinalized)
+	require.Equal(t, 20*time.Second, progress.CurrentTimeout)
+	require.Equal(t, 1, progress.Timeouts)
+	require.Equal(t, 2, progress.LiveBlocks)
+
+	signed, missing, ok := status.QuorumSigners(status.RoundStatus.HighestCertifiedBlock)
+	require.True(t, ok)
+	require.Len(t, signed, 2)
+	require.Equal(t, []v2.Finalizer{{Index: v2.FinalizerIndex{Value: 1}, BakerId: v2.BakerId{Value: 5}, Stake: v2.Amount{Value: 50}}}, missing)
+
+	signed, missing, ok = status.TimeoutSigners()
+	require.True(t, ok)
+	require.Len(t, signed, 1)
+	require.Equal(t, uint64(5), signed[0].BakerId.Value)
+	require.Len(t, missing, 2)
+
+	_, ok = status.BakersForEpoch(v2.Epoch{Value: 5})
+	require.False(t, ok)
+}
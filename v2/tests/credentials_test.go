@@ -0,0 +1,75 @@
+package tests_test
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// metadataNode records the metadata of the last request.
+type metadataNode struct {
+	pb.UnimplementedQueriesServer
+	md metadata.MD
+}
+
+func (n *metadataNode) GetConsensusInfo(ctx context.Context, _ *pb.Empty) (*pb.ConsensusInfo, error) {
+	n.md, _ = metadata.FromIncomingContext(ctx)
+	return &pb.ConsensusInfo{}, nil
+}
+
+func TestClientMetadataAndCredentials(t *testing.T) {
+	node := &metadataNode{}
+	address := startQueriesServer(t, node)
+
+	var refreshes, intercepted int
+	credentials := v2.NewRefreshingCredentials(func(context.Context) (map[string]string, time.Time, error) {
+		refreshes++
+		return map[string]string{"authorization": "Bearer token"}, time.Now().Add(time.Hour), nil
+	}, true)
+	client, err := v2.NewClient(v2.Config{
+		NodeAddress:       address,
+		PerRPCCredentials: credentials,
+		Metadata:          map[string]string{"x-api-key": "secret"},
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{
+			func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				intercepted++
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = client.GetConsensusInfo(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"secret"}, node.md.Get("x-api-key"))
+	require.Equal(t, []string{"Bearer token"}, node.md.Get("authorization"))
+	require.Equal(t, 1, refreshes)
+	require.Equal(t, 2, intercepted)
+
+	credentials.Invalidate()
+	_, err = client.GetConsensusInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, refreshes)
+}
+
+func TestClientCertificatesConflict(t *testing.T) {
+	tlsCredentials, err := v2.HostTLSRoots()
+	require.NoError(t, err)
+	_, err = v2.NewClient(v2.Config{
+		NodeAddress:        "localhost:20000",
+		TlsCredentials:     tlsCredentials,
+		ClientCertificates: []tls.Certificate{{}},
+	})
+	require.Error(t, err)
+}
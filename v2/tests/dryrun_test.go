@@ -0,0 +1,188 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// fakeDryRun a node serving dry-run sessions. Each request of a session is answered with the next of the responses.
+// Once the responses are used up, the session ends with err.
+type fakeDryRun struct {
+	pb.UnimplementedQueriesServer
+
+	quota     string
+	responses []*pb.DryRunResponse
+	err       error
+
+	mu       sync.Mutex
+	requests []*pb.DryRunRequest
+}
+
+func (f *fakeDryRun) DryRun(stream grpc.BidiStreamingServer[pb.DryRunRequest, pb.DryRunResponse]) error {
+	if err := stream.SendHeader(metadata.Pairs("quota", f.quota, "timeout", "30000")); err != nil {
+		return err
+	}
+	for i := 0; ; i++ {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		f.mu.Lock()
+		f.requests = append(f.requests, req)
+		f.mu.Unlock()
+		if i >= len(f.responses) {
+			return f.err
+		}
+		if err = stream.Send(f.responses[i]); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *fakeDryRun) received() []*pb.DryRunRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*pb.DryRunRequest(nil), f.requests...)
+}
+
+// dryRunSuccess a successful dry-run response leaving the given quota.
+func dryRunSuccess(quotaRemaining uint64, res *pb.DryRunSuccessResponse) *pb.DryRunResponse {
+	return &pb.DryRunResponse{
+		Response:       &pb.DryRunResponse_Success{Success: res},
+		QuotaRemaining: &pb.Energy{Value: quotaRemaining},
+	}
+}
+
+func newDryRunClient(t *testing.T, node *fakeDryRun) *v2.Client {
+	client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestDryRunSession(t *testing.T) {
+	blockHash := bytes.Repeat([]byte{7}, 32)
+	owner := bytes.Repeat([]byte{1}, 32)
+	contract := v2.ContractAddress{Index: 3, Subindex: 0}
+
+	t.Run("queries", func(t *testing.T) {
+		node := &fakeDryRun{quota: "100000", responses: []*pb.DryRunResponse{
+			dryRunSuccess(98000, &pb.DryRunSuccessResponse{Response: &pb.DryRunSuccessResponse_BlockStateLoaded_{
+				BlockStateLoaded: &pb.DryRunSuccessResponse_BlockStateLoaded{
+					CurrentTimestamp: &pb.Timestamp{Value: 1000},
+					BlockHash:        &pb.BlockHash{Value: blockHash},
+					ProtocolVersion:  pb.ProtocolVersion_PROTOCOL_VERSION_6,
+				},
+			}}),
+			dryRunSuccess(97800, &pb.DryRunSuccessResponse{Response: &pb.DryRunSuccessResponse_InstanceInfo{
+				InstanceInfo: &pb.InstanceInfo{Version: &pb.InstanceInfo_V1_{V1: &pb.InstanceInfo_V1{
+					Owner:        &pb.AccountAddress{Value: owner},
+					Amount:       &pb.Amount{Value: 5},
+					Methods:      []*pb.ReceiveName{{Value: "token.balanceOf"}},
+					Name:         &pb.InitName{Value: "init_token"},
+					SourceModule: &pb.ModuleRef{Value: make([]byte, 32)},
+				}}},
+			}}),
+			{
+				Response: &pb.DryRunResponse_Error{Error: &pb.DryRunErrorResponse{
+					Error: &pb.DryRunErrorResponse_AccountNotFound_{AccountNotFound: &pb.DryRunErrorResponse_AccountNotFound{}},
+				}},
+				QuotaRemaining: &pb.Energy{Value: 97600},
+			},
+		}}
+		session, err := newDryRunClient(t, node).DryRun(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, v2.Energy{Value: 100000}, session.Quota())
+		require.Equal(t, uint64(30000), session.Timeout())
+
+		loaded, err := session.LoadBlockState(v2.BlockHashInputLastFinal{})
+		require.NoError(t, err)
+		require.Equal(t, blockHash, loaded.BlockHash.Value[:])
+		require.Equal(t, uint64(1000), loaded.CurrentTimestamp.Value)
+		require.Equal(t, v2.Energy{Value: 98000}, session.QuotaRemaining())
+
+		info, err := session.GetInstanceInfo(contract)
+		require.NoError(t, err)
+		require.Equal(t, v2.ContractVersionV1, info.Version)
+		require.Equal(t, owner, info.Owner.Value[:])
+		require.Equal(t, []v2.ReceiveName{{Value: "token.balanceOf"}}, info.Methods)
+		require.Equal(t, v2.InitName{Value: "init_token"}, info.Name)
+
+		_, err = session.GetAccountInfo(v2.AccountIndex{Value: 9})
+		require.ErrorAs(t, err, &v2.DryRunAccountNotFoundError{})
+		require.Equal(t, v2.Energy{Value: 97600}, session.QuotaRemaining())
+
+		_, err = session.RunTransaction(v2.DryRunTransaction{EnergyAmount: v2.Energy{Value: 1000}})
+		require.ErrorIs(t, err, v2.ErrDryRunMissingPayload)
+		_, err = session.GetAccountInfo((*v2.AccountIndex)(nil))
+		require.ErrorContains(t, err, "unsupported account identifier")
+		require.Len(t, node.received(), 3)
+		require.NoError(t, session.Close())
+	})
+
+	t.Run("invoke without used energy", func(t *testing.T) {
+		node := &fakeDryRun{quota: "100000", responses: []*pb.DryRunResponse{
+			dryRunSuccess(99000, &pb.DryRunSuccessResponse{Response: &pb.DryRunSuccessResponse_InvokeSucceeded{
+				InvokeSucceeded: &pb.DryRunSuccessResponse_InvokeSuccess{ReturnValue: []byte{1}},
+			}}),
+			{Response: &pb.DryRunResponse_Error{Error: &pb.DryRunErrorResponse{
+				Error: &pb.DryRunErrorResponse_InvokeFailed{InvokeFailed: &pb.DryRunErrorResponse_InvokeFailure{
+					Reason: &pb.RejectReason{Reason: &pb.RejectReason_OutOfEnergy{OutOfEnergy: &pb.Empty{}}},
+				}},
+			}}},
+		}}
+		session, err := newDryRunClient(t, node).DryRun(context.Background())
+		require.NoError(t, err)
+
+		invoked, err := session.InvokeInstance(v2.DryRunInvokeInstance{Instance: contract})
+		require.NoError(t, err)
+		require.Equal(t, []byte{1}, invoked.ReturnValue)
+		require.Zero(t, invoked.UsedEnergy.Value)
+
+		_, err = session.InvokeInstance(v2.DryRunInvokeInstance{Instance: contract})
+		var failed *v2.DryRunInvokeFailedError
+		require.ErrorAs(t, err, &failed)
+		require.Zero(t, failed.UsedEnergy.Value)
+		require.NoError(t, session.Close())
+	})
+
+	for _, test := range []struct {
+		name string
+		code codes.Code
+		want error
+	}{
+		{name: "quota exceeded", code: codes.ResourceExhausted, want: v2.ErrDryRunQuotaExceeded},
+		{name: "timeout", code: codes.DeadlineExceeded, want: v2.ErrDryRunTimeout},
+		{name: "other error", code: codes.Internal},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			node := &fakeDryRun{quota: "100000", err: status.Error(test.code, "session ended")}
+			session, err := newDryRunClient(t, node).DryRun(context.Background())
+			require.NoError(t, err)
+
+			_, err = session.LoadBlockState(v2.BlockHashInputBest{})
+			require.ErrorContains(t, err, "session ended")
+			if test.want != nil {
+				require.ErrorIs(t, err, test.want)
+			} else {
+				require.Equal(t, test.code, status.Code(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/estimate"
+)
+
+// fakeEstimateNode a node answering InvokeInstance with a fixed response and dry runs like fakeDryRun.
+type fakeEstimateNode struct {
+	fakeDryRun
+
+	invoked    *pb.InvokeInstanceRequest
+	usedEnergy uint64
+}
+
+func (n *fakeEstimateNode) InvokeInstance(_ context.Context, req *pb.InvokeInstanceRequest) (*pb.InvokeInstanceResponse, error) {
+	n.invoked = req
+	return &pb.InvokeInstanceResponse{Result: &pb.InvokeInstanceResponse_Success_{Success: &pb.InvokeInstanceResponse_Success{
+		UsedEnergy: &pb.Energy{Value: n.usedEnergy},
+	}}}, nil
+}
+
+// dryRunExecuted a dry-run response to a transaction costing the given energy with the given effects.
+func dryRunExecuted(energyCost uint64, effects *pb.AccountTransactionEffects) *pb.DryRunResponse {
+	return dryRunSuccess(90000, &pb.DryRunSuccessResponse{Response: &pb.DryRunSuccessResponse_TransactionExecuted_{
+		TransactionExecuted: &pb.DryRunSuccessResponse_TransactionExecuted{
+			EnergyCost: &pb.Energy{Value: energyCost},
+			Details:    &pb.AccountTransactionDetails{Effects: effects},
+		},
+	}})
+}
+
+func TestEstimator(t *testing.T) {
+	sender, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	nonce := v2.SequenceNumber{Value: 4}
+	expiry := v2.TransactionTime{Value: 1 << 40}
+	contract := v2.ContractAddress{Index: 3}
+	update := v2.UpdateContractPayload{
+		Amount:      &v2.Amount{},
+		Address:     &contract,
+		ReceiveName: &v2.ReceiveName{Value: "token.mint"},
+		Parameter:   &v2.Parameter{Value: []byte{1, 2, 3}},
+	}
+	init := v2.InitContractPayload{
+		Amount:    &v2.Amount{},
+		ModuleRef: &v2.ModuleRef{},
+		InitName:  &v2.InitName{Value: "init_token"},
+		Parameter: &v2.Parameter{Value: []byte{4, 5}},
+	}
+	// the base cost of the dry-run transaction, which has a single signature.
+	initSize := v2.TransactionHeaderSize + uint64(v2.InitContract{Payload: &init}.Encode().Size().Value)
+	dryRunBaseCost := costs.BaseCost(initSize, 1).Value
+
+	loaded := dryRunSuccess(98000, &pb.DryRunSuccessResponse{Response: &pb.DryRunSuccessResponse_BlockStateLoaded_{
+		BlockStateLoaded: &pb.DryRunSuccessResponse_BlockStateLoaded{
+			CurrentTimestamp: &pb.Timestamp{},
+			BlockHash:        &pb.BlockHash{Value: make([]byte, 32)},
+		},
+	}})
+	initialized := &pb.AccountTransactionEffects{Effect: &pb.AccountTransactionEffects_ContractInitialized{
+		ContractInitialized: &pb.ContractInitializedEvent{},
+	}}
+	outOfEnergy := &pb.AccountTransactionEffects{Effect: &pb.AccountTransactionEffects_None_{None: &pb.AccountTransactionEffects_None{
+		RejectReason: &pb.RejectReason{Reason: &pb.RejectReason_OutOfEnergy{OutOfEnergy: &pb.Empty{}}},
+	}}}
+
+	newEstimator := func(node *fakeEstimateNode) *estimate.Estimator {
+		client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+		require.NoError(t, err)
+		t.Cleanup(func() { client.Close() })
+		return estimate.NewEstimator(client)
+	}
+
+	t.Run("update contract", func(t *testing.T) {
+		node := &fakeEstimateNode{usedEnergy: 1000}
+		estimator := newEstimator(node)
+		estimator.MarginEnergy = v2.Energy{Value: 50}
+
+		tx, err := estimator.UpdateContract(context.Background(), 2, sender, nonce, expiry, update)
+		require.NoError(t, err)
+		require.Equal(t, sender.Value[:], node.invoked.GetInvoker().GetAccount().GetValue())
+		require.Equal(t, uint64(3_000_000), node.invoked.GetEnergy().GetValue())
+
+		// 10% and 50 on top of the used energy, plus the base cost of the transaction with two signatures.
+		size := v2.TransactionHeaderSize + uint64(len(tx.Encoded.Value))
+		require.Equal(t, 1000+100+50+costs.BaseCost(size, 2).Value, tx.Header.EnergyAmount.Value)
+	})
+
+	t.Run("init contract", func(t *testing.T) {
+		node := &fakeEstimateNode{fakeDryRun: fakeDryRun{responses: []*pb.DryRunResponse{
+			loaded, dryRunExecuted(dryRunBaseCost+2000, initialized),
+		}}}
+		tx, err := newEstimator(node).InitContract(context.Background(), 1, sender, nonce, expiry, init)
+		require.NoError(t, err)
+		require.Equal(t, 2000+200+dryRunBaseCost, tx.Header.EnergyAmount.Value)
+		run := node.received()[1].GetStateOperation().GetRunTransaction()
+		require.Equal(t, uint64(3_000_000), run.GetEnergyAmount().GetValue())
+	})
+
+	t.Run("init contract rejected", func(t *testing.T) {
+		node := &fakeEstimateNode{fakeDryRun: fakeDryRun{responses: []*pb.DryRunResponse{
+			loaded, dryRunExecuted(dryRunBaseCost+2000, outOfEnergy),
+		}}}
+		_, err := newEstimator(node).InitContract(context.Background(), 1, sender, nonce, expiry, init)
+		require.ErrorAs(t, err, &v2.RejectReasonOutOfEnergy{})
+	})
+
+	t.Run("init contract below base cost", func(t *testing.T) {
+		node := &fakeEstimateNode{fakeDryRun: fakeDryRun{responses: []*pb.DryRunResponse{
+			loaded, dryRunExecuted(dryRunBaseCost-1, initialized),
+		}}}
+		_, err := newEstimator(node).InitContract(context.Background(), 1, sender, nonce, expiry, init)
+		require.ErrorIs(t, err, estimate.ErrUnexpectedOutcome)
+	})
+
+	t.Run("init contract with insufficient balance", func(t *testing.T) {
+		node := &fakeEstimateNode{fakeDryRun: fakeDryRun{responses: []*pb.DryRunResponse{
+			loaded,
+			{Response: &pb.DryRunResponse_Error{Error: &pb.DryRunErrorResponse{
+				Error: &pb.DryRunErrorResponse_BalanceInsufficient_{BalanceInsufficient: &pb.DryRunErrorResponse_BalanceInsufficient{
+					RequiredAmount:  &pb.Amount{Value: 3000},
+					AvailableAmount: &pb.Amount{Value: 1000},
+				}},
+			}}},
+			dryRunExecuted(dryRunBaseCost+2000, initialized),
+		}}}
+		tx, err := newEstimator(node).InitContract(context.Background(), 1, sender, nonce, expiry, init)
+		require.NoError(t, err)
+		require.Equal(t, 2000+200+dryRunBaseCost, tx.Header.EnergyAmount.Value)
+		run := node.received()[2].GetStateOperation().GetRunTransaction()
+		require.Equal(t, uint64(1_000_000), run.GetEnergyAmount().GetValue())
+	})
+}
@@ -0,0 +1,55 @@
+package tests_test
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// fakeChainParameters serves the given chain parameters for every block.
+type fakeChainParameters struct {
+	pb.QueriesClient
+
+	params *pb.ChainParameters
+}
+
+func (f *fakeChainParameters) GetBlockChainParameters(context.Context, *pb.BlockHashInput, ...grpc.CallOption) (*pb.ChainParameters, error) {
+	return f.params, nil
+}
+
+// exchangeRate an exchange rate of numerator/denominator.
+func exchangeRate(numerator, denominator uint64) *pb.ExchangeRate {
+	return &pb.ExchangeRate{Value: &pb.Ratio{Numerator: numerator, Denominator: denominator}}
+}
+
+func TestEstimateCost(t *testing.T) {
+	client := &v2.Client{GrpcClient: &fakeChainParameters{params: &pb.ChainParameters{
+		Parameters: &pb.ChainParameters_V0{V0: &pb.ChainParametersV0{
+			EuroPerEnergy:   exchangeRate(1, 2),
+			MicroCcdPerEuro: exchangeRate(3, 1),
+		}},
+	}}}
+	txWithEnergy := func(energy uint64) *v2.PreAccountTransaction {
+		return &v2.PreAccountTransaction{Header: &v2.AccountTransactionHeader{EnergyAmount: &v2.Energy{Value: energy}}}
+	}
+
+	cost, err := client.EstimateCost(context.Background(), txWithEnergy(5), v2.BlockHashInputLastFinal{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), cost.Energy.Value)
+	require.Equal(t, big.NewRat(15, 2), cost.ExactAmount)
+	require.Equal(t, uint64(8), cost.Amount.Value)
+
+	// 3/2 microCCD per energy overflows the amount for the maximum energy.
+	_, err = client.EstimateCost(context.Background(), txWithEnergy(math.MaxUint64), v2.BlockHashInputLastFinal{})
+	require.Error(t, err)
+
+	_, err = client.EstimateCost(context.Background(), &v2.PreAccountTransaction{}, v2.BlockHashInputLastFinal{})
+	require.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/indexer"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// fakeChain serves a chain of finalized blocks, where block i has a hash filled with byte i.
+// Each connection to the stream of finalized blocks sees the next entry of connections.
+type fakeChain struct {
+	pb.QueriesClient
+
+	mu          sync.Mutex
+	connections []fakeConnection
+	current     fakeConnection
+}
+
+type fakeConnection struct {
+	// height of the last finalized block when connecting.
+	lastFinal uint64
+	// heights announced on the stream before it fails. If fail is false the stream blocks after them instead.
+	announced []uint64
+	fail      bool
+}
+
+func fakeBlockHash(height uint64) []byte {
+	hash := make([]byte, 32)
+	for i := range hash {
+		hash[i] = byte(height)
+	}
+	return hash
+}
+
+func (f *fakeChain) GetFinalizedBlocks(ctx context.Context, _ *pb.Empty, _ ...grpc.CallOption) (pb.Queries_GetFinalizedBlocksClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = f.connections[0]
+	if len(f.connections) > 1 {
+		f.connections = f.connections[1:]
+	}
+	return &fakeFinalizedStream{ctx: ctx, conn: f.current}, nil
+}
+
+func (f *fakeChain) GetConsensusInfo(context.Context, *pb.Empty, ...grpc.CallOption) (*pb.ConsensusInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &pb.ConsensusInfo{LastFinalizedBlockHeight: &pb.AbsoluteBlockHeight{Value: f.current.lastFinal}}, nil
+}
+
+func (f *fakeChain) GetBlocksAtHeight(_ context.Context, req *pb.BlocksAtHeightRequest, _ ...grpc.CallOption) (*pb.BlocksAtHeightResponse, error) {
+	height := req.GetAbsolute().GetHeight().GetValue()
+	return &pb.BlocksAtHeightResponse{Blocks: []*pb.BlockHash{{Value: fakeBlockHash(height)}}}, nil
+}
+
+func (f *fakeChain) GetBlockInfo(_ context.Context, req *pb.BlockHashInput, _ ...grpc.CallOption) (*pb.BlockInfo, error) {
+	hash := req.GetGiven().GetValue()
+	return &pb.BlockInfo{Hash: &pb.BlockHash{Value: hash}, Height: &pb.AbsoluteBlockHeight{Value: uint64(hash[0])}, Finalized: true}, nil
+}
+
+type fakeFinalizedStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	conn fakeConnection
+}
+
+func (s *fakeFinalizedStream) Recv() (*pb.FinalizedBlockInfo, error) {
+	if len(s.conn.announced) > 0 {
+		height := s.conn.announced[0]
+		s.conn.announced = s.conn.announced[1:]
+		return &pb.FinalizedBlockInfo{
+			Hash:   &pb.BlockHash{Value: fakeBlockHash(height)},
+			Height: &pb.AbsoluteBlockHeight{Value: height},
+		}, nil
+	}
+	if s.conn.fail {
+		return nil, errors.New("connection reset")
+	}
+	<-s.ctx.Done()
+	return nil, s.ctx.Err()
+}
+
+func TestIndexer(t *testing.T) {
+	chain := &fakeChain{connections: []fakeConnection{
+		{lastFinal: 6, announced: []uint64{5, 9}, fail: true},
+		{lastFinal: 14},
+	}}
+	client := &v2.Client{GrpcClient: chain}
+
+	checkpointer := &indexer.MemoryCheckpointer{}
+	require.NoError(t, checkpointer.SaveCheckpoint(context.Background(), v2.AbsoluteBlockHeight{Value: 3}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var processed []uint64
+	var reconnects int
+	idx := indexer.New(client, indexer.BlockProcessorFunc(func(ctx context.Context, block *indexer.BlockData) error {
+		require.Equal(t, block.Height.Value, block.Info.Height.Value)
+		require.Equal(t, fakeBlockHash(block.Height.Value), block.Hash.Value[:])
+		processed = append(processed, block.Height.Value)
+		if block.Height.Value == 14 {
+			cancel()
+		}
+		return nil
+	}), indexer.Config{
+		StartHeight:  v2.AbsoluteBlockHeight{Value: 0},
+		Checkpointer: checkpointer,
+		Concurrency:  3,
+		MinBackoff:   time.Millisecond,
+		OnError:      func(error) { reconnects++ },
+	})
+
+	err := idx.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []uint64{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, processed)
+	require.Equal(t, 1, reconnects)
+
+	next, ok, err := checkpointer.LoadCheckpoint(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(15), next.Value)
+}
+
+func TestIndexerProcessorError(t *testing.T) {
+	chain := &fakeChain{connections: []fakeConnection{{lastFinal: 5}}}
+	client := &v2.Client{GrpcClient: chain}
+
+	failure := errors.New("database unavailable")
+	idx := indexer.New(client, indexer.BlockProcessorFunc(func(ctx context.Context, block *indexer.BlockData) error {
+		if block.Height.Value == 2 {
+			return failure
+		}
+		return nil
+	}), indexer.Config{})
+
+	require.ErrorIs(t, idx.Run(context.Background()), failure)
+}
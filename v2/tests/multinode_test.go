@@ -0,0 +1,107 @@
+package tests_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// fakeNode a node serving GetConsensusInfo, SendBlockItem and health checks on a local port.
+type fakeNode struct {
+	pb.UnimplementedQueriesServer
+	pb.UnimplementedHealthServer
+
+	lastFinal uint64
+	requests  atomic.Int32
+	sent      atomic.Int32
+}
+
+func (n *fakeNode) Check(context.Context, *pb.NodeHealthRequest) (*pb.NodeHealthResponse, error) {
+	return &pb.NodeHealthResponse{}, nil
+}
+
+func (n *fakeNode) GetConsensusInfo(context.Context, *pb.Empty) (*pb.ConsensusInfo, error) {
+	n.requests.Add(1)
+	return &pb.ConsensusInfo{LastFinalizedBlockHeight: &pb.AbsoluteBlockHeight{Value: n.lastFinal}}, nil
+}
+
+func (n *fakeNode) SendBlockItem(context.Context, *pb.SendBlockItemRequest) (*pb.TransactionHash, error) {
+	n.sent.Add(1)
+	return &pb.TransactionHash{Value: make([]byte, 32)}, nil
+}
+
+// startQueriesServer serves node on a local port, including health checks if node implements them.
+func startQueriesServer(t *testing.T, node pb.QueriesServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	pb.RegisterQueriesServer(server, node)
+	if health, ok := node.(pb.HealthServer); ok {
+		pb.RegisterHealthServer(server, health)
+	}
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+// unusedAddress returns the address of a local port nothing listens on.
+func unusedAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return address
+}
+
+func TestMultiNodeClient(t *testing.T) {
+	ahead, behind := &fakeNode{lastFinal: 100}, &fakeNode{lastFinal: 80}
+	down := unusedAddress(t)
+	client, err := v2.NewClient(v2.Config{
+		NodeAddress:         down,
+		NodeAddresses:       []string{startQueriesServer(t, ahead), startQueriesServer(t, behind)},
+		BroadcastBlockItems: true,
+		HealthCheckInterval: time.Hour,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// requests fail over from the node that is down, even before it is found unhealthy.
+	for i := 0; i < 3; i++ {
+		_, err = client.GetConsensusInfo(ctx)
+		require.NoError(t, err)
+	}
+
+	statuses := client.CheckNodes(ctx)
+	require.Len(t, statuses, 3)
+	require.Equal(t, down, statuses[0].Address)
+	require.False(t, statuses[0].Healthy)
+	require.True(t, statuses[1].Healthy)
+	require.Equal(t, uint64(100), statuses[1].LastFinalizedHeight.Value)
+	require.False(t, statuses[2].Healthy)
+
+	// only the healthy node serves requests.
+	before := behind.requests.Load()
+	for i := 0; i < 3; i++ {
+		info, err := client.GetConsensusInfo(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), info.LastFinalizedBlockHeight.Value)
+	}
+	require.Equal(t, before, behind.requests.Load())
+
+	// block items are broadcast to every node that is up.
+	_, err = client.SendBlockItem(ctx, &pb.SendBlockItemRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), ahead.sent.Load())
+	require.Equal(t, int32(1), behind.sent.Load())
+}
@@ -0,0 +1,153 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+	"github.com/Concordium/concordium-go-sdk/v2/testnode"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+func TestClassifySendError(t *testing.T) {
+	err := v2.ClassifySendError(status.Error(codes.InvalidArgument, "Duplicate nonce"))
+	require.ErrorIs(t, err, v2.ErrDuplicateNonce)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = v2.ClassifySendError(status.Error(codes.InvalidArgument, "Nonce too large"))
+	require.ErrorIs(t, err, v2.ErrNonceTooLarge)
+	require.NotErrorIs(t, err, v2.ErrDuplicateNonce)
+
+	other := status.Error(codes.Unavailable, "connection refused")
+	require.Equal(t, other, v2.ClassifySendError(other))
+	require.Nil(t, v2.ClassifySendError(nil))
+}
+
+// lossyNode a testnode.Node that delays block items and drops every third of them with codes.Unavailable, so that
+// concurrent senders see failures while other sequence numbers are in flight. It counts the block items rejected
+// because their sequence number was already used.
+type lossyNode struct {
+	*testnode.Node
+
+	sends      atomic.Int32
+	duplicates atomic.Int32
+}
+
+func (n *lossyNode) SendBlockItem(ctx context.Context, req *pb.SendBlockItemRequest) (*pb.TransactionHash, error) {
+	count := n.sends.Add(1)
+	time.Sleep(time.Duration(count%4) * time.Millisecond)
+	if count%3 == 0 {
+		return nil, status.Error(codes.Unavailable, "connection reset")
+	}
+	hash, err := n.Node.SendBlockItem(ctx, req)
+	if errors.Is(v2.ClassifySendError(err), v2.ErrDuplicateNonce) {
+		n.duplicates.Add(1)
+	}
+	return hash, err
+}
+
+func TestNonceManagerConcurrentSend(t *testing.T) {
+	const senders = 20
+	node := &lossyNode{Node: testnode.New()}
+	defer node.Close()
+
+	alice, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	bob, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(alice, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	_, err = node.AddAccount(bob, v2.Amount{})
+	require.NoError(t, err)
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	wallet := v2.NewWalletAccount(alice, *keyPair)
+
+	client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	nonces := v2.NewNonceManager(client, alice)
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// the sender retries until its transfer is accepted.
+			for ctx.Err() == nil {
+				_, err := nonces.Send(ctx, func(nonce v2.SequenceNumber) (*v2.AccountTransaction, error) {
+					return construct.Transfer(1, alice, nonce, v2.TransactionTime{Value: 1 << 40}, bob, v2.Amount{Value: 1}).Sign(wallet)
+				})
+				if err == nil {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, ctx.Err())
+	require.Zero(t, node.duplicates.Load())
+
+	node.BakeBlock()
+	balance, ok := node.Balance(bob)
+	require.True(t, ok)
+	require.Equal(t, uint64(senders), balance.Value)
+	next, err := client.GetNextAccountSequenceNumber(ctx, &alice)
+	require.NoError(t, err)
+	require.Equal(t, uint64(senders+1), next.SequenceNumber.Value)
+}
+
+func TestNonceManagerResync(t *testing.T) {
+	node := testnode.New()
+	defer node.Close()
+	alice, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	bob, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(alice, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	wallet := v2.NewWalletAccount(alice, *keyPair)
+
+	client, err := node.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	nonces := v2.NewNonceManager(client, alice)
+	var sent []*v2.TransactionHash
+	for i := 0; i < 2; i++ {
+		hash, err := nonces.Send(ctx, func(nonce v2.SequenceNumber) (*v2.AccountTransaction, error) {
+			return construct.Transfer(1, alice, nonce, v2.TransactionTime{Value: 1 << 40}, bob, v2.Amount{Value: 1}).Sign(wallet)
+		})
+		require.NoError(t, err)
+		sent = append(sent, hash)
+	}
+
+	syncStatus, err := nonces.Resync(ctx)
+	require.NoError(t, err)
+	require.False(t, syncStatus.AllFinal)
+	require.Equal(t, uint64(3), syncStatus.Next.Value)
+	require.ElementsMatch(t, sent, syncStatus.NonFinalized)
+
+	node.BakeBlock()
+	node.Finalize()
+	syncStatus, err = nonces.Resync(ctx)
+	require.NoError(t, err)
+	require.True(t, syncStatus.AllFinal)
+	require.Nil(t, syncStatus.NonFinalized)
+}
@@ -172,4 +172,220 @@ func TestPayloads(t *testing.T) {
 		require.Equal(t, encodedDeployModulePayload.Size(), newEncodedDeployModulePayload.Size())
 		require.Equal(t, encodedDeployModulePayload.Value, newEncodedDeployModulePayload.Value)
 	})
+	t.Run("configureBaker encode/decode", func(t *testing.T) {
+		restakeEarnings := true
+		openStatus := v2.OpenStatusOpenForAll
+		metadataUrl := "https://example.com/baker.json"
+		commission, err := v2.AmountFractionFromUInt32(5000)
+		require.NoError(t, err)
+		keys := &v2.BakerKeysWithProofs{
+			ElectionVerifyKey:    v2.BakerElectionVerifyKey{Value: bytes.Repeat([]byte{1}, v2.BakerElectionVerifyKeyLength)},
+			SignatureVerifyKey:   v2.BakerSignatureVerifyKey{Value: bytes.Repeat([]byte{2}, v2.BakerSignatureVerifyKeyLength)},
+			AggregationVerifyKey: v2.BakerAggregationVerifyKey{Value: bytes.Repeat([]byte{3}, v2.BakerAggregationVerifyKeyLength)},
+		}
+		copy(keys.ProofElection.Value[:], bytes.Repeat([]byte{4}, v2.BakerKeyProofLength))
+		copy(keys.ProofSig.Value[:], bytes.Repeat([]byte{5}, v2.BakerKeyProofLength))
+		copy(keys.ProofAggregation.Value[:], bytes.Repeat([]byte{6}, v2.BakerKeyProofLength))
+		configureBakerPayload := &v2.ConfigureBakerPayload{
+			Capital:                      amount,
+			RestakeEarnings:              &restakeEarnings,
+			OpenForDelegation:            &openStatus,
+			KeysWithProofs:               keys,
+			MetadataUrl:                  &metadataUrl,
+			TransactionFeeCommission:     &commission,
+			BakingRewardCommission:       &commission,
+			FinalizationRewardCommission: &commission,
+		}
+		encodedConfigureBakerPayload := configureBakerPayload.Encode()
+		require.NotNil(t, encodedConfigureBakerPayload)
+		require.Len(t, encodedConfigureBakerPayload.Value, configureBakerPayload.Size()+1)
+
+		newConfigureBakerPayload, err := encodedConfigureBakerPayload.Decode()
+		require.NoError(t, err)
+		require.Equal(t, v2.ConfigureBaker{Payload: configureBakerPayload}, newConfigureBakerPayload.Payload)
+
+		newEncodedConfigureBakerPayload := newConfigureBakerPayload.Payload.Encode()
+		require.Equal(t, encodedConfigureBakerPayload.Value, newEncodedConfigureBakerPayload.Value)
+
+		// only the capital is updated.
+		partialPayload := &v2.ConfigureBakerPayload{Capital: amount}
+		require.Equal(t, []byte{25, 0, 1, 0, 0, 0, 0, 0, 1, 134, 160}, partialPayload.Encode().Value)
+	})
+
+	t.Run("configureDelegation encode/decode", func(t *testing.T) {
+		restakeEarnings := false
+		for _, target := range []v2.DelegationTarget{
+			{Target: v2.DelegationTargetPassive{}},
+			{Target: v2.DelegationTargetBaker{BakerId: v2.BakerId{Value: 42}}},
+		} {
+			target := target
+			configureDelegationPayload := &v2.ConfigureDelegationPayload{
+				Capital:          amount,
+				RestakeEarnings:  &restakeEarnings,
+				DelegationTarget: &target,
+			}
+			encodedConfigureDelegationPayload := configureDelegationPayload.Encode()
+			require.Len(t, encodedConfigureDelegationPayload.Value, configureDelegationPayload.Size()+1)
+
+			newConfigureDelegationPayload, err := encodedConfigureDelegationPayload.Decode()
+			require.NoError(t, err)
+			require.Equal(t, v2.ConfigureDelegation{Payload: configureDelegationPayload}, newConfigureDelegationPayload.Payload)
+		}
+
+		_, err := (&v2.RawPayload{Value: []byte{26, 0, 8}}).Decode()
+		require.Error(t, err)
+	})
+	t.Run("transferWithSchedule encode/decode", func(t *testing.T) {
+		schedule := []v2.NewRelease{
+			{Timestamp: v2.Timestamp{Value: 1000}, Amount: v2.Amount{Value: 10}},
+			{Timestamp: v2.Timestamp{Value: 2000}, Amount: v2.Amount{Value: 20}},
+		}
+		transferWithSchedulePayload := &v2.TransferWithSchedulePayload{
+			Receiver: &receiver,
+			Schedule: schedule,
+		}
+		require.NoError(t, transferWithSchedulePayload.Validate())
+		encodedTransferWithSchedulePayload := transferWithSchedulePayload.Encode()
+		require.Len(t, encodedTransferWithSchedulePayload.Value, transferWithSchedulePayload.Size()+1)
+
+		newTransferWithSchedulePayload, err := encodedTransferWithSchedulePayload.Decode()
+		require.NoError(t, err)
+		require.Equal(t, v2.TransferWithSchedule{Payload: transferWithSchedulePayload}, newTransferWithSchedulePayload.Payload)
+
+		transferWithScheduleAndMemoPayload := &v2.TransferWithScheduleAndMemoPayload{
+			Receiver: &receiver,
+			Memo:     memo,
+			Schedule: schedule,
+		}
+		encodedTransferWithScheduleAndMemoPayload := transferWithScheduleAndMemoPayload.Encode()
+		require.Len(t, encodedTransferWithScheduleAndMemoPayload.Value, transferWithScheduleAndMemoPayload.Size()+1)
+
+		newTransferWithScheduleAndMemoPayload, err := encodedTransferWithScheduleAndMemoPayload.Decode()
+		require.NoError(t, err)
+		require.Equal(t, v2.TransferWithScheduleAndMemo{Payload: transferWithScheduleAndMemoPayload},
+			newTransferWithScheduleAndMemoPayload.Payload)
+	})
+
+	t.Run("transferWithSchedule validate", func(t *testing.T) {
+		cases := []struct {
+			schedule []v2.NewRelease
+			err      error
+		}{
+			{schedule: nil, err: v2.ErrInvalidScheduleLength},
+			{schedule: make([]v2.NewRelease, v2.MaxReleases+1), err: v2.ErrInvalidScheduleLength},
+			{schedule: []v2.NewRelease{{Timestamp: v2.Timestamp{Value: 1000}}}, err: v2.ErrZeroScheduledAmount},
+			{schedule: []v2.NewRelease{
+				{Timestamp: v2.Timestamp{Value: 1000}, Amount: v2.Amount{Value: 10}},
+				{Timestamp: v2.Timestamp{Value: 1000}, Amount: v2.Amount{Value: 10}},
+			}, err: v2.ErrScheduleNotIncreasing},
+		}
+		for _, c := range cases {
+			payload := &v2.TransferWithSchedulePayload{Receiver: &receiver, Schedule: c.schedule}
+			require.ErrorIs(t, payload.Validate(), c.err)
+		}
+	})
+	t.Run("updateCredentialKeys encode/decode", func(t *testing.T) {
+		updateCredentialKeysPayload := &v2.UpdateCredentialKeysPayload{
+			CredId: &v2.CredentialRegistrationId{Value: bytes.Repeat([]byte{7}, v2.CredentialRegistrationIdLength)},
+			Keys: &v2.CredentialPublicKeys{
+				Keys: map[v2.KeyIndex]v2.AccountVerifyKey{
+					0: {Ed25519Key: bytes.Repeat([]byte{1}, 32)},
+					2: {Ed25519Key: bytes.Repeat([]byte{2}, 32)},
+				},
+				Threshold: v2.SignatureThreshold{Value: 2},
+			},
+		}
+		encodedUpdateCredentialKeysPayload := updateCredentialKeysPayload.Encode()
+		require.Len(t, encodedUpdateCredentialKeysPayload.Value, updateCredentialKeysPayload.Size()+1)
+
+		newUpdateCredentialKeysPayload, err := encodedUpdateCredentialKeysPayload.Decode()
+		require.NoError(t, err)
+		require.Equal(t, v2.UpdateCredentialKeys{Payload: updateCredentialKeysPayload}, newUpdateCredentialKeysPayload.Payload)
+	})
+
+	t.Run("updateCredentials encode/decode", func(t *testing.T) {
+		// a credential with one key, one anonymity revoker, one revealed attribute and 5 bytes of proofs.
+		credential := []byte{1, 0, 0}
+		credential = append(credential, bytes.Repeat([]byte{1}, 32)...)
+		credential = append(credential, 1)
+		credential = append(credential, bytes.Repeat([]byte{2}, v2.CredentialRegistrationIdLength)...)
+		credential = append(credential, 0, 0, 0, 1, 1)
+		credential = append(credential, 0, 1, 0, 0, 0, 1)
+		credential = append(credential, bytes.Repeat([]byte{3}, 96)...)
+		credential = append(credential, 7, 234, 12, 7, 233, 12, 0, 1, 4, 2, 'D', 'K')
+		credential = append(credential, 0, 0, 0, 5, 1, 2, 3, 4, 5)
+
+		updateCredentialsPayload := &v2.UpdateCredentialsPayload{
+			NewCredentials: map[v2.CredentialIndex]v2.CredentialDeploymentInfo{
+				1: {Value: credential},
+				2: {Value: credential},
+			},
+			RemoveCredentialIds: []v2.CredentialRegistrationId{
+				{Value: bytes.Repeat([]byte{4}, v2.CredentialRegistrationIdLength)},
+			},
+			NewThreshold: &v2.AccountThreshold{Value: 2},
+		}
+		require.Equal(t, []uint16{1, 1}, updateCredentialsPayload.NumKeys())
+		encodedUpdateCredentialsPayload := updateCredentialsPayload.Encode()
+		require.Len(t, encodedUpdateCredentialsPayload.Value, updateCredentialsPayload.Size()+1)
+
+		newUpdateCredentialsPayload, err := encodedUpdateCredentialsPayload.Decode()
+		require.NoError(t, err)
+		require.Equal(t, v2.UpdateCredentials{Payload: updateCredentialsPayload}, newUpdateCredentialsPayload.Payload)
+
+		_, err = (&v2.RawPayload{Value: encodedUpdateCredentialsPayload.Value[:len(encodedUpdateCredentialsPayload.Value)-1]}).Decode()
+		require.Error(t, err)
+	})
+	t.Run("all payload types encode/decode", func(t *testing.T) {
+		restakeEarnings := true
+		keys := &v2.BakerKeysWithProofs{
+			ElectionVerifyKey:    v2.BakerElectionVerifyKey{Value: bytes.Repeat([]byte{1}, v2.BakerElectionVerifyKeyLength)},
+			SignatureVerifyKey:   v2.BakerSignatureVerifyKey{Value: bytes.Repeat([]byte{2}, v2.BakerSignatureVerifyKeyLength)},
+			AggregationVerifyKey: v2.BakerAggregationVerifyKey{Value: bytes.Repeat([]byte{3}, v2.BakerAggregationVerifyKeyLength)},
+		}
+		copy(keys.ProofSig.Value[:], bytes.Repeat([]byte{4}, v2.BakerKeyProofLength))
+		encryptedAmountTransferData := &v2.EncryptedAmountTransferData{
+			RemainingAmount: v2.EncryptedAmount{Value: bytes.Repeat([]byte{5}, v2.EncryptedAmountLength)},
+			TransferAmount:  v2.EncryptedAmount{Value: bytes.Repeat([]byte{6}, v2.EncryptedAmountLength)},
+			Index:           3,
+			Proof:           bytes.Repeat([]byte{7}, 100),
+		}
+
+		payloads := []v2.AccountTransactionPayload{
+			{Payload: v2.AddBaker{Payload: &v2.AddBakerPayload{
+				KeysWithProofs: keys, BakingStake: amount, RestakeEarnings: &restakeEarnings}}},
+			{Payload: v2.RemoveBaker{Payload: &v2.RemoveBakerPayload{}}},
+			{Payload: v2.UpdateBakerStake{Payload: &v2.UpdateBakerStakePayload{Stake: amount}}},
+			{Payload: v2.UpdateBakerRestakeEarnings{Payload: &v2.UpdateBakerRestakeEarningsPayload{
+				RestakeEarnings: &restakeEarnings}}},
+			{Payload: v2.UpdateBakerKeys{Payload: &v2.UpdateBakerKeysPayload{KeysWithProofs: keys}}},
+			{Payload: v2.EncryptedAmountTransfer{Payload: &v2.EncryptedAmountTransferPayload{
+				Receiver: &receiver, Data: encryptedAmountTransferData}}},
+			{Payload: v2.EncryptedAmountTransferWithMemo{Payload: &v2.EncryptedAmountTransferWithMemoPayload{
+				Receiver: &receiver, Memo: memo, Data: encryptedAmountTransferData}}},
+			{Payload: v2.TransferToEncrypted{Payload: &v2.TransferToEncryptedPayload{Amount: amount}}},
+			{Payload: v2.TransferToPublic{Payload: &v2.TransferToPublicPayload{
+				RemainingAmount: &encryptedAmountTransferData.RemainingAmount, TransferAmount: amount, Index: 3,
+				Proof: encryptedAmountTransferData.Proof}}},
+			{Payload: v2.InitContract{Payload: &v2.InitContractPayload{
+				Amount: amount, ModuleRef: moduleRef, InitName: initName, Parameter: &v2.Parameter{Value: []byte{}}}}},
+		}
+		for _, payload := range payloads {
+			encoded := payload.Payload.Encode()
+			decoded, err := encoded.Decode()
+			require.NoError(t, err)
+			require.Equal(t, payload, *decoded)
+		}
+	})
+
+	t.Run("unknown payload decode", func(t *testing.T) {
+		decoded, err := (&v2.RawPayload{Value: []byte{200, 1, 2, 3}}).Decode()
+		require.NoError(t, err)
+		require.Equal(t, v2.UnknownPayload{Type: 200, Bytes: []byte{1, 2, 3}}, decoded.Payload)
+		require.Equal(t, []byte{200, 1, 2, 3}, decoded.Payload.Encode().Value)
+
+		payloadType, err := v2.GetPayloadType(v2.AccountTransactionPayload{Payload: &v2.UnknownPayload{Type: 200}})
+		require.NoError(t, err)
+		require.Equal(t, v2.PayloadType(200), payloadType)
+	})
 }
@@ -0,0 +1,125 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/replay"
+	"github.com/Concordium/concordium-go-sdk/v2/testnode"
+)
+
+func TestReplay(t *testing.T) {
+	node := testnode.New()
+	defer node.Close()
+	alice, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(alice, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	bob, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	node.BakeBlock()
+	node.Finalize()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// record some queries against the node.
+	recorder := replay.NewRecorder()
+	config := node.Config()
+	recorder.Install(&config)
+	client, err := v2.NewClient(config)
+	require.NoError(t, err)
+	defer client.Close()
+
+	consensusInfo, err := client.GetConsensusInfo(ctx)
+	require.NoError(t, err)
+	accountInfo, err := client.GetAccountInfo(ctx, &alice, v2.BlockHashInputLastFinal{})
+	require.NoError(t, err)
+	_, err = client.GetAccountInfo(ctx, &bob, v2.BlockHashInputLastFinal{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, recorder.Save(path))
+
+	// replay them without the node.
+	fixture, err := replay.Load(path)
+	require.NoError(t, err)
+	require.Len(t, fixture.Interactions, 3)
+	server := replay.NewServer(fixture)
+	defer server.Close()
+	replayClient, err := server.NewClient()
+	require.NoError(t, err)
+	defer replayClient.Close()
+
+	replayedAccountInfo, err := replayClient.GetAccountInfo(ctx, &alice, v2.BlockHashInputLastFinal{})
+	require.NoError(t, err)
+	require.Equal(t, accountInfo, replayedAccountInfo)
+	require.Len(t, server.Unused(), 2)
+	replayedConsensusInfo, err := replayClient.GetConsensusInfo(ctx)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(consensusInfo, replayedConsensusInfo))
+	// repeated requests reuse the last matching interaction.
+	replayedAccountInfo, err = replayClient.GetAccountInfo(ctx, &alice, v2.BlockHashInputLastFinal{})
+	require.NoError(t, err)
+	require.Equal(t, accountInfo, replayedAccountInfo)
+	_, err = replayClient.GetAccountInfo(ctx, &bob, v2.BlockHashInputLastFinal{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Empty(t, server.Unused())
+
+	_, err = replayClient.GetAccountInfo(ctx, &alice, v2.BlockHashInputBest{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "+")
+	require.Len(t, server.Mismatches(), 1)
+}
+
+func TestReplayStreamOrder(t *testing.T) {
+	node := testnode.New()
+	defer node.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// the first stream is abandoned before receiving anything, the second receives a block.
+	recorder := replay.NewRecorder()
+	config := node.Config()
+	recorder.Install(&config)
+	client, err := v2.NewClient(config)
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.GetFinalizedBlocks(ctx)
+	require.NoError(t, err)
+	stream, err := client.GetFinalizedBlocks(ctx)
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+	node.BakeBlock()
+	node.Finalize()
+	block, err := stream.Recv()
+	require.NoError(t, err)
+
+	server := replay.NewServer(recorder.Fixture())
+	defer server.Close()
+	replayClient, err := server.NewClient()
+	require.NoError(t, err)
+	defer replayClient.Close()
+
+	// the streams are served concurrently, but matched in the order they were started.
+	for i := 0; i < 10; i++ {
+		_, err = replayClient.GetFinalizedBlocks(ctx)
+		require.NoError(t, err)
+		stream, err = replayClient.GetFinalizedBlocks(ctx)
+		require.NoError(t, err)
+		replayed, err := stream.Recv()
+		require.NoError(t, err)
+		require.True(t, proto.Equal(block, replayed))
+	}
+	require.Empty(t, server.Mismatches())
+}
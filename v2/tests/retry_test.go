@@ -0,0 +1,83 @@
+package tests_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// flakyNode fails the first requests of each kind with codes.Unavailable.
+type flakyNode struct {
+	pb.UnimplementedQueriesServer
+
+	failures      int32
+	consensusInfo atomic.Int32
+	sent          atomic.Int32
+	received      atomic.Value
+}
+
+func (n *flakyNode) GetConsensusInfo(context.Context, *pb.Empty) (*pb.ConsensusInfo, error) {
+	if n.consensusInfo.Add(1) <= n.failures {
+		return nil, status.Error(codes.Unavailable, "node is restarting")
+	}
+	return &pb.ConsensusInfo{}, nil
+}
+
+func (n *flakyNode) GetNextAccountSequenceNumber(context.Context, *pb.AccountAddress) (*pb.NextAccountSequenceNumber, error) {
+	return nil, status.Error(codes.InvalidArgument, "invalid account address")
+}
+
+// SendBlockItem accepts the block item, but reports that the node is unavailable.
+func (n *flakyNode) SendBlockItem(context.Context, *pb.SendBlockItemRequest) (*pb.TransactionHash, error) {
+	n.sent.Add(1)
+	return nil, status.Error(codes.Unavailable, "connection reset")
+}
+
+func (n *flakyNode) GetBlockItemStatus(_ context.Context, hash *pb.TransactionHash) (*pb.BlockItemStatus, error) {
+	n.received.Store(hash.Value)
+	return &pb.BlockItemStatus{Status: &pb.BlockItemStatus_Received{Received: &pb.Empty{}}}, nil
+}
+
+func TestRetryPolicy(t *testing.T) {
+	node := &flakyNode{failures: 2}
+	address := startQueriesServer(t, node)
+	policy := v2.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client, err := v2.NewClient(v2.Config{NodeAddress: address, RetryPolicy: &policy})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := context.Background()
+	_, err = client.GetConsensusInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), node.consensusInfo.Load())
+
+	_, err = client.GetNextAccountSequenceNumber(ctx, &v2.AccountAddress{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the block item is not sent again, since its status shows that the node received it.
+	tx := &v2.AccountTransaction{
+		Signature: &v2.AccountTransactionSignature{Signatures: map[uint8]*v2.AccountSignatureMap{
+			0: {Signatures: map[uint8]*v2.Signature{0: {Value: make([]byte, 64)}}},
+		}},
+		Header: &v2.AccountTransactionHeader{
+			Sender:         &v2.AccountAddress{},
+			SequenceNumber: &v2.SequenceNumber{Value: 1},
+			EnergyAmount:   &v2.Energy{Value: 501},
+			Expiry:         &v2.TransactionTime{Value: 1700000000},
+		},
+		Payload: &v2.AccountTransactionPayload{Payload: &v2.RawPayload{Value: []byte{3}}},
+	}
+	hash, err := tx.Send(ctx, client)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), node.sent.Load())
+	require.Equal(t, hash.Value[:], node.received.Load())
+}
@@ -0,0 +1,153 @@
+package tests_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/schema"
+)
+
+// schemaString serializes a string prefixed by its length as u32, as in contract schemas.
+func schemaString(s string) []byte {
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(len(s))), s...)
+}
+
+// testSchema returns a V3 schema of the contract "test" with an init function without parameter and
+// an entrypoint "transfer" with parameter, return value and error types, and an event type.
+func testSchema() []byte {
+	var b []byte
+	b = append(b, 0xff, 0xff, 3)
+	b = binary.LittleEndian.AppendUint32(b, 1)
+	b = append(b, schemaString("test")...)
+	// init: some parameter of type unit.
+	b = append(b, 1, 0, 0)
+	b = binary.LittleEndian.AppendUint32(b, 1)
+	b = append(b, schemaString("transfer")...)
+	// parameter, return value and error.
+	b = append(b, 6)
+	// parameter: struct with named fields.
+	b = append(b, 20, 0)
+	b = binary.LittleEndian.AppendUint32(b, 4)
+	b = append(b, schemaString("to")...)
+	b = append(b, 11)
+	b = append(b, schemaString("amount")...)
+	b = append(b, 10)
+	b = append(b, schemaString("ids")...)
+	b = append(b, 16, 1, 4)
+	b = append(b, schemaString("data")...)
+	b = append(b, 29, 0)
+	// return value: u64.
+	b = append(b, 5)
+	// error: enum with a variant without fields and a variant with an unnamed i128 field.
+	b = append(b, 21)
+	b = binary.LittleEndian.AppendUint32(b, 2)
+	b = append(b, schemaString("NotFound")...)
+	b = append(b, 2)
+	b = append(b, schemaString("Insufficient")...)
+	b = append(b, 1)
+	b = binary.LittleEndian.AppendUint32(b, 1)
+	b = append(b, 24)
+	// event: some tagged enum with a single variant.
+	b = append(b, 1, 31)
+	b = binary.LittleEndian.AppendUint32(b, 1)
+	b = append(b, 255)
+	b = append(b, schemaString("Minted")...)
+	b = append(b, 0)
+	b = binary.LittleEndian.AppendUint32(b, 2)
+	b = append(b, schemaString("amount")...)
+	b = append(b, 27)
+	b = binary.LittleEndian.AppendUint32(b, 37)
+	b = append(b, schemaString("duration")...)
+	b = append(b, 14)
+	return b
+}
+
+func TestSchema(t *testing.T) {
+	// a Wasm module consisting only of the custom section containing the schema.
+	sectionName := "concordium-schema"
+	section := append([]byte{byte(len(sectionName))}, sectionName...)
+	section = append(section, testSchema()...)
+	wasm := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0}
+	wasm = append(wasm, byte(len(section)&0x7f|0x80), byte(len(section)>>7))
+	wasm = append(wasm, section...)
+
+	s, err := schema.FromModuleSource(&v2.VersionedModuleSource{Module: v2.ModuleSourceV1{Value: wasm}})
+	require.NoError(t, err)
+	require.Equal(t, schema.VersionV3, s.Version)
+
+	t.Run("no schema", func(t *testing.T) {
+		_, err := schema.FromModuleSource(&v2.VersionedModuleSource{Module: v2.ModuleSourceV1{Value: wasm[:8]}})
+		require.ErrorIs(t, err, schema.ErrNoSchema)
+	})
+
+	t.Run("parameter", func(t *testing.T) {
+		receiver, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+		require.NoError(t, err)
+		value := map[string]any{
+			"to":     receiver.ToBase58(),
+			"amount": "1000000",
+			"ids":    []uint32{1, 2},
+			"data":   "cafe",
+		}
+		parameter, err := s.ReceiveParameterFromValue("test", "transfer", value)
+		require.NoError(t, err)
+
+		expected := append(receiver.Value[:], 0x40, 0x42, 0x0f, 0, 0, 0, 0, 0)
+		expected = append(expected, 2, 0, 1, 0, 0, 0, 2, 0, 0, 0, 2, 0xca, 0xfe)
+		require.Equal(t, expected, parameter.Value)
+
+		decoded, err := s.ReceiveParameterToJSON("test", "transfer", *parameter)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"to":"`+receiver.ToBase58()+`","amount":"1000000","ids":[1,2],"data":"cafe"}`,
+			string(decoded))
+
+		_, err = s.ReceiveParameterFromJSON("test", "transfer", []byte(`{"to":"invalid"}`))
+		require.Error(t, err)
+		_, err = s.ReceiveParameterFromJSON("test", "unknown", []byte(`{}`))
+		require.ErrorIs(t, err, schema.ErrEntrypointNotFound)
+
+		initParameter, err := s.InitParameterFromJSON("test", []byte(`[]`))
+		require.NoError(t, err)
+		require.Empty(t, initParameter.Value)
+	})
+
+	t.Run("return value and error", func(t *testing.T) {
+		returnValue, err := s.ReturnValueToJSON("test", "transfer", []byte{42, 0, 0, 0, 0, 0, 0, 0})
+		require.NoError(t, err)
+		require.JSONEq(t, `42`, string(returnValue))
+
+		errorValue, err := s.ErrorToJSON("test", "transfer", append([]byte{1}, bytes.Repeat([]byte{0xff}, 16)...))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"Insufficient":["-1"]}`, string(errorValue))
+
+		_, err = s.ErrorToJSON("test", "transfer", []byte{0, 0})
+		require.Error(t, err)
+	})
+
+	t.Run("event", func(t *testing.T) {
+		eventType, err := s.Event("test")
+		require.NoError(t, err)
+		event, err := eventType.SerializeJSON([]byte(`{"Minted":{"amount":"300","duration":"1d 2h 3m 4s 5ms"}}`))
+		require.NoError(t, err)
+		require.Equal(t, []byte{255, 0xac, 0x02, 0xc5, 0x07, 0x97, 0x05, 0, 0, 0, 0}, event)
+
+		var decoded struct {
+			Minted struct {
+				Amount   string `json:"amount"`
+				Duration string `json:"duration"`
+			}
+		}
+		require.NoError(t, eventType.Deserialize(event, &decoded))
+		require.Equal(t, "300", decoded.Minted.Amount)
+		require.Equal(t, "1d 2h 3m 4s 5ms", decoded.Minted.Duration)
+
+		raw, err := s.EventToJSON("test", v2.ContractEvent{Value: event})
+		require.NoError(t, err)
+		require.True(t, json.Valid(raw))
+	})
+}
@@ -0,0 +1,101 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/testnode"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+func TestTestNode(t *testing.T) {
+	node := testnode.New()
+	defer node.Close()
+
+	alice, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	bob, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(alice, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	_, err = node.AddAccount(bob, v2.Amount{})
+	require.NoError(t, err)
+
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+	wallet := v2.NewWalletAccount(alice, *keyPair)
+
+	client, err := node.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	transfer := func(nonce v2.SequenceNumber, amount uint64) (*v2.AccountTransaction, error) {
+		return construct.Transfer(1, alice, nonce, v2.TransactionTime{Value: 1 << 40}, bob, v2.Amount{Value: amount}).Sign(wallet)
+	}
+	nonces := v2.NewNonceManager(client, alice)
+	hash, err := nonces.Send(ctx, func(nonce v2.SequenceNumber) (*v2.AccountTransaction, error) {
+		return transfer(nonce, 300)
+	})
+	require.NoError(t, err)
+
+	status, err := client.GetBlockItemStatus(ctx, *hash)
+	require.NoError(t, err)
+	require.IsType(t, v2.BlockItemStatusReceived{}, status.Status)
+
+	tx, err := transfer(v2.SequenceNumber{Value: 1}, 300)
+	require.NoError(t, err)
+	_, err = tx.Send(ctx, client)
+	require.ErrorIs(t, v2.ClassifySendError(err), v2.ErrDuplicateNonce)
+
+	node.BakeBlock()
+	status, err = client.GetBlockItemStatus(ctx, *hash)
+	require.NoError(t, err)
+	require.IsType(t, v2.BlockItemStatusCommitted{}, status.Status)
+
+	best, err := client.GetAccountInfo(ctx, &bob, v2.BlockHashInputBest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(300), best.Amount.Value)
+	lastFinal, err := client.GetAccountInfo(ctx, &bob, v2.BlockHashInputLastFinal{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), lastFinal.Amount.Value)
+
+	next, err := client.GetNextAccountSequenceNumber(ctx, &alice)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), next.SequenceNumber.Value)
+	require.False(t, next.AllFinal)
+
+	node.Finalize()
+	outcome, err := client.WaitUntilFinalized(ctx, *hash)
+	require.NoError(t, err)
+	require.True(t, outcome.Outcome.IsSuccess())
+
+	// with auto finalization every block item is finalized in its own block as soon as it is received.
+	stream, err := client.GetFinalizedBlocks(ctx)
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+	node.SetAutoFinalize(true)
+	tx, err = transfer(v2.SequenceNumber{Value: 2}, 5000)
+	require.NoError(t, err)
+	hash, err = tx.Send(ctx, client)
+	require.NoError(t, err)
+
+	finalized, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), finalized.Height.Value)
+	outcome, err = client.WaitUntilFinalized(ctx, *hash)
+	require.NoError(t, err)
+	require.False(t, outcome.Outcome.IsSuccess())
+
+	balance, ok := node.Balance(alice)
+	require.True(t, ok)
+	require.Equal(t, uint64(700), balance.Value)
+}
@@ -0,0 +1,134 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+)
+
+// fakeUnlocks serves the scheduled releases and cooldowns of accounts in a single block.
+type fakeUnlocks struct {
+	pb.QueriesClient
+
+	block    []byte
+	accounts map[uint64]*pb.AccountInfo
+	// the accounts with scheduled releases and cooldowns, and in pre-cooldown and pre-pre-cooldown.
+	releases, cooldowns           []*pb.AccountPending
+	preCooldowns, prePreCooldowns []*pb.AccountIndex
+}
+
+func (f *fakeUnlocks) GetBlockInfo(context.Context, *pb.BlockHashInput, ...grpc.CallOption) (*pb.BlockInfo, error) {
+	return &pb.BlockInfo{Hash: &pb.BlockHash{Value: f.block}}, nil
+}
+
+func (f *fakeUnlocks) GetScheduledReleaseAccounts(_ context.Context, req *pb.BlockHashInput, _ ...grpc.CallOption) (pb.Queries_GetScheduledReleaseAccountsClient, error) {
+	if !bytes.Equal(req.GetGiven().GetValue(), f.block) {
+		return nil, errors.New("unexpected block")
+	}
+	return &fakePendingStream{pending: f.releases}, nil
+}
+
+func (f *fakeUnlocks) GetCooldownAccounts(context.Context, *pb.BlockHashInput, ...grpc.CallOption) (pb.Queries_GetCooldownAccountsClient, error) {
+	return &fakePendingStream{pending: f.cooldowns}, nil
+}
+
+func (f *fakeUnlocks) GetPreCooldownAccounts(context.Context, *pb.BlockHashInput, ...grpc.CallOption) (pb.Queries_GetPreCooldownAccountsClient, error) {
+	return &fakeIndexStream{indices: f.preCooldowns}, nil
+}
+
+func (f *fakeUnlocks) GetPrePreCooldownAccounts(context.Context, *pb.BlockHashInput, ...grpc.CallOption) (pb.Queries_GetPrePreCooldownAccountsClient, error) {
+	return &fakeIndexStream{indices: f.prePreCooldowns}, nil
+}
+
+func (f *fakeUnlocks) GetAccountInfo(_ context.Context, req *pb.AccountInfoRequest, _ ...grpc.CallOption) (*pb.AccountInfo, error) {
+	return f.accounts[req.GetAccountIdentifier().GetAccountIndex().GetValue()], nil
+}
+
+type fakePendingStream struct {
+	grpc.ClientStream
+	pending []*pb.AccountPending
+}
+
+func (s *fakePendingStream) Recv() (*pb.AccountPending, error) {
+	if len(s.pending) == 0 {
+		return nil, io.EOF
+	}
+	next := s.pending[0]
+	s.pending = s.pending[1:]
+	return next, nil
+}
+
+type fakeIndexStream struct {
+	grpc.ClientStream
+	indices []*pb.AccountIndex
+}
+
+func (s *fakeIndexStream) Recv() (*pb.AccountIndex, error) {
+	if len(s.indices) == 0 {
+		return nil, io.EOF
+	}
+	next := s.indices[0]
+	s.indices = s.indices[1:]
+	return next, nil
+}
+
+func TestUpcomingUnlocks(t *testing.T) {
+	second := func(s uint64) *pb.Timestamp { return &pb.Timestamp{Value: s * 1000} }
+	account := func(index uint64, releases []*pb.Release, cooldowns []*pb.Cooldown) *pb.AccountInfo {
+		return &pb.AccountInfo{
+			Address:   &pb.AccountAddress{Value: bytes.Repeat([]byte{byte(index)}, 32)},
+			Index:     &pb.AccountIndex{Value: index},
+			Schedule:  &pb.ReleaseSchedule{Schedules: releases},
+			Cooldowns: cooldowns,
+			// required by the parser of account info.
+			EncryptedBalance: &pb.EncryptedBalance{},
+		}
+	}
+	chain := &fakeUnlocks{
+		block: bytes.Repeat([]byte{7}, 32),
+		accounts: map[uint64]*pb.AccountInfo{
+			1: account(1, []*pb.Release{
+				{Timestamp: second(10), Amount: &pb.Amount{Value: 100}},
+				{Timestamp: second(30), Amount: &pb.Amount{Value: 200}},
+			}, nil),
+			2: account(2, nil, []*pb.Cooldown{
+				{EndTime: second(10), Amount: &pb.Amount{Value: 5}, Status: pb.Cooldown_COOLDOWN},
+			}),
+			3: account(3, []*pb.Release{{Timestamp: second(50), Amount: &pb.Amount{Value: 1}}}, nil),
+			4: account(4, nil, []*pb.Cooldown{
+				{EndTime: second(15), Amount: &pb.Amount{Value: 7}, Status: pb.Cooldown_PRE_COOLDOWN},
+			}),
+		},
+		releases: []*pb.AccountPending{
+			{AccountIndex: &pb.AccountIndex{Value: 1}, FirstTimestamp: second(10)},
+			{AccountIndex: &pb.AccountIndex{Value: 3}, FirstTimestamp: second(50)},
+		},
+		cooldowns:    []*pb.AccountPending{{AccountIndex: &pb.AccountIndex{Value: 2}, FirstTimestamp: second(10)}},
+		preCooldowns: []*pb.AccountIndex{{Value: 4}},
+	}
+	client := &v2.Client{GrpcClient: chain}
+
+	unlocks, err := client.UpcomingUnlocks(context.Background(), v2.BlockHashInputLastFinal{}, time.UnixMilli(20000))
+	require.NoError(t, err)
+	require.Len(t, unlocks, 3)
+
+	require.Equal(t, uint64(1), unlocks[0].AccountIndex.Value)
+	require.Equal(t, v2.UnlockKindRelease, unlocks[0].Kind)
+	require.Equal(t, uint64(100), unlocks[0].Amount.Value)
+	require.Equal(t, uint64(2), unlocks[1].AccountIndex.Value)
+	require.Equal(t, v2.UnlockKindCooldown, unlocks[1].Kind)
+	require.Equal(t, v2.CooldownStatusCooldown, unlocks[1].CooldownStatus)
+	require.Equal(t, uint64(10000), unlocks[1].Time.Value)
+	require.Equal(t, uint64(4), unlocks[2].AccountIndex.Value)
+	require.Equal(t, v2.CooldownStatusPreCooldown, unlocks[2].CooldownStatus)
+	require.Equal(t, bytes.Repeat([]byte{4}, 32), unlocks[2].Account.Value[:])
+}
@@ -0,0 +1,134 @@
+package tests_test
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/pb"
+	"github.com/Concordium/concordium-go-sdk/v2/testnode"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// restartingNode a testnode.Node whose GetBlockItemStatus fails with codes.Unavailable the given number of times, and
+// whose stream of finalized blocks is unavailable if noStream is set.
+type restartingNode struct {
+	*testnode.Node
+
+	failures atomic.Int32
+	calls    atomic.Int32
+	noStream bool
+}
+
+func (n *restartingNode) GetBlockItemStatus(ctx context.Context, req *pb.TransactionHash) (*pb.BlockItemStatus, error) {
+	n.calls.Add(1)
+	if n.failures.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "node is restarting")
+	}
+	return n.Node.GetBlockItemStatus(ctx, req)
+}
+
+func (n *restartingNode) GetFinalizedBlocks(req *pb.Empty, stream pb.Queries_GetFinalizedBlocksServer) error {
+	if n.noStream {
+		return status.Error(codes.Unavailable, "node is restarting")
+	}
+	return n.Node.GetFinalizedBlocks(req, stream)
+}
+
+// sendTransfer sends a transfer between two new accounts of the node.
+func sendTransfer(t *testing.T, node *testnode.Node, client *v2.Client) v2.TransactionHash {
+	alice, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+	bob, err := v2.AccountAddressFromBytes(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	_, err = node.AddAccount(alice, v2.Amount{Value: 1000})
+	require.NoError(t, err)
+	_, err = node.AddAccount(bob, v2.Amount{})
+	require.NoError(t, err)
+	keyPair, err := v2.NewKeyPairFromSignKey(bytes.Repeat([]byte{3}, 32))
+	require.NoError(t, err)
+
+	tx, err := construct.Transfer(1, alice, v2.SequenceNumber{Value: 1}, v2.TransactionTime{Value: 1 << 40}, bob,
+		v2.Amount{Value: 300}).Sign(v2.NewWalletAccount(alice, *keyPair))
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	hash, err := tx.Send(ctx, client)
+	require.NoError(t, err)
+	return *hash
+}
+
+func TestWaitUntilFinalizedRetries(t *testing.T) {
+	t.Run("stream", func(t *testing.T) {
+		node := &restartingNode{Node: testnode.New()}
+		defer node.Close()
+		node.failures.Store(3)
+		client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+		require.NoError(t, err)
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		hash := sendTransfer(t, node.Node, client)
+		var outcome v2.BlockItemSummaryInBlock
+		done := make(chan error, 1)
+		go func() {
+			var err error
+			outcome, err = client.WaitUntilFinalized(ctx, hash)
+			done <- err
+		}()
+
+		// every finalized block triggers a status query, the first ones fail.
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case err := <-done:
+				require.NoError(t, err)
+				require.True(t, outcome.Outcome.IsSuccess())
+				require.Greater(t, node.calls.Load(), int32(3))
+				return
+			case <-ticker.C:
+				node.BakeBlock()
+				node.Finalize()
+			}
+		}
+	})
+
+	t.Run("polling", func(t *testing.T) {
+		node := &restartingNode{Node: testnode.New(), noStream: true}
+		defer node.Close()
+		node.SetAutoFinalize(true)
+		// the first query and the first poll fail, the second poll succeeds.
+		node.failures.Store(2)
+		client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+		require.NoError(t, err)
+		defer client.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		hash := sendTransfer(t, node.Node, client)
+		outcome, err := client.WaitUntilFinalized(ctx, hash)
+		require.NoError(t, err)
+		require.True(t, outcome.Outcome.IsSuccess())
+		require.Equal(t, int32(3), node.calls.Load())
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		node := &restartingNode{Node: testnode.New(), noStream: true}
+		defer node.Close()
+		client, err := v2.NewClient(v2.Config{NodeAddress: startQueriesServer(t, node)})
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.WaitUntilFinalized(context.Background(), v2.TransactionHash{})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -6,8 +6,6 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-
-	"github.com/Concordium/concordium-go-sdk/v2/pb"
 )
 
 // TransactionHeaderSize describes size of a transaction Header. This is currently always 60 Bytes.
@@ -121,29 +119,11 @@ func (*AccountTransaction) isBlockItem() {}
 
 // Send sends BlockItem with AccountTransaction using provided Client and returns TransactionHash.
 func (accountTransaction *AccountTransaction) Send(ctx context.Context, client *Client) (*TransactionHash, error) {
-	signaturesMap := make(map[uint32]*pb.AccountSignatureMap, len(accountTransaction.Signature.Signatures))
-	for extKey, signatureMap := range accountTransaction.Signature.Signatures {
-		signatures := make(map[uint32]*pb.Signature, len(signatureMap.Signatures))
-		for innKey, signature := range signatureMap.Signatures {
-			signatures[uint32(innKey)] = &pb.Signature{Value: signature.Value}
-		}
-		signaturesMap[uint32(extKey)] = &pb.AccountSignatureMap{Signatures: signatures}
+	req, err := accountTransaction.sendBlockItemRequest()
+	if err != nil {
+		return &TransactionHash{}, err
 	}
-
-	return client.SendBlockItem(ctx, &pb.SendBlockItemRequest{
-		BlockItem: &pb.SendBlockItemRequest_AccountTransaction{AccountTransaction: &pb.AccountTransaction{
-			Signature: &pb.AccountTransactionSignature{Signatures: signaturesMap},
-			Header: &pb.AccountTransactionHeader{
-				Sender:         &pb.AccountAddress{Value: accountTransaction.Header.Sender.Value[:]},
-				SequenceNumber: &pb.SequenceNumber{Value: accountTransaction.Header.SequenceNumber.Value},
-				EnergyAmount:   &pb.Energy{Value: accountTransaction.Header.EnergyAmount.Value},
-				Expiry:         &pb.TransactionTime{Value: accountTransaction.Header.Expiry.Value},
-			},
-			Payload: &pb.AccountTransactionPayload{Payload: &pb.AccountTransactionPayload_RawPayload{
-				RawPayload: accountTransaction.Payload.Payload.Encode().Value,
-			}},
-		}},
-	})
+	return client.SendBlockItem(ctx, req)
 }
 
 // AccountTransactionSignature transaction signature.
@@ -165,6 +145,12 @@ type Signature struct {
 
 // signTransaction signs the AccountTransactionHeader and AccountTransactionPayload, construct the transaction, and return it.
 func signTransaction(signer TransactionSigner, header *AccountTransactionHeader, payload *AccountTransactionPayload) (*AccountTransaction, error) {
+	if p, ok := payload.Payload.(validatedPayload); ok {
+		if err := p.Validate(); err != nil {
+			return &AccountTransaction{}, err
+		}
+	}
+
 	hashToSign := ComputeTransactionSignHash(header, payload)
 	signature, err := signer.SignTransactionHash(hashToSign)
 	if err != nil {
@@ -178,6 +164,11 @@ func signTransaction(signer TransactionSigner, header *AccountTransactionHeader,
 	}, nil
 }
 
+// validatedPayload is implemented by payloads that can be checked for validity before they are signed.
+type validatedPayload interface {
+	Validate() error
+}
+
 // ComputeTransactionSignHash computes the transaction sign hash from an AccountTransactionHeader and AccountTransactionPayload.
 func ComputeTransactionSignHash(header *AccountTransactionHeader, payload *AccountTransactionPayload) *TransactionHash {
 	encodedPayload := payload.Payload.Encode()
@@ -0,0 +1,22 @@
+package construct
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+)
+
+// ConfigureBaker construct a transaction to add, update or remove the sender account as a baker.
+func ConfigureBaker(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, configureBaker v2.ConfigureBakerPayload) *v2.PreAccountTransaction {
+	payload := &v2.AccountTransactionPayload{Payload: &v2.ConfigureBaker{Payload: &configureBaker}}
+	cost := costs.ConfigureBakerWithoutKeys
+	if configureBaker.KeysWithProofs != nil {
+		cost = costs.ConfigureBakerWithKeys
+	}
+	energy := &v2.GivenEnergy{Energy: &v2.AddEnergy{
+		NumSigs: numSigs,
+		Energy:  cost,
+	}}
+
+	return makeTransaction(sender, nonce, expiry, energy, payload)
+}
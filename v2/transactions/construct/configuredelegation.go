@@ -0,0 +1,18 @@
+package construct
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+)
+
+// ConfigureDelegation construct a transaction to add, update or remove the sender account as a delegator.
+func ConfigureDelegation(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, configureDelegation v2.ConfigureDelegationPayload) *v2.PreAccountTransaction {
+	payload := &v2.AccountTransactionPayload{Payload: &v2.ConfigureDelegation{Payload: &configureDelegation}}
+	energy := &v2.GivenEnergy{Energy: &v2.AddEnergy{
+		NumSigs: numSigs,
+		Energy:  costs.ConfigureDelegation,
+	}}
+
+	return makeTransaction(sender, nonce, expiry, energy, payload)
+}
@@ -0,0 +1,40 @@
+package construct
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+)
+
+// TransferWithSchedule constructs a transfer transaction with a release schedule. The schedule is validated when
+// the transaction is signed.
+func TransferWithSchedule(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	receiver v2.AccountAddress, schedule []v2.NewRelease) *v2.PreAccountTransaction {
+	payload := &v2.AccountTransactionPayload{Payload: &v2.TransferWithSchedule{Payload: &v2.TransferWithSchedulePayload{
+		Receiver: &receiver,
+		Schedule: schedule,
+	}}}
+	energy := &v2.GivenEnergy{Energy: &v2.AddEnergy{
+		NumSigs: numSigs,
+		Energy:  costs.ScheduledTransfer(uint16(len(schedule))),
+	}}
+
+	return makeTransaction(sender, nonce, expiry, energy, payload)
+}
+
+// TransferWithScheduleAndMemo constructs a transfer transaction with a release schedule and a memo. The schedule
+// is validated when the transaction is signed.
+func TransferWithScheduleAndMemo(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, receiver v2.AccountAddress, schedule []v2.NewRelease, memo v2.Memo) *v2.PreAccountTransaction {
+	payload := &v2.AccountTransactionPayload{Payload: &v2.TransferWithScheduleAndMemo{
+		Payload: &v2.TransferWithScheduleAndMemoPayload{
+			Receiver: &receiver,
+			Memo:     &memo,
+			Schedule: schedule,
+		}}}
+	energy := &v2.GivenEnergy{Energy: &v2.AddEnergy{
+		NumSigs: numSigs,
+		Energy:  costs.ScheduledTransfer(uint16(len(schedule))),
+	}}
+
+	return makeTransaction(sender, nonce, expiry, energy, payload)
+}
@@ -0,0 +1,22 @@
+package construct
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+)
+
+// UpdateCredentialKeys constructs a transaction to replace the keys of the credential with the given registration ID.
+// numExistingCredentials is the number of credentials on the sender account before the update.
+func UpdateCredentialKeys(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	numExistingCredentials uint16, credId v2.CredentialRegistrationId, keys v2.CredentialPublicKeys) *v2.PreAccountTransaction {
+	payload := &v2.AccountTransactionPayload{Payload: &v2.UpdateCredentialKeys{Payload: &v2.UpdateCredentialKeysPayload{
+		CredId: &credId,
+		Keys:   &keys,
+	}}}
+	energy := &v2.GivenEnergy{Energy: &v2.AddEnergy{
+		NumSigs: numSigs,
+		Energy:  costs.UpdateCredentialKeys(numExistingCredentials, uint16(len(keys.Keys))),
+	}}
+
+	return makeTransaction(sender, nonce, expiry, energy, payload)
+}
@@ -0,0 +1,19 @@
+package construct
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+)
+
+// UpdateCredentials constructs a transaction to add and remove credentials of the sender account and to update its
+// threshold. numExistingCredentials is the number of credentials on the sender account before the update.
+func UpdateCredentials(numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	numExistingCredentials uint16, updateCredentials v2.UpdateCredentialsPayload) *v2.PreAccountTransaction {
+	payload := &v2.AccountTransactionPayload{Payload: &v2.UpdateCredentials{Payload: &updateCredentials}}
+	energy := &v2.GivenEnergy{Energy: &v2.AddEnergy{
+		NumSigs: numSigs,
+		Energy:  costs.UpdateCredentials(numExistingCredentials, updateCredentials.NumKeys()),
+	}}
+
+	return makeTransaction(sender, nonce, expiry, energy, payload)
+}
@@ -0,0 +1,111 @@
+// Package estimate constructs contract transactions with an energy amount estimated by executing them
+// on the node against the last finalized block, instead of a guessed amount.
+package estimate
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/costs"
+)
+
+const (
+	// DefaultMarginPercent is the default relative safety margin added to the estimated energy.
+	DefaultMarginPercent uint64 = 10
+	// maxEnergy is the energy the transaction is executed with during estimation. This is the maximum energy of a block.
+	// A dry run is limited to the energy the sender can pay for.
+	maxEnergy uint64 = 3_000_000
+)
+
+// ErrUnexpectedOutcome indicates that the dry-run of a transaction had an unexpected outcome.
+var ErrUnexpectedOutcome = errors.New("unexpected outcome of dry run")
+
+// Estimator estimates the energy needed by contract transactions. The energy amount of the constructed
+// transactions is the energy used by the execution plus a safety margin of MarginPercent percent and
+// MarginEnergy. The base cost for the size and signatures of the transaction is added by construct.
+type Estimator struct {
+	client *v2.Client
+	// Relative safety margin in percent of the used energy.
+	MarginPercent uint64
+	// Absolute safety margin.
+	MarginEnergy v2.Energy
+}
+
+// NewEstimator creates an Estimator with a margin of DefaultMarginPercent.
+func NewEstimator(client *v2.Client) *Estimator {
+	return &Estimator{client: client, MarginPercent: DefaultMarginPercent}
+}
+
+// UpdateContract estimates the energy needed by the update by invoking the instance with the sender as invoker
+// and returns the transaction. If the invocation fails, the error is a *v2.InvokeInstanceFailedError.
+func (e *Estimator) UpdateContract(ctx context.Context, numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, payload v2.UpdateContractPayload) (*v2.PreAccountTransaction, error) {
+	res, err := e.client.InvokeInstance(ctx, payload, v2.BlockHashInputLastFinal{}, v2.Energy{Value: maxEnergy}, &sender)
+	if err != nil {
+		return nil, err
+	}
+	success, err := v2.ParseInvokeInstanceResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return construct.UpdateContract(numSigs, sender, nonce, expiry, payload, e.withMargin(success.UsedEnergy)), nil
+}
+
+// InitContract estimates the energy needed by the initialization by dry running it and returns the transaction.
+// If the initialization is rejected, the error is the v2.RejectReason.
+//
+// The dry run is executed with the maximum energy of a block. If the balance of the sender does not cover that,
+// it is executed again with the energy the sender can pay for, and a v2.RejectReasonOutOfEnergy means that the
+// sender cannot afford the initialization.
+func (e *Estimator) InitContract(ctx context.Context, numSigs uint32, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, payload v2.InitContractPayload) (*v2.PreAccountTransaction, error) {
+	session, err := e.client.DryRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	if _, err = session.LoadBlockState(v2.BlockHashInputLastFinal{}); err != nil {
+		return nil, err
+	}
+	accountPayload := &v2.AccountTransactionPayload{Payload: &v2.InitContract{Payload: &payload}}
+	transaction := v2.DryRunTransaction{
+		Sender:       sender,
+		EnergyAmount: v2.Energy{Value: maxEnergy},
+		Payload:      accountPayload,
+	}
+	executed, err := session.RunTransaction(transaction)
+	var insufficient *v2.DryRunBalanceInsufficientError
+	if errors.As(err, &insufficient) && insufficient.AvailableAmount.Value < insufficient.RequiredAmount.Value {
+		// the required amount is the cost of maxEnergy, so the sender can pay for the same share of it.
+		hi, lo := bits.Mul64(maxEnergy, insufficient.AvailableAmount.Value)
+		affordable, _ := bits.Div64(hi, lo, insufficient.RequiredAmount.Value)
+		transaction.EnergyAmount = v2.Energy{Value: affordable}
+		executed, err = session.RunTransaction(transaction)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rejected, ok := executed.Details.Effects.(v2.TransactionRejected); ok {
+		return nil, rejected.Reason
+	}
+
+	// the energy cost includes the base cost of the dry-run transaction, which has a single signature.
+	size := v2.TransactionHeaderSize + uint64(accountPayload.Payload.Encode().Size().Value)
+	baseCost := costs.BaseCost(size, 1)
+	if executed.EnergyCost.Value < baseCost.Value {
+		return nil, ErrUnexpectedOutcome
+	}
+	used := v2.Energy{Value: executed.EnergyCost.Value - baseCost.Value}
+
+	return construct.InitContract(numSigs, sender, nonce, expiry, payload, e.withMargin(used)), nil
+}
+
+// withMargin adds the safety margin to the energy.
+func (e *Estimator) withMargin(energy v2.Energy) v2.Energy {
+	return v2.Energy{Value: energy.Value + energy.Value*e.MarginPercent/100 + e.MarginEnergy.Value}
+}
@@ -0,0 +1,12 @@
+package send
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// ConfigureBaker construct and sign a transaction to add, update or remove the sender account as a baker.
+func ConfigureBaker(signer v2.ExactSizeTransactionSigner, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, payload v2.ConfigureBakerPayload) (*v2.AccountTransaction, error) {
+	return construct.ConfigureBaker(signer.NumberOfKeys(), sender, nonce, expiry, payload).Sign(signer)
+}
@@ -0,0 +1,12 @@
+package send
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// ConfigureDelegation construct and sign a transaction to add, update or remove the sender account as a delegator.
+func ConfigureDelegation(signer v2.ExactSizeTransactionSigner, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, payload v2.ConfigureDelegationPayload) (*v2.AccountTransaction, error) {
+	return construct.ConfigureDelegation(signer.NumberOfKeys(), sender, nonce, expiry, payload).Sign(signer)
+}
@@ -0,0 +1,22 @@
+package send
+
+import (
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// TransferWithSchedule constructs and signs a transfer transaction with a release schedule. An error is returned
+// if the schedule is invalid.
+func TransferWithSchedule(signer v2.ExactSizeTransactionSigner, sender v2.AccountAddress, nonce v2.SequenceNumber,
+	expiry v2.TransactionTime, receiver v2.AccountAddress, schedule []v2.NewRelease) (*v2.AccountTransaction, error) {
+	return construct.TransferWithSchedule(signer.NumberOfKeys(), sender, nonce, expiry, receiver, schedule).Sign(signer)
+}
+
+// TransferWithScheduleAndMemo constructs and signs a transfer transaction with a release schedule and a memo.
+// An error is returned if the schedule is invalid.
+func TransferWithScheduleAndMemo(signer v2.ExactSizeTransactionSigner, sender v2.AccountAddress,
+	nonce v2.SequenceNumber, expiry v2.TransactionTime, receiver v2.AccountAddress, schedule []v2.NewRelease,
+	memo v2.Memo) (*v2.AccountTransaction, error) {
+	return construct.TransferWithScheduleAndMemo(signer.NumberOfKeys(), sender, nonce, expiry, receiver, schedule,
+		memo).Sign(signer)
+}
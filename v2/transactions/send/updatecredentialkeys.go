@@ -0,0 +1,33 @@
+package send
+
+import (
+	"context"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// UpdateCredentialKeys constructs and signs a transaction to replace the keys of the credential with the given
+// registration ID. The number of credentials on the sender account, which determines the energy cost, is looked up
+// in the last finalized block.
+func UpdateCredentialKeys(ctx context.Context, client *v2.Client, signer v2.ExactSizeTransactionSigner,
+	sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime, credId v2.CredentialRegistrationId,
+	keys v2.CredentialPublicKeys) (*v2.AccountTransaction, error) {
+	numCredentials, err := numAccountCredentials(ctx, client, sender)
+	if err != nil {
+		return &v2.AccountTransaction{}, err
+	}
+
+	return construct.UpdateCredentialKeys(signer.NumberOfKeys(), sender, nonce, expiry, numCredentials, credId,
+		keys).Sign(signer)
+}
+
+// numAccountCredentials returns the number of credentials on the account in the last finalized block.
+func numAccountCredentials(ctx context.Context, client *v2.Client, account v2.AccountAddress) (uint16, error) {
+	accountInfo, err := client.GetAccountInfo(ctx, account, v2.BlockHashInputLastFinal{})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(len(accountInfo.Creds)), nil
+}
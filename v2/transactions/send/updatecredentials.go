@@ -0,0 +1,23 @@
+package send
+
+import (
+	"context"
+
+	"github.com/Concordium/concordium-go-sdk/v2"
+	"github.com/Concordium/concordium-go-sdk/v2/transactions/construct"
+)
+
+// UpdateCredentials constructs and signs a transaction to add and remove credentials of the sender account and to
+// update its threshold. The number of credentials on the sender account, which determines the energy cost, is looked
+// up in the last finalized block.
+func UpdateCredentials(ctx context.Context, client *v2.Client, signer v2.ExactSizeTransactionSigner,
+	sender v2.AccountAddress, nonce v2.SequenceNumber, expiry v2.TransactionTime,
+	payload v2.UpdateCredentialsPayload) (*v2.AccountTransaction, error) {
+	numCredentials, err := numAccountCredentials(ctx, client, sender)
+	if err != nil {
+		return &v2.AccountTransaction{}, err
+	}
+
+	return construct.UpdateCredentials(signer.NumberOfKeys(), sender, nonce, expiry, numCredentials,
+		payload).Sign(signer)
+}
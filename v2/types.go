@@ -18,6 +18,11 @@ const (
 	TransactionHashLength = 32
 	ModuleRefLength       = 32
 	hundredThousand       = 100000
+
+	BakerElectionVerifyKeyLength    = 32
+	BakerSignatureVerifyKeyLength   = 32
+	BakerAggregationVerifyKeyLength = 96
+	BakerKeyProofLength             = 64
 )
 
 // WalletAccount an account imported from one of the supported export formats.
@@ -251,6 +256,44 @@ type isAddress interface {
 	isAddress()
 }
 
+// convertAddress converts AccountAddress or ContractAddress to *pb.Address.
+func convertAddress(address isAddress) *pb.Address {
+	var res pb.Address
+	switch k := address.(type) {
+	case *AccountAddress:
+		accountAddress := make([]byte, AccountAddressLength)
+		copy(accountAddress, k.Value[:])
+		res.Type = &pb.Address_Account{
+			Account: &pb.AccountAddress{
+				Value: accountAddress,
+			},
+		}
+	case *ContractAddress:
+		res.Type = &pb.Address_Contract{
+			Contract: &pb.ContractAddress{
+				Index:    k.Index,
+				Subindex: k.Subindex,
+			},
+		}
+	}
+
+	return &res
+}
+
+// Parses *pb.Address to either *AccountAddress or *ContractAddress.
+func parseAddress(a *pb.Address) isAddress {
+	switch v := a.GetType().(type) {
+	case *pb.Address_Account:
+		accountAddress := parseAccountAddress(v.Account)
+		return &accountAddress
+	case *pb.Address_Contract:
+		contractAddress := parseContractAddress(v.Contract)
+		return &contractAddress
+	}
+
+	return nil
+}
+
 // AccountAddress an address of an account.
 type AccountAddress struct {
 	Value [AccountAddressLength]byte
@@ -284,6 +327,13 @@ func AccountAddressFromBytes(b []byte) (AccountAddress, error) {
 	return accountAddress, nil
 }
 
+// Parses *pb.AccountAddress to AccountAddress.
+func parseAccountAddress(a *pb.AccountAddress) AccountAddress {
+	var accountAddress AccountAddress
+	copy(accountAddress.Value[:], a.GetValue())
+	return accountAddress
+}
+
 // BlockHash hash of a block. This is always 32 bytes long.
 type BlockHash struct {
 	Value [BlockHashLength]byte
@@ -325,6 +375,10 @@ type isBlockHashInput interface {
 	isBlockHashInput()
 }
 
+// BlockHashInput identifies a block in queries. It is implemented by BlockHashInputBest, BlockHashInputLastFinal,
+// BlockHashInputGiven, BlockHashInputAbsoluteHeight and BlockHashInputRelativeHeight.
+type BlockHashInput = isBlockHashInput
+
 func convertBlockHashInput(req isBlockHashInput) (_ *pb.BlockHashInput) {
 	var res *pb.BlockHashInput
 	switch v := req.(type) {
@@ -408,6 +462,13 @@ func (t *TransactionHash) Hex() string {
 	return hex.EncodeToString(t.Value[:])
 }
 
+// Parses *pb.TransactionHash to TransactionHash.
+func parseTransactionHash(h *pb.TransactionHash) TransactionHash {
+	var hash TransactionHash
+	copy(hash.Value[:], h.GetValue())
+	return hash
+}
+
 // ModuleRef a smart contract module reference. This is always 32 bytes long.
 type ModuleRef struct {
 	Value [ModuleRefLength]byte
@@ -418,6 +479,13 @@ func (m *ModuleRef) Hex() string {
 	return hex.EncodeToString(m.Value[:])
 }
 
+// Parses *pb.ModuleRef to ModuleRef.
+func parseModuleRef(m *pb.ModuleRef) ModuleRef {
+	var moduleRef ModuleRef
+	copy(moduleRef.Value[:], m.GetValue())
+	return moduleRef
+}
+
 // BlockInfo information about given block, contains height, timings, transaction count, state, etc.
 type BlockInfo struct {
 	Hash                   *BlockHash
@@ -498,36 +566,32 @@ type BlockHeight struct {
 func convertBlockInfo(b *pb.BlockInfo) *BlockInfo {
 	var hash, parentBlock, lastFinalizedBlock BlockHash
 
-	copy(hash.Value[:], b.Hash.Value)
-	copy(parentBlock.Value[:], b.ParentBlock.Value)
-	copy(lastFinalizedBlock.Value[:], b.LastFinalizedBlock.Value)
+	copy(hash.Value[:], b.GetHash().GetValue())
+	copy(parentBlock.Value[:], b.GetParentBlock().GetValue())
+	copy(lastFinalizedBlock.Value[:], b.GetLastFinalizedBlock().GetValue())
 
-	var slotTime, slotNumber uint64
-	if b.SlotTime != nil {
-		slotTime = b.SlotTime.Value
-	}
-	if b.SlotNumber != nil {
-		slotNumber = b.SlotNumber.Value
-	}
+	// the slot fields are absent from protocol version 6 and the baker is absent in genesis blocks.
+	slotTime := b.GetSlotTime().GetValue()
+	slotNumber := b.GetSlotNumber().GetValue()
 
 	return &BlockInfo{
 		Hash: &hash,
 		Height: &AbsoluteBlockHeight{
-			Value: b.Height.Value,
+			Value: b.GetHeight().GetValue(),
 		},
 		ParentBlock:        &parentBlock,
 		LastFinalizedBlock: &lastFinalizedBlock,
 		GenesisIndex: &GenesisIndex{
-			Value: b.GenesisIndex.Value,
+			Value: b.GetGenesisIndex().GetValue(),
 		},
 		EraBlockHeight: &BlockHeight{
-			Value: b.EraBlockHeight.Value,
+			Value: b.GetEraBlockHeight().GetValue(),
 		},
 		ReceiveTime: &Timestamp{
-			Value: b.ReceiveTime.Value,
+			Value: b.GetReceiveTime().GetValue(),
 		},
 		ArriveTime: &Timestamp{
-			Value: b.ArriveTime.Value,
+			Value: b.GetArriveTime().GetValue(),
 		},
 		SlotNumber: &Slot{
 			Value: slotNumber,
@@ -536,16 +600,16 @@ func convertBlockInfo(b *pb.BlockInfo) *BlockInfo {
 			Value: slotTime,
 		},
 		Baker: &BakerId{
-			Value: b.Baker.Value,
+			Value: b.GetBaker().GetValue(),
 		},
 		Finalized:        b.Finalized,
 		TransactionCount: b.TransactionCount,
 		TransactionsEnergyCost: &Energy{
-			Value: b.TransactionsEnergyCost.Value,
+			Value: b.GetTransactionsEnergyCost().GetValue(),
 		},
 		TransactionsSize: b.TransactionsSize,
 		StateHash: &StateHash{
-			Value: b.StateHash.Value,
+			Value: b.GetStateHash().GetValue(),
 		},
 		ProtocolVersion: ProtocolVersion{
 			Value: int32(b.ProtocolVersion),
@@ -561,6 +625,11 @@ type ContractAddress struct {
 
 func (c *ContractAddress) isAddress() {}
 
+// Parses *pb.ContractAddress to ContractAddress.
+func parseContractAddress(c *pb.ContractAddress) ContractAddress {
+	return ContractAddress{Index: c.GetIndex(), Subindex: c.GetSubindex()}
+}
+
 // BlockItem is account transaction or credential deployment or update instruction item.
 type BlockItem struct {
 	Hash      *TransactionHash
@@ -769,6 +838,142 @@ func (transferWithMemo TransferWithMemo) Encode() *RawPayload {
 	return transferWithMemo.Payload.Encode()
 }
 
+// AddBaker payload registering the sender account as a baker.
+type AddBaker struct {
+	Payload *AddBakerPayload
+}
+
+func (AddBaker) isAccountTransactionPayload() {}
+func (addBaker AddBaker) Encode() *RawPayload {
+	return addBaker.Payload.Encode()
+}
+
+// RemoveBaker payload removing the sender account as a baker.
+type RemoveBaker struct {
+	Payload *RemoveBakerPayload
+}
+
+func (RemoveBaker) isAccountTransactionPayload() {}
+func (removeBaker RemoveBaker) Encode() *RawPayload {
+	return removeBaker.Payload.Encode()
+}
+
+// UpdateBakerStake payload updating the stake of a baker.
+type UpdateBakerStake struct {
+	Payload *UpdateBakerStakePayload
+}
+
+func (UpdateBakerStake) isAccountTransactionPayload() {}
+func (updateBakerStake UpdateBakerStake) Encode() *RawPayload {
+	return updateBakerStake.Payload.Encode()
+}
+
+// UpdateBakerRestakeEarnings payload updating whether the earnings of a baker are restaked.
+type UpdateBakerRestakeEarnings struct {
+	Payload *UpdateBakerRestakeEarningsPayload
+}
+
+func (UpdateBakerRestakeEarnings) isAccountTransactionPayload() {}
+func (updateBakerRestakeEarnings UpdateBakerRestakeEarnings) Encode() *RawPayload {
+	return updateBakerRestakeEarnings.Payload.Encode()
+}
+
+// UpdateBakerKeys payload updating the keys of a baker.
+type UpdateBakerKeys struct {
+	Payload *UpdateBakerKeysPayload
+}
+
+func (UpdateBakerKeys) isAccountTransactionPayload() {}
+func (updateBakerKeys UpdateBakerKeys) Encode() *RawPayload {
+	return updateBakerKeys.Payload.Encode()
+}
+
+// EncryptedAmountTransfer payload of a transfer of an encrypted amount between two accounts.
+type EncryptedAmountTransfer struct {
+	Payload *EncryptedAmountTransferPayload
+}
+
+func (EncryptedAmountTransfer) isAccountTransactionPayload() {}
+func (encryptedAmountTransfer EncryptedAmountTransfer) Encode() *RawPayload {
+	return encryptedAmountTransfer.Payload.Encode()
+}
+
+// EncryptedAmountTransferWithMemo payload of a transfer of an encrypted amount between two accounts with a memo.
+type EncryptedAmountTransferWithMemo struct {
+	Payload *EncryptedAmountTransferWithMemoPayload
+}
+
+func (EncryptedAmountTransferWithMemo) isAccountTransactionPayload() {}
+func (encryptedAmountTransferWithMemo EncryptedAmountTransferWithMemo) Encode() *RawPayload {
+	return encryptedAmountTransferWithMemo.Payload.Encode()
+}
+
+// TransferToEncrypted payload of a transfer from the public to the encrypted balance.
+type TransferToEncrypted struct {
+	Payload *TransferToEncryptedPayload
+}
+
+func (TransferToEncrypted) isAccountTransactionPayload() {}
+func (transferToEncrypted TransferToEncrypted) Encode() *RawPayload {
+	return transferToEncrypted.Payload.Encode()
+}
+
+// TransferToPublic payload of a transfer from the encrypted to the public balance.
+type TransferToPublic struct {
+	Payload *TransferToPublicPayload
+}
+
+func (TransferToPublic) isAccountTransactionPayload() {}
+func (transferToPublic TransferToPublic) Encode() *RawPayload {
+	return transferToPublic.Payload.Encode()
+}
+
+// UpdateCredentialKeys payload updating the keys of a credential.
+type UpdateCredentialKeys struct {
+	Payload *UpdateCredentialKeysPayload
+}
+
+func (UpdateCredentialKeys) isAccountTransactionPayload() {}
+func (updateCredentialKeys UpdateCredentialKeys) Encode() *RawPayload {
+	return updateCredentialKeys.Payload.Encode()
+}
+
+// UpdateCredentials payload adding and removing credentials of an account.
+type UpdateCredentials struct {
+	Payload *UpdateCredentialsPayload
+}
+
+func (UpdateCredentials) isAccountTransactionPayload() {}
+func (updateCredentials UpdateCredentials) Encode() *RawPayload {
+	return updateCredentials.Payload.Encode()
+}
+
+// TransferWithSchedule payload of a transfer with a release schedule.
+type TransferWithSchedule struct {
+	Payload *TransferWithSchedulePayload
+}
+
+func (TransferWithSchedule) isAccountTransactionPayload() {}
+func (transferWithSchedule TransferWithSchedule) Encode() *RawPayload {
+	return transferWithSchedule.Payload.Encode()
+}
+func (transferWithSchedule TransferWithSchedule) Validate() error {
+	return transferWithSchedule.Payload.Validate()
+}
+
+// TransferWithScheduleAndMemo payload of a transfer with a release schedule and a memo.
+type TransferWithScheduleAndMemo struct {
+	Payload *TransferWithScheduleAndMemoPayload
+}
+
+func (TransferWithScheduleAndMemo) isAccountTransactionPayload() {}
+func (transferWithScheduleAndMemo TransferWithScheduleAndMemo) Encode() *RawPayload {
+	return transferWithScheduleAndMemo.Payload.Encode()
+}
+func (transferWithScheduleAndMemo TransferWithScheduleAndMemo) Validate() error {
+	return transferWithScheduleAndMemo.Payload.Validate()
+}
+
 // Memo a memo which can be included as part of a transfer. Max size is 256 bytes.
 type Memo struct {
 	Value []byte
@@ -784,6 +989,26 @@ func (registerData RegisterData) Encode() *RawPayload {
 	return registerData.Payload.Encode()
 }
 
+// ConfigureBaker registers the sender account as a baker, updates its baker settings or removes it.
+type ConfigureBaker struct {
+	Payload *ConfigureBakerPayload
+}
+
+func (ConfigureBaker) isAccountTransactionPayload() {}
+func (configureBaker ConfigureBaker) Encode() *RawPayload {
+	return configureBaker.Payload.Encode()
+}
+
+// ConfigureDelegation registers the sender account as a delegator, updates its delegation settings or removes it.
+type ConfigureDelegation struct {
+	Payload *ConfigureDelegationPayload
+}
+
+func (ConfigureDelegation) isAccountTransactionPayload() {}
+func (configureDelegation ConfigureDelegation) Encode() *RawPayload {
+	return configureDelegation.Payload.Encode()
+}
+
 // RegisteredData data registered on the chain with a register data transaction.
 type RegisteredData struct {
 	Value []byte
@@ -1267,7 +1492,7 @@ func AmountFractionFromUInt32(value uint32) (AmountFraction, error) {
 
 // Parses *pb.AmountFraction to AmountFraction.
 func parseAmountFraction(a *pb.AmountFraction) (AmountFraction, error) {
-	res, err := AmountFractionFromUInt32(a.PartsPerHundredThousand)
+	res, err := AmountFractionFromUInt32(a.GetPartsPerHundredThousand())
 	if err != nil {
 		return AmountFraction{}, errors.New("Error parsing AmountFraction: " + err.Error())
 	}
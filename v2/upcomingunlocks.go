@@ -0,0 +1,166 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"time"
+)
+
+// UnlockKind the kind of an amount becoming liquid.
+type UnlockKind uint8
+
+const (
+	// UnlockKindRelease the amount is released from the release schedule of the account.
+	UnlockKindRelease UnlockKind = iota
+	// UnlockKindCooldown the amount leaves cooldown after stake was removed from a baker or delegator.
+	UnlockKindCooldown
+)
+
+// Unlock an amount of an account that becomes liquid at a given time.
+type Unlock struct {
+	Account      AccountAddress
+	AccountIndex AccountIndex
+	// The time in milliseconds since the Unix epoch when the amount becomes liquid. For cooldowns it is the end of
+	// the cooldown period, after which the amount is released at the subsequent pay day. For stake in pre-cooldown
+	// or pre-pre-cooldown it is the end time expected by the node.
+	Time   Timestamp
+	Amount Amount
+	Kind   UnlockKind
+	// The status of the cooldown if Kind is UnlockKindCooldown.
+	CooldownStatus CooldownStatus
+	// The transactions that contributed the released amount if Kind is UnlockKindRelease.
+	Transactions []TransactionHash
+}
+
+// UpcomingUnlocks lists the amounts that are locked in a release schedule or in cooldown at the end of the given
+// block and become liquid no later than until, ordered by time and account index.
+//
+// It finds the accounts with GetScheduledReleaseAccounts, GetCooldownAccounts, GetPreCooldownAccounts and
+// GetPrePreCooldownAccounts, and retrieves the releases and cooldowns of each of them with GetAccountInfo, so it
+// sends a request per account with a pending unlock. All queries are made against the same block, which is
+// resolved with GetBlockInfo first.
+func (c *Client) UpcomingUnlocks(ctx context.Context, block isBlockHashInput, until time.Time) (_ []Unlock, err error) {
+	blockInfo, err := c.GetBlockInfo(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if blockInfo.Hash == nil {
+		return nil, errors.New("Error resolving block: missing block hash")
+	}
+	given := BlockHashInputGiven{Given: *blockInfo.Hash}
+	var limit Timestamp
+	if until.UnixMilli() > 0 {
+		limit.Value = uint64(until.UnixMilli())
+	}
+
+	var accounts []AccountIndex
+	seen := make(map[AccountIndex]bool)
+	add := func(index AccountIndex) {
+		if !seen[index] {
+			seen[index] = true
+			accounts = append(accounts, index)
+		}
+	}
+
+	// accounts whose first pending release or cooldown is after until have nothing to unlock.
+	releases, err := c.GetScheduledReleaseAccounts(ctx, given)
+	if err != nil {
+		return nil, err
+	}
+	if err = collectPendingAccounts(&releases, limit, add); err != nil {
+		return nil, err
+	}
+	cooldowns, err := c.GetCooldownAccounts(ctx, given)
+	if err != nil {
+		return nil, err
+	}
+	if err = collectPendingAccounts(&cooldowns, limit, add); err != nil {
+		return nil, err
+	}
+	preCooldowns, err := c.GetPreCooldownAccounts(ctx, given)
+	if err != nil {
+		return nil, err
+	}
+	if err = collectAccounts(&preCooldowns, add); err != nil {
+		return nil, err
+	}
+	prePreCooldowns, err := c.GetPrePreCooldownAccounts(ctx, given)
+	if err != nil {
+		return nil, err
+	}
+	if err = collectAccounts(&prePreCooldowns, add); err != nil {
+		return nil, err
+	}
+
+	var unlocks []Unlock
+	for _, index := range accounts {
+		info, err := c.GetAccountInfo(ctx, index, given)
+		if err != nil {
+			return nil, err
+		}
+		for _, release := range info.Schedule.Schedules {
+			if release.Timestamp.Value <= limit.Value {
+				unlocks = append(unlocks, Unlock{
+					Account:      info.Address,
+					AccountIndex: info.Index,
+					Time:         release.Timestamp,
+					Amount:       release.Amount,
+					Kind:         UnlockKindRelease,
+					Transactions: release.Transactions,
+				})
+			}
+		}
+		for _, cooldown := range info.Cooldowns {
+			if cooldown.EndTime.Value <= limit.Value {
+				unlocks = append(unlocks, Unlock{
+					Account:        info.Address,
+					AccountIndex:   info.Index,
+					Time:           cooldown.EndTime,
+					Amount:         cooldown.Amount,
+					Kind:           UnlockKindCooldown,
+					CooldownStatus: cooldown.Status,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(unlocks, func(i, j int) bool {
+		if unlocks[i].Time.Value != unlocks[j].Time.Value {
+			return unlocks[i].Time.Value < unlocks[j].Time.Value
+		}
+		return unlocks[i].AccountIndex.Value < unlocks[j].AccountIndex.Value
+	})
+	return unlocks, nil
+}
+
+// collectPendingAccounts adds the accounts of the stream whose first pending timestamp is not after limit.
+func collectPendingAccounts(stream *AccountPendingStream, limit Timestamp, add func(AccountIndex)) error {
+	for {
+		pending, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if pending.FirstTimestamp.Value <= limit.Value {
+			add(pending.AccountIndex)
+		}
+	}
+}
+
+// collectAccounts adds all accounts of the stream.
+func collectAccounts(stream *AccountIndexStream, add func(AccountIndex)) error {
+	for {
+		index, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		add(index)
+	}
+}
@@ -0,0 +1,154 @@
+package v2
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// waitUntilFinalizedMinBackoff is the initial delay between polls of the block item status
+	// when the stream of finalized blocks is not available.
+	waitUntilFinalizedMinBackoff = 1 * time.Second
+	// waitUntilFinalizedMaxBackoff is the maximum delay between polls of the block item status.
+	waitUntilFinalizedMaxBackoff = 16 * time.Second
+)
+
+// WaitUntilFinalized waits until the block item with the given hash is finalized and returns the hash of
+// the block it is finalized in together with its outcome. The outcome is also returned if the transaction
+// was rejected, use BlockItemSummary.IsSuccess to check for that.
+//
+// The status is checked every time a block is finalized. If the stream of finalized blocks fails,
+// the status is polled with exponential backoff instead. Status queries that fail with codes.Unavailable,
+// codes.ResourceExhausted or codes.DeadlineExceeded are retried at the next finalized block or poll, so that
+// the wait survives an outage of the node. Other errors are returned. The call returns when the context is cancelled.
+func (c *Client) WaitUntilFinalized(ctx context.Context, hash TransactionHash) (_ BlockItemSummaryInBlock, err error) {
+	return c.WaitUntilFinalizedWithCallback(ctx, hash, nil)
+}
+
+// WaitUntilFinalizedWithCallback works like WaitUntilFinalized, but calls onStatus every time the status of
+// the block item changes, i.e. when it is received, when it is committed to a different number of blocks
+// and when it is finalized. The callback is called from the calling goroutine and may be nil.
+func (c *Client) WaitUntilFinalizedWithCallback(
+	ctx context.Context,
+	hash TransactionHash,
+	onStatus func(BlockItemStatus),
+) (_ BlockItemSummaryInBlock, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	// closes the stream of finalized blocks when done.
+	defer cancel()
+
+	w := statusWatcher{client: c, hash: hash, onStatus: onStatus}
+
+	// the stream is opened before the first status check so that no finalized block is missed in between.
+	stream, streamErr := c.GetFinalizedBlocks(ctx)
+
+	outcome, finalized, err := w.check(ctx)
+	if finalized || !isTransientStatusError(ctx, err) {
+		return outcome, err
+	}
+
+	if streamErr == nil {
+		for {
+			if _, streamErr = stream.Recv(); streamErr != nil {
+				break
+			}
+
+			outcome, finalized, err = w.check(ctx)
+			if finalized || !isTransientStatusError(ctx, err) {
+				return outcome, err
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return BlockItemSummaryInBlock{}, ctx.Err()
+	}
+
+	// the stream failed, fall back to polling.
+	backoff := waitUntilFinalizedMinBackoff
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return BlockItemSummaryInBlock{}, ctx.Err()
+		case <-timer.C:
+		}
+
+		outcome, finalized, err = w.check(ctx)
+		if finalized || !isTransientStatusError(ctx, err) {
+			return outcome, err
+		}
+
+		backoff *= 2
+		if backoff > waitUntilFinalizedMaxBackoff {
+			backoff = waitUntilFinalizedMaxBackoff
+		}
+		timer.Reset(backoff)
+	}
+}
+
+// isTransientStatusError returns whether err is nil or a failure of a single status query that is retried
+// while waiting, which is the case for codes.Unavailable, codes.ResourceExhausted and codes.DeadlineExceeded
+// unless the context itself is done.
+func isTransientStatusError(ctx context.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// statusWatcher queries the status of a block item and reports status transitions.
+type statusWatcher struct {
+	client   *Client
+	hash     TransactionHash
+	onStatus func(BlockItemStatus)
+	// whether any status has been reported yet.
+	reported bool
+	// the number of blocks the item was committed to when the status was last reported.
+	committedTo int
+	// whether the last reported status was BlockItemStatusReceived.
+	received bool
+}
+
+// check queries the status of the block item, reports it if it changed and returns the outcome if it is finalized.
+func (w *statusWatcher) check(ctx context.Context) (BlockItemSummaryInBlock, bool, error) {
+	itemStatus, err := w.client.GetBlockItemStatus(ctx, w.hash)
+	if err != nil {
+		return BlockItemSummaryInBlock{}, false, err
+	}
+
+	changed := !w.reported
+	switch s := itemStatus.Status.(type) {
+	case BlockItemStatusReceived:
+		changed = changed || !w.received
+		w.received = true
+		w.committedTo = 0
+	case BlockItemStatusCommitted:
+		changed = changed || w.received || w.committedTo != len(s.Outcomes)
+		w.received = false
+		w.committedTo = len(s.Outcomes)
+	case BlockItemStatusFinalized:
+		if w.onStatus != nil {
+			w.onStatus(itemStatus)
+		}
+		return s.Outcome, true, nil
+	}
+
+	if changed && w.onStatus != nil {
+		w.onStatus(itemStatus)
+	}
+	w.reported = true
+
+	return BlockItemSummaryInBlock{}, false, nil
+}